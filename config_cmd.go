@@ -0,0 +1,43 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"lunch_menu/internal/config"
+)
+
+// runConfig implements the "config" subcommand:
+//
+//	lunch_menu config print [-config config.yaml]
+//
+// It prints the effective configuration (defaults, file, environment and
+// *_FILE secrets) as YAML with secrets redacted, followed by any validation
+// errors, and returns the process exit code.
+func runConfig(args []string) int {
+	if len(args) == 0 || args[0] != "print" {
+		fmt.Fprintln(os.Stderr, "usage: lunch_menu config print [-config file]")
+		return 2
+	}
+	fs := flag.NewFlagSet("config print", flag.ContinueOnError)
+	file := fs.String("config", os.Getenv("CONFIG_FILE"), "YAML or TOML config file (default $CONFIG_FILE)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	cfg, err := config.Load(*file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "config: %v\n", err)
+		return 1
+	}
+	if err := cfg.Print(os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "config: %v\n", err)
+		return 1
+	}
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "config is invalid:\n%v\n", err)
+		return 1
+	}
+	return 0
+}
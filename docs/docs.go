@@ -37,7 +37,7 @@ const docTemplate = `{
         },
         "/api/statistics": {
             "get": {
-                "description": "Returns business analytics and statistics",
+                "description": "Returns restaurant counts, the price distribution (average, median, percentiles and histogram) overall, per category and per region with its cheapest and most expensive item, and per-restaurant figures with the time each menu last changed. Prices are those in the configured currency.",
                 "produces": [
                     "application/json"
                 ],
@@ -45,36 +45,100 @@ const docTemplate = `{
                     "statistics"
                 ],
                 "summary": "Get business statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Aggregate the prices of this variant, e.g. student",
+                        "name": "price_tier",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only restaurants of this region",
+                        "name": "region",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only menu items created at or after, RFC 3339 or YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only menu items created before, RFC 3339 or YYYY-MM-DD (that day included)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Width of the price histogram buckets (default 25.00)",
+                        "name": "bucket_width",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.StandardResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.StandardResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.BusinessStatistics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/models.StandardResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/menu-items": {
+        "/export/menu-items": {
             "get": {
-                "description": "Get a paginated list of menu items",
+                "description": "Stream menu items as CSV, JSON Lines or XLSX. The format is taken from ?format= or the Accept header (CSV by default).",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "menu-items"
+                    "export"
                 ],
-                "summary": "List menu items",
+                "summary": "Export menu items",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv, jsonl or xlsx",
+                        "name": "format",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Limit",
+                        "description": "Only items of this restaurant",
+                        "name": "restaurant_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit (all rows if omitted)",
                         "name": "limit",
                         "in": "query"
                     },
@@ -89,114 +153,140 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.StandardResponse"
+                            "type": "file"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.StandardResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
-            },
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Admin only: create a new menu item",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/export/restaurants": {
+            "get": {
+                "description": "Stream active restaurants as CSV, JSON Lines or XLSX. The format is taken from ?format= or the Accept header (CSV by default).",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "menu-items"
+                    "export"
                 ],
-                "summary": "Create a new menu item",
+                "summary": "Export restaurants",
                 "parameters": [
                     {
-                        "description": "Menu Item Input",
-                        "name": "menu_item",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.MenuItem"
-                        }
+                        "type": "string",
+                        "description": "csv, jsonl or xlsx",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit (all rows if omitted)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.StandardResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.StandardResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/models.StandardResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/menu-items/{id}": {
+        "/export/stats": {
             "get": {
-                "description": "Get a menu item by ID",
+                "description": "Export per-restaurant business statistics as CSV, JSON Lines or XLSX. The format is taken from ?format= or the Accept header (CSV by default).",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "menu-items"
+                    "export"
                 ],
-                "summary": "Get a menu item",
+                "summary": "Export business statistics",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Menu Item ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "csv, jsonl or xlsx",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Aggregate the prices of this variant, e.g. student",
+                        "name": "price_tier",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only restaurants of this region",
+                        "name": "region",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only menu items created at or after, RFC 3339 or YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only menu items created before, RFC 3339 or YYYY-MM-DD (that day included)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.StandardResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.StandardResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/models.StandardResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/graphql": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin only: update an existing menu item",
+                "description": "Queries restaurants (with their menu items), menu items, business statistics and the current user (me).\nMutations create, update and delete restaurants and menu items and require a Bearer token.\nQueries deeper than 6 levels or with an estimated complexity above 1000 fields are rejected.\nErrors are reported in the GraphQL \"errors\" array with status 200.",
                 "consumes": [
                     "application/json"
                 ],
@@ -204,24 +294,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "menu-items"
+                    "graphql"
                 ],
-                "summary": "Update a menu item",
+                "summary": "GraphQL endpoint",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Menu Item ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Menu Item Input",
-                        "name": "menu_item",
+                        "description": "GraphQL request",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.MenuItem"
+                            "$ref": "#/definitions/graph.Request"
                         }
                     }
                 ],
@@ -229,90 +312,140 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.StandardResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.StandardResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/models.StandardResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Reports that the process is alive; it does not check dependencies",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.StandardResponse"
+                            "$ref": "#/definitions/models.HealthResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/import": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin only: delete a menu item",
+                "description": "Admin only: upsert restaurants (by name) and menu items (by restaurant name and item name) from CSV or JSON.\nSend either a JSON body {\"restaurants\": [...], \"menu_items\": [...]} or a multipart form with \"restaurants\" and/or \"menu_items\" files.\nAll rows are applied in a single transaction, or none are if any row is invalid.",
+                "consumes": [
+                    "application/json",
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "menu-items"
+                    "import"
                 ],
-                "summary": "Delete a menu item",
+                "summary": "Bulk import restaurants and menu items",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Menu Item ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "boolean",
+                        "description": "Validate and report without applying",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "File format (csv or json); detected from the file extension if omitted",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Restaurants file",
+                        "name": "restaurants",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Menu items file",
+                        "name": "menu_items",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.StandardResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.StandardResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ImportReport"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.StandardResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/models.StandardResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "422": {
+                        "description": "Invalid rows, one error per invalid field (e.g. restaurants[3].email)",
                         "schema": {
-                            "$ref": "#/definitions/models.StandardResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/restaurants": {
+        "/menu-items": {
             "get": {
-                "description": "Get a paginated list of restaurants",
+                "description": "Get a paginated list of menu items",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "restaurants"
+                    "menu-items"
                 ],
-                "summary": "List restaurants",
+                "summary": "List menu items",
                 "parameters": [
                     {
                         "type": "integer",
@@ -325,19 +458,31 @@ const docTemplate = `{
                         "description": "Offset",
                         "name": "offset",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Show the prices of this variant, e.g. student",
+                        "name": "price_tier",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.RestaurantsResponse"
+                            "$ref": "#/definitions/models.StandardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
@@ -348,7 +493,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin only: create a new restaurant",
+                "description": "Admin only: create a new menu item. The price includes VAT and has at most two decimals. Optional price variants, such as a student price, need exactly one default, whose price is the item's price.",
                 "consumes": [
                     "application/json"
                 ],
@@ -356,17 +501,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "restaurants"
+                    "menu-items"
                 ],
-                "summary": "Create a new restaurant",
+                "summary": "Create a new menu item",
                 "parameters": [
                     {
-                        "description": "Restaurant Input",
-                        "name": "restaurant",
+                        "description": "Menu Item Input",
+                        "name": "menu_item",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.RestaurantInput"
+                            "$ref": "#/definitions/models.MenuItemInput"
                         }
                     }
                 ],
@@ -374,60 +519,66 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.RestaurantResponse"
+                            "$ref": "#/definitions/models.StandardResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/restaurants/{id}": {
+        "/menu-items/{id}": {
             "get": {
-                "description": "Get a restaurant by ID",
+                "description": "Get a menu item by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "restaurants"
+                    "menu-items"
                 ],
-                "summary": "Get a restaurant",
+                "summary": "Get a menu item",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Restaurant ID",
+                        "description": "Menu Item ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Show the price of this variant, e.g. student",
+                        "name": "price_tier",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.RestaurantResponse"
+                            "$ref": "#/definitions/models.StandardResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
@@ -438,7 +589,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin only: update an existing restaurant",
+                "description": "Admin only: update an existing menu item. Prices replace all price variants; a new price of an item with variants is that of its default variant.",
                 "consumes": [
                     "application/json"
                 ],
@@ -446,24 +597,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "restaurants"
+                    "menu-items"
                 ],
-                "summary": "Update a restaurant",
+                "summary": "Update a menu item",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Restaurant ID",
+                        "description": "Menu Item ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Restaurant Input",
-                        "name": "restaurant",
+                        "description": "Menu Item Input",
+                        "name": "menu_item",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.RestaurantInput"
+                            "$ref": "#/definitions/models.MenuItemUpdateInput"
                         }
                     }
                 ],
@@ -471,25 +622,25 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.RestaurantResponse"
+                            "$ref": "#/definitions/models.StandardResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
@@ -500,18 +651,18 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin only: delete a restaurant",
+                "description": "Admin only: delete a menu item",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "restaurants"
+                    "menu-items"
                 ],
-                "summary": "Delete a restaurant",
+                "summary": "Delete a menu item",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Restaurant ID",
+                        "description": "Menu Item ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -527,38 +678,230 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/restaurants/{id}/menu": {
+        "/menu-items/{id}/prices": {
             "get": {
-                "description": "Get a paginated list of menu items for a specific restaurant",
+                "description": "Returns the prices a menu item has had, oldest first, the current one and the scheduled future changes",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "menu-items"
                 ],
-                "summary": "Get menu items for a restaurant",
+                "summary": "Get the price history of a menu item",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Restaurant ID",
+                        "description": "Menu Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.StandardResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PriceHistoryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin only: schedule a new price of a menu item that takes effect at effective_from, which must be in the future. Use PUT /menu-items/{id} to change the price now.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "menu-items"
+                ],
+                "summary": "Schedule a price change",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Menu Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Price change",
+                        "name": "change",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PriceChangeInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.StandardResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PriceChange"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/menu-items/{id}/prices/{change_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin only: delete a price change that has not taken effect yet",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "menu-items"
+                ],
+                "summary": "Cancel a scheduled price change",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Menu Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Price change ID",
+                        "name": "change_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/menu-items/{id}/reviews": {
+            "get": {
+                "description": "Returns a page of the visible reviews of a menu item, newest first, and its average rating",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "List the reviews of a menu item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Menu Item ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -580,27 +923,42 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.StandardResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.StandardResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ReviewsResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.StandardResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/models.StandardResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/user/login": {
+            },
             "post": {
-                "description": "Login as admin user and receive JWT",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Customers only: rate a menu item from 1 to 5 with an optional comment, once per day",
                 "consumes": [
                     "application/json"
                 ],
@@ -608,65 +966,151 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "reviews"
                 ],
-                "summary": "Login as admin",
+                "summary": "Review a menu item",
                 "parameters": [
                     {
-                        "description": "Login Input",
-                        "name": "user",
+                        "type": "integer",
+                        "description": "Menu Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review",
+                        "name": "review",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UserLoginInput"
+                            "$ref": "#/definitions/models.ReviewInput"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.SafeUser"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.StandardResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Review"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/user/logout": {
-            "post": {
-                "description": "Logout the current user and expire tokens",
+        "/readyz": {
+            "get": {
+                "description": "Checks the database connection, pending migrations and the configuration.\nReturns 503 with the per-check status if any check fails.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "health"
                 ],
-                "summary": "Logout user",
+                "summary": "Readiness probe",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.StandardResponse"
+                            "$ref": "#/definitions/models.HealthResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.HealthResponse"
                         }
                     }
                 }
             }
         },
-        "/user/register": {
+        "/restaurants": {
+            "get": {
+                "description": "Get a paginated list of restaurants",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurants"
+                ],
+                "summary": "List restaurants",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RestaurantsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "Register a new admin user (admin only)",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin only: create a new restaurant",
                 "consumes": [
                     "application/json"
                 ],
@@ -674,17 +1118,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "restaurants"
                 ],
-                "summary": "Register a new admin user",
+                "summary": "Create a new restaurant",
                 "parameters": [
                     {
-                        "description": "User Input",
-                        "name": "user",
+                        "description": "Restaurant Input",
+                        "name": "restaurant",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UserInput"
+                            "$ref": "#/definitions/models.RestaurantInput"
                         }
                     }
                 ],
@@ -692,162 +1136,3249 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.SafeUser"
+                            "$ref": "#/definitions/models.RestaurantResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.ErrorResponse": {
+        },
+        "/restaurants/{id}": {
+            "get": {
+                "description": "Get a restaurant by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurants"
+                ],
+                "summary": "Get a restaurant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Restaurant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RestaurantResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin only: update an existing restaurant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurants"
+                ],
+                "summary": "Update a restaurant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Restaurant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Restaurant Update Input",
+                        "name": "restaurant",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RestaurantUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RestaurantResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin only: delete a restaurant",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurants"
+                ],
+                "summary": "Delete a restaurant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Restaurant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurants/{id}/menu": {
+            "get": {
+                "description": "Get a paginated list of menu items for a specific restaurant",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "menu-items"
+                ],
+                "summary": "Get menu items for a restaurant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Restaurant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Show the prices of this variant, e.g. student",
+                        "name": "price_tier",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurants/{id}/reviews": {
+            "get": {
+                "description": "Returns a page of the visible reviews of a restaurant, newest first, and its average rating",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "List the reviews of a restaurant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Restaurant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.StandardResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ReviewsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Customers only: rate a restaurant from 1 to 5 with an optional comment, once per day",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Review a restaurant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Restaurant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.StandardResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Review"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin only: a page of all reviews, also hidden ones, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "List reviews for moderation",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only flagged (true) or unflagged (false) reviews",
+                        "name": "flagged",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only hidden (true) or visible (false) reviews",
+                        "name": "hidden",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "restaurant or menu_item",
+                        "name": "target_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.StandardResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ModerationReviewsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the rating or comment of one of your own reviews",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Edit a review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Changes",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.StandardResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Review"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete one of your own reviews",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Delete a review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{id}/moderation": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin only: hide a review from the listings and ratings, flag it for a closer look, or undo either",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Moderate a review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Moderation",
+                        "name": "moderation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewModerationInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.StandardResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Review"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/price-trends": {
+            "get": {
+                "description": "Returns the average price of the menu items per restaurant, region or category at the end of each day, week, month or year, from the price history. Only prices in the configured currency are included.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "statistics"
+                ],
+                "summary": "Get price trends",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "restaurant, region (default) or category",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "day, week, month (default) or year",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start, RFC 3339 or YYYY-MM-DD (default: a year before to)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End, RFC 3339 or YYYY-MM-DD (default: now)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.StandardResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PriceTrends"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/trending": {
+            "get": {
+                "description": "Returns the most viewed active restaurants and available menu items of the last day or week, from the anonymous views of GET /restaurants/{id}, /restaurants/{id}/menu and /menu-items/{id}. The views of a restaurant include those of its menu and menu items. Views are written in batches, so the latest ones may be missing for a few seconds.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "statistics"
+                ],
+                "summary": "Get trending restaurants and dishes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "day (default, the last 24 hours) or week (the last 7 days)",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Restaurants and menu items to return (default 10, at most 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.StandardResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Trending"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/stream": {
+            "get": {
+                "description": "Server-Sent Events stream of restaurant and menu item change events.\nEach event has the event type as name, the event ID as id and a JSON body.\nReconnecting clients send Last-Event-ID (or ?last_event_id=) to receive the events they missed, as far as they are still in the recent history.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "stream"
+                ],
+                "summary": "Stream live menu updates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only events of restaurants in this region",
+                        "name": "region",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only events of this restaurant",
+                        "name": "restaurant_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Resume after this event ID (alternative to the Last-Event-ID header)",
+                        "name": "last_event_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "event stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/login": {
+            "post": {
+                "description": "Login as an admin or customer user and receive JWT",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Login",
+                "parameters": [
+                    {
+                        "description": "Login Input",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserLoginInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SafeUser"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/logout": {
+            "post": {
+                "description": "Logout the current user and expire tokens",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Logout user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/me/favorites": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns your favorite restaurants and menu items, oldest first; deleted ones are left out",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List your favorites",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.StandardResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Favorites"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/me/favorites/menu-items/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a menu item to your favorites; starring it again has no effect",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Star a menu item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Menu item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.StandardResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Favorite"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a menu item from your favorites",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Unstar a menu item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Menu item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/me/favorites/restaurants/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a restaurant to your favorites; starring it again has no effect",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Star a restaurant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Restaurant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.StandardResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Favorite"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a restaurant from your favorites",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Unstar a restaurant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Restaurant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/me/feed": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the menu items available today at active restaurants, ranked for you: your favorite menu items first, then the items of your favorite restaurants, those in your dietary preferences and those in your home region, and then the rest. Each item lists the reasons it is ranked first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get your lunch feed",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Items to return (default 10, at most 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Price variant to return as price, e.g. student; items without it keep their default price",
+                        "name": "price_tier",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.StandardResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.FeedResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/me/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns your home region and dietary preferences, the menu item categories your feed ranks first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get your preferences",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.StandardResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UserPreferences"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set your home region or your dietary preferences; an empty home region or list clears them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update your preferences",
+                "parameters": [
+                    {
+                        "description": "Changes",
+                        "name": "preferences",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserPreferencesInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.StandardResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UserPreferences"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/register": {
+            "post": {
+                "description": "Register a new customer; registering an admin requires the Bearer token of an admin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "User Input",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.SafeUser"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin only: get a paginated list of webhook subscriptions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhook subscriptions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.StandardResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.WebhookSubscriptionsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin only: subscribe a URL to restaurant and menu item change events (\"*\" for all).\nThe secret is used to sign deliveries (X-Webhook-Signature) and is only returned on creation; one is generated if omitted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Create a webhook subscription",
+                "parameters": [
+                    {
+                        "description": "Webhook Subscription Input",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSubscriptionInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.StandardResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.WebhookSubscription"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin only: get a webhook subscription by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get a webhook subscription",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.StandardResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.WebhookSubscription"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin only: update the URL, event types, secret or active flag of a webhook subscription",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Update a webhook subscription",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook Subscription Update Input",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSubscriptionUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.StandardResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.WebhookSubscription"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin only: delete a webhook subscription; its delivery log is kept",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete a webhook subscription",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin only: get the delivery log of a webhook subscription, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending, succeeded, dead or skipped",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.StandardResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.WebhookDeliveriesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries/{delivery_id}/retry": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin only: reset a (typically dead or skipped) delivery to pending so that it is attempted again",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Retry a webhook delivery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.StandardResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.WebhookDelivery"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "graph.Request": {
+            "type": "object",
+            "properties": {
+                "operationName": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "models.BusinessStatistics": {
+            "type": "object",
+            "properties": {
+                "active_restaurants": {
+                    "type": "integer"
+                },
+                "average_price": {
+                    "description": "AveragePrice is Prices.Average",
+                    "type": "number"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CategoryStatistics"
+                    }
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "SEK"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "generated_at": {
+                    "description": "when the statistics were computed; they may be served from a cache",
+                    "type": "string"
+                },
+                "inactive_restaurants": {
+                    "type": "integer"
+                },
+                "last_menu_update": {
+                    "description": "latest menu change of the restaurants, null without menu items",
+                    "type": "string"
+                },
+                "price_histogram": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.HistogramBucket"
+                    }
+                },
+                "price_tier": {
+                    "type": "string",
+                    "example": "student"
+                },
+                "prices": {
+                    "$ref": "#/definitions/models.PriceSummary"
+                },
+                "region": {
+                    "type": "string",
+                    "example": "Solna"
+                },
+                "regions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RegionStatistics"
+                    }
+                },
+                "restaurant_details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RestaurantBusinessData"
+                    }
+                },
+                "stale_restaurants": {
+                    "description": "active restaurants whose menu was not updated within StaleMenuAge",
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "total_menu_items": {
+                    "type": "integer"
+                },
+                "total_restaurants": {
+                    "type": "integer"
+                },
+                "total_views": {
+                    "description": "of the active restaurants, see RestaurantBusinessData.Views",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CategoryStatistics": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "Main"
+                },
+                "menu_items": {
+                    "type": "integer"
+                },
+                "prices": {
+                    "$ref": "#/definitions/models.PriceSummary"
+                }
+            }
+        },
+        "models.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Favorite": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "target_id": {
+                    "type": "integer"
+                },
+                "target_type": {
+                    "type": "string",
+                    "example": "restaurant"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Favorites": {
+            "type": "object",
+            "properties": {
+                "menu_items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MenuItem"
+                    }
+                },
+                "restaurants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Restaurant"
+                    }
+                }
+            }
+        },
+        "models.FeedItem": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "SEK"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_available": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "description": "including VAT",
+                    "type": "number",
+                    "example": 129
+                },
+                "price_tier": {
+                    "description": "PriceTier is the variant whose price is in Price, set by ApplyPriceTier\nwhen a client asks for a price tier",
+                    "type": "string",
+                    "example": "student"
+                },
+                "prices": {
+                    "description": "Prices are the named price variants of the item, e.g. the student\nprice; the default variant's price is also Price",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MenuItemPrice"
+                    }
+                },
+                "rating": {
+                    "description": "Rating summarizes the visible reviews of the item, set for API responses",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.RatingSummary"
+                        }
+                    ]
+                },
+                "reasons": {
+                    "description": "why the item is ranked first, empty for the others",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "region": {
+                    "type": "string"
+                },
+                "restaurant_id": {
+                    "type": "integer"
+                },
+                "restaurant_name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "vat": {
+                    "description": "VAT is the VAT breakdown of the price, set by SetVAT for API responses",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/money.VAT"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.FeedResponse": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2026-10-19"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FeedItem"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.HealthCheck": {
+            "type": "object",
+            "properties": {
+                "duration_ms": {
+                    "type": "number"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "ok or failed",
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "models.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.HealthCheck"
+                    }
+                },
+                "status": {
+                    "description": "ok or unavailable",
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "models.HistogramBucket": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "number",
+                    "example": 100
+                },
+                "to": {
+                    "type": "number",
+                    "example": 125
+                }
+            }
+        },
+        "models.ImportCounts": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ImportReport": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "boolean"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImportRowError"
+                    }
+                },
+                "menu_items": {
+                    "$ref": "#/definitions/models.ImportCounts"
+                },
+                "restaurants": {
+                    "$ref": "#/definitions/models.ImportCounts"
+                }
+            }
+        },
+        "models.ImportRowError": {
+            "type": "object",
+            "properties": {
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "kind": {
+                    "description": "\"restaurants\" or \"menu_items\"",
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.MenuItem": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "SEK"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_available": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "description": "including VAT",
+                    "type": "number",
+                    "example": 129
+                },
+                "price_tier": {
+                    "description": "PriceTier is the variant whose price is in Price, set by ApplyPriceTier\nwhen a client asks for a price tier",
+                    "type": "string",
+                    "example": "student"
+                },
+                "prices": {
+                    "description": "Prices are the named price variants of the item, e.g. the student\nprice; the default variant's price is also Price",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MenuItemPrice"
+                    }
+                },
+                "rating": {
+                    "description": "Rating summarizes the visible reviews of the item, set for API responses",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.RatingSummary"
+                        }
+                    ]
+                },
+                "restaurant_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "vat": {
+                    "description": "VAT is the VAT breakdown of the price, set by SetVAT for API responses",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/money.VAT"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.MenuItemInput": {
+            "type": "object",
+            "required": [
+                "name",
+                "restaurant_id"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "defaults to pricing.currency",
+                    "type": "string",
+                    "example": "SEK"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "is_available": {
+                    "description": "pointer to allow \"not set\"",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "description": "at most 2 decimals; defaults to the default variant's price",
+                    "type": "number",
+                    "example": 129
+                },
+                "prices": {
+                    "description": "Prices are optional price variants, one of them the default",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MenuItemPrice"
+                    }
+                },
+                "restaurant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.MenuItemPrice": {
+            "type": "object",
+            "properties": {
+                "is_default": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "description": "including VAT",
+                    "type": "number",
+                    "example": 99
+                },
+                "tier": {
+                    "type": "string",
+                    "example": "student"
+                }
+            }
+        },
+        "models.MenuItemUpdateInput": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "is_available": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "prices": {
+                    "description": "Prices replace all price variants of the item; an empty list removes them",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MenuItemPrice"
+                    }
+                },
+                "restaurant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ModerationReviewsResponse": {
+            "type": "object",
+            "properties": {
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Review"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PriceChange": {
+            "type": "object",
+            "properties": {
+                "applied_at": {
+                    "description": "null while scheduled",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "SEK"
+                },
+                "effective_from": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "menu_item_id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "description": "including VAT",
+                    "type": "number",
+                    "example": 129
+                }
+            }
+        },
+        "models.PriceChangeInput": {
+            "type": "object",
+            "required": [
+                "effective_from",
+                "price"
+            ],
+            "properties": {
+                "currency": {
+                    "description": "defaults to the item's currency",
+                    "type": "string",
+                    "example": "SEK"
+                },
+                "effective_from": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                },
+                "price": {
+                    "description": "including VAT, at most 2 decimals",
+                    "type": "number",
+                    "example": 135
+                }
+            }
+        },
+        "models.PriceHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "the change in effect, null for items without history",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.PriceChange"
+                        }
+                    ]
+                },
+                "history": {
+                    "description": "applied changes, oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PriceChange"
+                    }
+                },
+                "menu_item_id": {
+                    "type": "integer"
+                },
+                "scheduled": {
+                    "description": "changes yet to take effect, soonest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PriceChange"
+                    }
+                }
+            }
+        },
+        "models.PriceSummary": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "type": "number",
+                    "example": 118.4
+                },
+                "max": {
+                    "type": "number",
+                    "example": 189
+                },
+                "median": {
+                    "type": "number",
+                    "example": 115
+                },
+                "min": {
+                    "type": "number",
+                    "example": 85
+                },
+                "p25": {
+                    "type": "number",
+                    "example": 105
+                },
+                "p75": {
+                    "type": "number",
+                    "example": 129
+                },
+                "p90": {
+                    "type": "number",
+                    "example": 145
+                },
+                "priced_items": {
+                    "description": "items priced in the statistics' currency",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PriceTrendPoint": {
+            "type": "object",
+            "properties": {
+                "average_price": {
+                    "type": "number",
+                    "example": 119.5
+                },
+                "change_percent": {
+                    "type": "number",
+                    "example": 4.2
+                },
+                "menu_items": {
+                    "type": "integer"
+                },
+                "period": {
+                    "description": "start of the period",
+                    "type": "string"
+                }
+            }
+        },
+        "models.PriceTrendSeries": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "description": "restaurant name, region or category",
+                    "type": "string",
+                    "example": "Solna"
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PriceTrendPoint"
+                    }
+                },
+                "restaurant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PriceTrends": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "SEK"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "group_by": {
+                    "type": "string",
+                    "example": "region"
+                },
+                "interval": {
+                    "type": "string",
+                    "example": "month"
+                },
+                "series": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PriceTrendSeries"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PricedMenuItem": {
+            "type": "object",
+            "properties": {
+                "menu_item_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "restaurant_id": {
+                    "type": "integer"
+                },
+                "restaurant_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "machine-readable error code, e.g. \"NOT_FOUND\"",
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "errors": {
+                    "description": "per-field validation details",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RatingSummary": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "description": "rounded to 2 decimals, 0 without reviews",
+                    "type": "number",
+                    "example": 4.25
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "models.RegionStatistics": {
+            "type": "object",
+            "properties": {
+                "cheapest": {
+                    "description": "null without priced items",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.PricedMenuItem"
+                        }
+                    ]
+                },
+                "menu_items": {
+                    "type": "integer"
+                },
+                "most_expensive": {
+                    "description": "null without priced items",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.PricedMenuItem"
+                        }
+                    ]
+                },
+                "prices": {
+                    "$ref": "#/definitions/models.PriceSummary"
+                },
+                "region": {
+                    "type": "string",
+                    "example": "Solna"
+                },
+                "restaurants": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Restaurant": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "coordinate": {
+                    "description": "Store as JSONB in Postgres, see FloatArray.GormDBDataType",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "homepage": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RestaurantBusinessData": {
+            "type": "object",
+            "properties": {
+                "average_price": {
+                    "type": "number"
+                },
+                "last_menu_update": {
+                    "description": "latest change of any of its menu items, null without items",
+                    "type": "string"
+                },
+                "max_price": {
+                    "type": "number"
+                },
+                "median_price": {
+                    "type": "number"
+                },
+                "menu_item_count": {
+                    "type": "integer"
+                },
+                "min_price": {
+                    "type": "number"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "restaurant_id": {
+                    "type": "integer"
+                },
+                "restaurant_name": {
+                    "type": "string"
+                },
+                "stale": {
+                    "type": "boolean"
+                },
+                "views": {
+                    "description": "Views counts the views of the restaurant, its menu and its menu items,\nthose within the date range of the filter if it has one",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.RestaurantInput": {
+            "type": "object",
+            "required": [
+                "address",
+                "coordinate",
+                "email",
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "coordinate": {
+                    "description": "[lat, lng]",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "homepage": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "region": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RestaurantResponse": {
+            "type": "object",
+            "properties": {
+                "rating": {
+                    "description": "of the visible reviews of the restaurant",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.RatingSummary"
+                        }
+                    ]
+                },
+                "restaurant": {
+                    "$ref": "#/definitions/models.Restaurant"
+                }
+            }
+        },
+        "models.RestaurantUpdateInput": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "coordinate": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "homepage": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "region": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RestaurantsResponse": {
+            "type": "object",
+            "properties": {
+                "restaurants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Restaurant"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Review": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "example": "Generous portions"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "flagged": {
+                    "type": "boolean"
+                },
+                "hidden": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "reviewed_on": {
+                    "description": "UTC date of creation",
+                    "type": "string",
+                    "example": "2026-10-19"
+                },
+                "target_id": {
+                    "type": "integer"
+                },
+                "target_type": {
+                    "type": "string",
+                    "example": "restaurant"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ReviewInput": {
             "type": "object",
+            "required": [
+                "rating"
+            ],
             "properties": {
-                "error": {
-                    "type": "string"
+                "comment": {
+                    "type": "string",
+                    "example": "Generous portions"
                 },
-                "message": {
-                    "type": "string"
+                "rating": {
+                    "description": "1 to 5",
+                    "type": "integer",
+                    "example": 4
                 }
             }
         },
-        "models.MenuItem": {
+        "models.ReviewModerationInput": {
             "type": "object",
             "properties": {
-                "category": {
+                "flagged": {
+                    "type": "boolean"
+                },
+                "hidden": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.ReviewUpdateInput": {
+            "type": "object",
+            "properties": {
+                "comment": {
                     "type": "string"
                 },
+                "rating": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "models.ReviewsResponse": {
+            "type": "object",
+            "properties": {
+                "rating": {
+                    "$ref": "#/definitions/models.RatingSummary"
+                },
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Review"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SafeUser": {
+            "type": "object",
+            "properties": {
                 "created_at": {
                     "type": "string"
                 },
-                "description": {
+                "email": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "is_available": {
+                "is_active": {
                     "type": "boolean"
                 },
-                "name": {
+                "role": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
-                },
-                "restaurant_id": {
-                    "type": "integer"
-                },
                 "updated_at": {
                     "type": "string"
+                },
+                "username": {
+                    "type": "string"
                 }
             }
         },
-        "models.Restaurant": {
+        "models.StandardResponse": {
             "type": "object",
             "properties": {
-                "address": {
-                    "type": "string"
+                "data": {},
+                "error": {
+                    "$ref": "#/definitions/models.ErrorResponse"
                 },
-                "coordinate": {
-                    "description": "Store as JSONB in Postgres",
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Trending": {
+            "type": "object",
+            "properties": {
+                "menu_items": {
                     "type": "array",
                     "items": {
-                        "type": "number"
+                        "$ref": "#/definitions/models.TrendingMenuItem"
                     }
                 },
-                "created_at": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
+                "period": {
+                    "type": "string",
+                    "example": "day"
                 },
-                "email": {
-                    "type": "string"
+                "restaurants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TrendingRestaurant"
+                    }
                 },
-                "homepage": {
+                "since": {
                     "type": "string"
-                },
-                "id": {
+                }
+            }
+        },
+        "models.TrendingMenuItem": {
+            "type": "object",
+            "properties": {
+                "menu_item_id": {
                     "type": "integer"
                 },
-                "is_active": {
-                    "type": "boolean"
-                },
                 "name": {
                     "type": "string"
                 },
-                "phone": {
+                "restaurant_id": {
+                    "type": "integer"
+                },
+                "restaurant_name": {
                     "type": "string"
                 },
+                "views": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TrendingRestaurant": {
+            "type": "object",
+            "properties": {
                 "region": {
                     "type": "string"
                 },
-                "updated_at": {
+                "restaurant_id": {
+                    "type": "integer"
+                },
+                "restaurant_name": {
                     "type": "string"
+                },
+                "views": {
+                    "type": "integer"
                 }
             }
         },
-        "models.RestaurantInput": {
+        "models.UserInput": {
             "type": "object",
             "required": [
-                "address",
-                "coordinate",
                 "email",
-                "name"
+                "password_hash",
+                "username"
             ],
             "properties": {
-                "address": {
+                "email": {
                     "type": "string"
                 },
-                "coordinate": {
-                    "description": "[lat, lng]",
-                    "type": "array",
-                    "items": {
-                        "type": "number"
-                    }
-                },
-                "description": {
+                "password_hash": {
                     "type": "string"
                 },
-                "email": {
-                    "type": "string"
+                "role": {
+                    "description": "customer (default) or admin; only admins register admins",
+                    "type": "string",
+                    "example": "customer"
                 },
-                "homepage": {
+                "username": {
                     "type": "string"
-                },
-                "name": {
+                }
+            }
+        },
+        "models.UserLoginInput": {
+            "type": "object",
+            "required": [
+                "password_hash",
+                "username"
+            ],
+            "properties": {
+                "password_hash": {
                     "type": "string"
                 },
-                "phone": {
+                "username": {
                     "type": "string"
+                }
+            }
+        },
+        "models.UserPreferences": {
+            "type": "object",
+            "properties": {
+                "dietary": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Vegetarian"
+                    ]
                 },
-                "region": {
-                    "type": "string"
+                "home_region": {
+                    "type": "string",
+                    "example": "Solna"
                 }
             }
         },
-        "models.RestaurantResponse": {
+        "models.UserPreferencesInput": {
             "type": "object",
             "properties": {
-                "restaurant": {
-                    "$ref": "#/definitions/models.Restaurant"
+                "dietary": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "home_region": {
+                    "type": "string",
+                    "example": "Solna"
                 }
             }
         },
-        "models.RestaurantsResponse": {
+        "models.WebhookDeliveriesResponse": {
             "type": "object",
             "properties": {
-                "restaurants": {
+                "deliveries": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Restaurant"
+                        "$ref": "#/definitions/models.WebhookDelivery"
                     }
                 },
                 "total": {
@@ -855,81 +4386,150 @@ const docTemplate = `{
                 }
             }
         },
-        "models.SafeUser": {
+        "models.WebhookDelivery": {
             "type": "object",
             "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
                 "created_at": {
                     "type": "string"
                 },
-                "email": {
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "integer"
+                },
+                "event_type": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "last_error": {
+                    "type": "string"
                 },
-                "role": {
+                "next_attempt_at": {
                     "type": "string"
                 },
-                "updated_at": {
+                "response_status": {
+                    "type": "integer"
+                },
+                "status": {
                     "type": "string"
                 },
-                "username": {
+                "subscription_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "models.StandardResponse": {
+        "models.WebhookSubscription": {
             "type": "object",
             "properties": {
-                "data": {},
-                "error": {
-                    "$ref": "#/definitions/models.ErrorResponse"
+                "created_at": {
+                    "type": "string"
                 },
-                "message": {
+                "event_types": {
+                    "description": "comma-separated, \"*\" for all",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "models.UserInput": {
+        "models.WebhookSubscriptionInput": {
             "type": "object",
             "required": [
-                "email",
-                "password_hash",
-                "role",
-                "username"
+                "event_types",
+                "url"
             ],
             "properties": {
-                "email": {
-                    "type": "string"
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "password_hash": {
-                    "type": "string"
+                "is_active": {
+                    "type": "boolean"
                 },
-                "role": {
+                "secret": {
+                    "description": "generated if empty",
                     "type": "string"
                 },
-                "username": {
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "models.UserLoginInput": {
+        "models.WebhookSubscriptionUpdateInput": {
             "type": "object",
-            "required": [
-                "password_hash",
-                "username"
-            ],
             "properties": {
-                "password_hash": {
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "secret": {
                     "type": "string"
                 },
-                "username": {
+                "url": {
                     "type": "string"
                 }
             }
+        },
+        "models.WebhookSubscriptionsResponse": {
+            "type": "object",
+            "properties": {
+                "subscriptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WebhookSubscription"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "money.VAT": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 13.82
+                },
+                "price_excl_vat": {
+                    "type": "number",
+                    "example": 115.18
+                },
+                "rate": {
+                    "description": "percent",
+                    "type": "number",
+                    "example": 12
+                }
+            }
         }
     }
 }`
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"lunch_menu/internal/config"
+	"lunch_menu/internal/database"
+	"lunch_menu/internal/importer"
+	"lunch_menu/internal/models"
+)
+
+// runImport implements the "import" subcommand:
+//
+//	lunch_menu import -restaurants restaurants.csv -menu-items menu.json [-dry-run]
+//
+// It prints the import report as JSON and returns the process exit code.
+func runImport(args []string) int {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	restaurantsFile := fs.String("restaurants", "", "CSV or JSON file with restaurants")
+	menuItemsFile := fs.String("menu-items", "", "CSV or JSON file with menu items")
+	format := fs.String("format", "", "file format (csv or json); detected from the file extension if omitted")
+	dryRun := fs.Bool("dry-run", false, "validate and report without applying")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *restaurantsFile == "" && *menuItemsFile == "" {
+		fmt.Fprintln(os.Stderr, "import: at least one of -restaurants or -menu-items is required")
+		fs.Usage()
+		return 2
+	}
+
+	var input models.CatalogueImport
+	for kind, path := range map[string]string{
+		importer.KindRestaurants: *restaurantsFile,
+		importer.KindMenuItems:   *menuItemsFile,
+	} {
+		if path == "" {
+			continue
+		}
+		if err := parseImportFile(kind, *format, path, &input); err != nil {
+			fmt.Fprintf(os.Stderr, "import: %v\n", err)
+			return 1
+		}
+	}
+
+	config.MustLoadConfig()
+	if err := database.InitDatabase(); err != nil {
+		log.Fatalf("DB init failed: %v", err)
+	}
+	defer database.CloseDatabase()
+
+	report, err := database.NewImportRepository(database.DB).Import(context.Background(), &input, *dryRun)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "import: %v\n", err)
+		return 1
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(report)
+
+	if len(report.Errors) > 0 {
+		return 1
+	}
+	return 0
+}
+
+func parseImportFile(kind, format, path string, input *models.CatalogueImport) error {
+	format, err := importer.DetectFormat(format, path)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return importer.Parse(kind, format, file, input)
+}
@@ -23,17 +23,76 @@ CREATE TABLE IF NOT EXISTS menu_items (
     name VARCHAR(200) NOT NULL,
     description VARCHAR(1000),
     price DECIMAL(10,2),
+    currency VARCHAR(3) NOT NULL DEFAULT 'SEK',
     category VARCHAR(100),
     is_available BOOLEAN DEFAULT true,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
 
+CREATE TABLE IF NOT EXISTS menu_item_prices (
+    id SERIAL PRIMARY KEY,
+    menu_item_id INTEGER NOT NULL REFERENCES menu_items(id) ON DELETE CASCADE,
+    tier VARCHAR(32) NOT NULL,
+    price DECIMAL(10,2) NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT false
+);
+
+CREATE TABLE IF NOT EXISTS price_changes (
+    id SERIAL PRIMARY KEY,
+    menu_item_id INTEGER NOT NULL,
+    price DECIMAL(10,2) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    effective_from TIMESTAMP WITH TIME ZONE NOT NULL,
+    applied_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS view_events (
+    id SERIAL PRIMARY KEY,
+    kind VARCHAR(16) NOT NULL,
+    restaurant_id INTEGER NOT NULL,
+    menu_item_id INTEGER,
+    viewed_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS reviews (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    target_type VARCHAR(16) NOT NULL,
+    target_id INTEGER NOT NULL,
+    reviewed_on VARCHAR(10) NOT NULL,
+    rating INTEGER NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    comment VARCHAR(2000),
+    hidden BOOLEAN NOT NULL DEFAULT false,
+    flagged BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS favorites (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    target_type VARCHAR(16) NOT NULL,
+    target_id INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
 -- Create indexes
 CREATE INDEX IF NOT EXISTS idx_menu_items_restaurant_id ON menu_items(restaurant_id);
 CREATE INDEX IF NOT EXISTS idx_restaurants_region ON restaurants(region);
 CREATE INDEX IF NOT EXISTS idx_restaurants_is_active ON restaurants(is_active);
 CREATE INDEX IF NOT EXISTS idx_menu_items_is_available ON menu_items(is_available);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_menu_item_prices_tier ON menu_item_prices(menu_item_id, tier);
+CREATE INDEX IF NOT EXISTS idx_price_changes_item_effective ON price_changes(menu_item_id, effective_from);
+CREATE INDEX IF NOT EXISTS idx_price_changes_applied_at ON price_changes(applied_at);
+CREATE INDEX IF NOT EXISTS idx_view_events_restaurant_viewed ON view_events(restaurant_id, viewed_at);
+CREATE INDEX IF NOT EXISTS idx_view_events_menu_item_id ON view_events(menu_item_id);
+CREATE INDEX IF NOT EXISTS idx_view_events_viewed_at ON view_events(viewed_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reviews_user_target_day ON reviews(user_id, target_type, target_id, reviewed_on);
+CREATE INDEX IF NOT EXISTS idx_reviews_target ON reviews(target_type, target_id);
+CREATE INDEX IF NOT EXISTS idx_reviews_flagged ON reviews(flagged);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_favorites_user_target ON favorites(user_id, target_type, target_id);
 
 -- Insert restaurants from original backend data
 INSERT INTO restaurants (name, description, address, coordinate, homepage, region, phone, email, is_active, created_at, updated_at) VALUES
@@ -0,0 +1,86 @@
+// Package app wires the repositories used by the HTTP handlers, the
+// middleware and the GraphQL and gRPC servers.
+package app
+
+import (
+	"lunch_menu/internal/config"
+	"lunch_menu/internal/database"
+	"lunch_menu/internal/repository"
+	"lunch_menu/internal/repository/memory"
+	"lunch_menu/internal/statistics"
+	"lunch_menu/internal/views"
+
+	"gorm.io/gorm"
+)
+
+// App holds the repositories of the application
+type App struct {
+	Restaurants  repository.RestaurantRepository
+	MenuItems    repository.MenuItemRepository
+	PriceHistory repository.PriceHistoryRepository
+	Views        repository.ViewRepository
+	Reviews      repository.ReviewRepository
+	Favorites    repository.FavoriteRepository
+	Users        repository.UserRepository
+	Tokens       repository.TokenRepository
+	Imports      repository.ImportRepository
+	Webhooks     repository.WebhookRepository
+	Outbox       repository.OutboxRepository
+	// StatisticsStore computes and stores the statistics served by Statistics
+	StatisticsStore repository.StatisticsRepository
+	Statistics      *statistics.Cache
+	ViewRecorder    *views.Recorder // queues the view events written to Views
+}
+
+// NewGorm returns an App backed by the database db
+func NewGorm(db *gorm.DB) *App {
+	a := &App{
+		Restaurants:  database.NewRestaurantRepository(db),
+		MenuItems:    database.NewMenuItemRepository(db),
+		PriceHistory: database.NewPriceHistoryRepository(db),
+		Views:        database.NewViewRepository(db),
+		Reviews:      database.NewReviewRepository(db),
+		Favorites:    database.NewFavoriteRepository(db),
+		Users:        database.NewUserRepository(db),
+		Tokens:       database.NewTokenRepository(db),
+		Imports:      database.NewImportRepository(db),
+		Webhooks:     database.NewWebhookRepository(db),
+		Outbox:       database.NewOutboxRepository(db),
+	}
+	a.StatisticsStore = database.NewStatisticsRepository(db)
+	a.Statistics = statistics.NewCache(a.StatisticsStore, statisticsOptions())
+	a.Statistics.Watch(db)
+	a.ViewRecorder = views.NewRecorder(a.Views, views.DefaultOptions())
+	return a
+}
+
+// NewMemory returns an App backed by store, for tests and local runs without
+// a database
+func NewMemory(store *memory.Store) *App {
+	a := &App{
+		Restaurants:  store.Restaurants(),
+		MenuItems:    store.MenuItems(),
+		PriceHistory: store.PriceHistory(),
+		Views:        store.Views(),
+		Reviews:      store.Reviews(),
+		Favorites:    store.Favorites(),
+		Users:        store.Users(),
+		Tokens:       store.Tokens(),
+		Imports:      store.Imports(),
+		Webhooks:     store.Webhooks(),
+		Outbox:       store.Outbox(),
+	}
+	a.StatisticsStore = store.Statistics()
+	a.Statistics = statistics.NewCache(a.StatisticsStore, statisticsOptions())
+	store.OnChange(a.Statistics.Changed)
+	a.ViewRecorder = views.NewRecorder(a.Views, views.DefaultOptions())
+	return a
+}
+
+// statisticsOptions returns the statistics cache settings of the configuration
+func statisticsOptions() statistics.Options {
+	return statistics.Options{
+		TTL:             config.AppConfig.Stats.CacheTTL,
+		RefreshInterval: config.AppConfig.Stats.RefreshInterval,
+	}
+}
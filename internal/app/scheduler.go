@@ -0,0 +1,29 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// RunPriceScheduler applies the scheduled price changes that have taken
+// effect every interval until ctx is done. Replicas may run it concurrently,
+// each change is applied once.
+func (a *App) RunPriceScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		n, err := a.PriceHistory.ApplyDue(ctx, time.Now())
+		if err != nil {
+			slog.Error("pricing: failed to apply scheduled price changes", slog.Any("error", err))
+		}
+		if n > 0 {
+			slog.Info("pricing: applied scheduled price changes", slog.Int("count", n))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// Package apperrors defines the typed application errors returned by the
+// database layer and the handlers. The error-mapping middleware turns them
+// into RFC 7807 problem details; any other error is reported as an internal
+// error without exposing its message.
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"lunch_menu/internal/models"
+)
+
+// Kind classifies an application error
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindNotFound
+	KindValidation
+	KindUnprocessable
+	KindConflict
+	KindUnauthorized
+	KindForbidden
+	KindUnavailable
+	KindTooManyRequests
+)
+
+// Status returns the HTTP status code of the kind
+func (k Kind) Status() int {
+	switch k {
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindValidation:
+		return http.StatusBadRequest
+	case KindUnprocessable:
+		return http.StatusUnprocessableEntity
+	case KindConflict:
+		return http.StatusConflict
+	case KindUnauthorized:
+		return http.StatusUnauthorized
+	case KindForbidden:
+		return http.StatusForbidden
+	case KindUnavailable:
+		return http.StatusServiceUnavailable
+	case KindTooManyRequests:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Error is an application error. Message is safe to show to clients.
+type Error struct {
+	Kind    Kind
+	Code    string              // machine-readable code, e.g. "NOT_FOUND"
+	Message string              // human-readable explanation for clients
+	Fields  []models.FieldError // per-field details of validation errors
+	Err     error               // underlying error, logged but never shown
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is matches errors of the same kind, so that
+// errors.Is(err, apperrors.ErrNotFound) works for every not-found error
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Kind == e.Kind
+}
+
+// Sentinels for errors.Is checks
+var (
+	ErrNotFound     = &Error{Kind: KindNotFound, Code: "NOT_FOUND"}
+	ErrValidation   = &Error{Kind: KindValidation, Code: "VALIDATION_FAILED"}
+	ErrConflict     = &Error{Kind: KindConflict, Code: "CONFLICT"}
+	ErrUnauthorized = &Error{Kind: KindUnauthorized, Code: "UNAUTHORIZED"}
+	ErrForbidden    = &Error{Kind: KindForbidden, Code: "FORBIDDEN"}
+)
+
+// NotFound reports that the resource (e.g. "restaurant") with the given ID does not exist
+func NotFound(resource string, id interface{}) *Error {
+	return &Error{Kind: KindNotFound, Code: "NOT_FOUND", Message: fmt.Sprintf("%s %v not found", resource, id)}
+}
+
+// Validation reports invalid input with per-field details
+func Validation(message string, fields ...models.FieldError) *Error {
+	return &Error{Kind: KindValidation, Code: "VALIDATION_FAILED", Message: message, Fields: fields}
+}
+
+// InvalidFields reports the invalid fields returned by the Validate methods of the input models
+func InvalidFields(names []string) *Error {
+	fields := make([]models.FieldError, 0, len(names))
+	for _, name := range names {
+		fields = append(fields, models.FieldError{Field: name, Message: FieldMessage(name)})
+	}
+	return Validation("Invalid input fields", fields...)
+}
+
+// Unprocessable reports well-formed input whose content cannot be applied, e.g. invalid import rows
+func Unprocessable(code, message string, fields ...models.FieldError) *Error {
+	return &Error{Kind: KindUnprocessable, Code: code, Message: message, Fields: fields}
+}
+
+// Conflict reports that the request conflicts with existing data, e.g. a duplicate
+func Conflict(message string) *Error {
+	return &Error{Kind: KindConflict, Code: "CONFLICT", Message: message}
+}
+
+// Unauthorized reports missing or invalid credentials; code refines the reason (e.g. "TOKEN_REVOKED")
+func Unauthorized(code, message string) *Error {
+	return &Error{Kind: KindUnauthorized, Code: code, Message: message}
+}
+
+// Forbidden reports an authenticated caller without the required permissions
+func Forbidden(message string) *Error {
+	return &Error{Kind: KindForbidden, Code: "FORBIDDEN", Message: message}
+}
+
+// Unavailable reports that a dependency of the request is temporarily not available
+func Unavailable(message string) *Error {
+	return &Error{Kind: KindUnavailable, Code: "UNAVAILABLE", Message: message}
+}
+
+// TooManyRequests reports that the caller exceeded a rate limit
+func TooManyRequests(message string) *Error {
+	return &Error{Kind: KindTooManyRequests, Code: "RATE_LIMITED", Message: message}
+}
+
+// Internal wraps an unexpected error; message is shown to clients, err is only logged
+func Internal(message string, err error) *Error {
+	return &Error{Kind: KindInternal, Code: "INTERNAL_ERROR", Message: message, Err: err}
+}
+
+// As returns err as an application error. Errors that are not application
+// errors become internal errors.
+func As(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return Internal("An unexpected error occurred", err)
+}
+
+// KindOf returns the kind of err, KindInternal for non-application errors
+func KindOf(err error) Kind {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Kind
+	}
+	return KindInternal
+}
+
+// fieldMessages explains the rules of the fields checked by the Validate methods
+var fieldMessages = map[string]string{
+	"email":           "must be a valid email address",
+	"role":            "must be customer or admin",
+	"coordinate":      "must be [latitude, longitude]",
+	"price":           "must be greater than 0",
+	"url":             "must be an absolute http(s) URL",
+	"event_types":     "must contain known event types or \"*\"",
+	"restaurant_id":   "must reference an existing restaurant",
+	"restaurant_name": "is required",
+	"rating":          "must be between 1 and 5",
+	"comment":         "must be at most 2000 characters",
+	"home_region":     "must be at most 100 characters",
+	"dietary":         "must be at most 20 categories of at most 100 characters, without commas",
+}
+
+// FieldMessage explains why the field checked by a Validate method is invalid
+func FieldMessage(name string) string {
+	if msg, ok := fieldMessages[name]; ok {
+		return msg
+	}
+	return "is required"
+}
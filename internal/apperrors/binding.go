@@ -0,0 +1,74 @@
+package apperrors
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/money"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	// Report validation errors with the JSON field names clients send
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(f reflect.StructField) string {
+			name := strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
+			if name == "-" {
+				return ""
+			}
+			if name == "" {
+				return f.Name
+			}
+			return name
+		})
+	}
+}
+
+// FromBinding converts an error of gin's ShouldBind* methods into a
+// validation error with per-field details
+func FromBinding(err error) *Error {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make([]models.FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			fields = append(fields, models.FieldError{Field: fe.Field(), Message: bindingMessage(fe)})
+		}
+		return Validation("Invalid input fields", fields...)
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return Validation("Invalid input fields", models.FieldError{
+			Field:   typeErr.Field,
+			Message: "must be of type " + typeErr.Type.String(),
+		})
+	}
+	// Amounts do not know their field name, see money.Amount.UnmarshalJSON
+	if errors.Is(err, money.ErrScale) || errors.Is(err, money.ErrSyntax) {
+		return Validation("Invalid amount: prices are decimal numbers with at most 2 decimals")
+	}
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return Validation("Request body is not valid JSON")
+	}
+	return Validation("Invalid request: " + err.Error())
+}
+
+func bindingMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "len":
+		return "must have length " + fe.Param()
+	case "gt":
+		return "must be greater than " + fe.Param()
+	case "email":
+		return "must be a valid email address"
+	default:
+		return "failed the " + fe.Tag() + " rule"
+	}
+}
@@ -1,87 +1,260 @@
-// Load environment variables, app settings
+// Package config holds the typed configuration of the API. Settings are
+// loaded, in increasing order of precedence, from the defaults below, an
+// optional YAML or TOML file (CONFIG_FILE), environment variables and
+// <VAR>_FILE secret files (e.g. DB_PASSWORD_FILE, as mounted from a
+// Kubernetes secret), and validated at startup.
 package config
 
 import (
 	"log"
 	"os"
-	"strconv"
+	"strings"
+	"time"
+
+	"lunch_menu/internal/money"
 )
 
+// Config is the configuration tree. The yaml tag names a setting in the
+// config file (also used for TOML), env the environment variable that
+// overrides it and secret marks values redacted by Print.
 type Config struct {
-	DBHost     string
-	DBPort     string
-	DBUser     string
-	DBPassword string
-	DBName     string
-	JWTSecret  string
-	DBSSLmode  string
-	DBTimeZone string // <-- need to test
+	Version   string          `yaml:"version" env:"VERSION"`
+	Server    ServerConfig    `yaml:"server"`
+	Database  DatabaseConfig  `yaml:"database"`
+	Auth      AuthConfig      `yaml:"auth"`
+	Cookie    CookieConfig    `yaml:"cookie"`
+	CORS      CORSConfig      `yaml:"cors"`
+	Security  SecurityConfig  `yaml:"security"`
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Pricing   PricingConfig   `yaml:"pricing"`
+	Stats     StatsConfig     `yaml:"stats"`
+	Log       LogConfig       `yaml:"log"`
+	Metrics   MetricsConfig   `yaml:"metrics"`
+	Tracing   TracingConfig   `yaml:"tracing"`
+}
+
+type ServerConfig struct {
+	Port              int           `yaml:"port" env:"PORT"`
+	GRPCPort          int           `yaml:"grpc_port" env:"GRPC_PORT"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"HTTP_READ_TIMEOUT"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"HTTP_READ_HEADER_TIMEOUT"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" env:"HTTP_WRITE_TIMEOUT"` // lifted for SSE streams and exports
+	IdleTimeout       time.Duration `yaml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT"` // drain deadline, below the pod's termination grace period
+}
+
+type DatabaseConfig struct {
+	Driver          string        `yaml:"driver" env:"DB_DRIVER"` // postgres or sqlite
+	Path            string        `yaml:"path" env:"DB_PATH"`     // SQLite database file
+	Host            string        `yaml:"host" env:"DB_HOST"`
+	Port            int           `yaml:"port" env:"DB_PORT"`
+	User            string        `yaml:"user" env:"DB_USER"`
+	Password        string        `yaml:"password" env:"DB_PASSWORD" secret:"true"`
+	Name            string        `yaml:"name" env:"DB_NAME"`
+	SSLMode         string        `yaml:"sslmode" env:"DB_SSLMODE"`
+	TimeZone        string        `yaml:"timezone" env:"DB_TIMEZONE"`
+	LogLevel        string        `yaml:"log_level" env:"DB_LOG_LEVEL"` // GORM query logging: silent, error, warn or info
+	SlowQuery       time.Duration `yaml:"slow_query" env:"DB_SLOW_QUERY"`
+	ConnectAttempts int           `yaml:"connect_attempts" env:"DB_CONNECT_ATTEMPTS"` // connection attempts at startup
+	ConnectBackoff  time.Duration `yaml:"connect_backoff" env:"DB_CONNECT_BACKOFF"`   // initial delay between attempts, doubled up to 30s
+	MaxOpenConns    int           `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME"`
+}
+
+type AuthConfig struct {
+	JWTSecret string `yaml:"jwt_secret" env:"JWT_SECRET" secret:"true"`
 }
 
 type CookieConfig struct {
-	Domain            string
-	Path              string
-	Secure            bool
-	HTTPOnly          bool
-	SameSite          string
-	MaxAge            int
-	AccessCookieName  string
-	AccessCookieAge   int
-	RefreshCookieName string
-	RefreshCookieAge  int
-	CSRFCookieName    string
-	CSRFCookieAge     int
+	Domain            string `yaml:"domain" env:"COOKIE_DOMAIN"`
+	Path              string `yaml:"path" env:"COOKIE_PATH"`
+	Secure            bool   `yaml:"secure" env:"COOKIE_SECURE"`
+	HTTPOnly          bool   `yaml:"http_only" env:"COOKIE_HTTPONLY"`
+	SameSite          string `yaml:"same_site" env:"COOKIE_SAMESITE"`
+	MaxAge            int    `yaml:"max_age" env:"COOKIE_AGE"`
+	AccessCookieName  string `yaml:"access_cookie_name" env:"ACCESS_COOKIE_NAME"`
+	AccessCookieAge   int    `yaml:"access_cookie_age" env:"ACCESS_COOKIE_AGE"`
+	RefreshCookieName string `yaml:"refresh_cookie_name" env:"REFRESH_COOKIE_NAME"`
+	RefreshCookieAge  int    `yaml:"refresh_cookie_age" env:"REFRESH_COOKIE_AGE"`
+	CSRFCookieName    string `yaml:"csrf_cookie_name" env:"CSRF_COOKIE_NAME"`
+	CSRFCookieAge     int    `yaml:"csrf_cookie_age" env:"CSRF_COOKIE_AGE"`
 }
 
-var AppConfig *Config
+// CORSConfig lists the browser origins allowed to call the API, e.g.
+// "https://lunch.example.com" or "https://*.example.com" for any subdomain.
+// An empty list allows same-origin requests only.
+type CORSConfig struct {
+	AllowedOrigins   []string      `yaml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS"` // "*" allows all origins, without credentials
+	AllowedMethods   []string      `yaml:"allowed_methods" env:"CORS_ALLOWED_METHODS"`
+	AllowedHeaders   []string      `yaml:"allowed_headers" env:"CORS_ALLOWED_HEADERS"`
+	ExposedHeaders   []string      `yaml:"exposed_headers" env:"CORS_EXPOSED_HEADERS"`
+	AllowCredentials bool          `yaml:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS"`
+	MaxAge           time.Duration `yaml:"max_age" env:"CORS_MAX_AGE"` // how long browsers cache preflight responses
+}
+
+// SecurityConfig configures the security headers sent with every response
+type SecurityConfig struct {
+	HSTSMaxAge            time.Duration `yaml:"hsts_max_age" env:"HSTS_MAX_AGE"` // 0 disables Strict-Transport-Security
+	HSTSIncludeSubdomains bool          `yaml:"hsts_include_subdomains" env:"HSTS_INCLUDE_SUBDOMAINS"`
+	ContentSecurityPolicy string        `yaml:"content_security_policy" env:"CONTENT_SECURITY_POLICY"` // the Swagger UI has its own policy
+	ReferrerPolicy        string        `yaml:"referrer_policy" env:"REFERRER_POLICY"`
+}
+
+// RateLimitConfig sets the request budgets, in the format "<n>-<S|M|H|D>",
+// e.g. "10-S" (10 per second) or "100-M" (100 per minute). Anonymous clients
+// are identified by IP, authenticated ones by user.
+type RateLimitConfig struct {
+	Store    string `yaml:"store" env:"RATE_LIMIT_STORE"`            // memory (per replica) or redis (shared by all replicas)
+	RedisURL string `yaml:"redis_url" env:"REDIS_URL" secret:"true"` // e.g. redis://:password@redis:6379/0
+	Rate     string `yaml:"rate" env:"RATE_LIMIT"`                   // all requests of an anonymous client
+	User     string `yaml:"user" env:"RATE_LIMIT_USER"`              // all requests of an authenticated user
+	Auth     string `yaml:"auth" env:"RATE_LIMIT_AUTH"`              // login and registration attempts per client
+	Write    string `yaml:"write" env:"RATE_LIMIT_WRITE"`            // admin writes (create, update, delete, import) per user
+}
+
+// PricingConfig sets the currency of menu prices and the VAT rates, in
+// percent, of the VAT breakdown. Prices are entered including VAT, as on
+// Swedish menus; restaurant and takeaway food is taxed at 12%.
+type PricingConfig struct {
+	Currency         string        `yaml:"currency" env:"PRICE_CURRENCY"`                   // ISO 4217 code of prices sent without a currency
+	VATRate          string        `yaml:"vat_rate" env:"VAT_RATE"`                         // rate of all categories not listed below
+	CategoryVATRates []string      `yaml:"category_vat_rates" env:"CATEGORY_VAT_RATES"`     // "<category>=<rate>", e.g. "Beverages=25"
+	ScheduleInterval time.Duration `yaml:"schedule_interval" env:"PRICE_SCHEDULE_INTERVAL"` // how often scheduled price changes that took effect are applied
+}
 
-func LoadConfig() {
-	AppConfig = &Config{
-		DBHost:     getEnv("DB_HOST", "localhost"),
-		DBPort:     getEnv("DB_PORT", "5432"),
-		DBUser:     getEnv("DB_USER", "db_user"),
-		DBPassword: getEnv("DB_PASSWORD", ""),
-		DBName:     getEnv("DB_NAME", "lunch_menu"),
-		JWTSecret:  getEnv("JWT_SECRET", ""),
-		DBSSLmode:  getEnv("DB_SSLMODE", "disable"),
-		DBTimeZone: getEnv("DB_TIMEZONE", "UTC"), // <-- Add this line
+// VATRateFor returns the VAT rate of a menu item category; the rates were
+// checked by Validate
+func (p PricingConfig) VATRateFor(category string) money.Rate {
+	for _, entry := range p.CategoryVATRates {
+		name, rate, _ := strings.Cut(entry, "=")
+		if strings.EqualFold(strings.TrimSpace(name), strings.TrimSpace(category)) {
+			r, _ := money.ParseRate(rate)
+			return r
+		}
 	}
+	r, _ := money.ParseRate(p.VATRate)
+	return r
+}
+
+// StatsConfig sets how long business statistics are cached and how often the
+// precomputed statistics are refreshed
+type StatsConfig struct {
+	CacheTTL        time.Duration `yaml:"cache_ttl" env:"STATS_CACHE_TTL"`               // how long statistics are served from memory
+	RefreshInterval time.Duration `yaml:"refresh_interval" env:"STATS_REFRESH_INTERVAL"` // how often the precomputed statistics are recomputed without changes
+}
+
+type LogConfig struct {
+	Level  string `yaml:"level" env:"LOG_LEVEL"`   // debug, info, warn or error
+	Format string `yaml:"format" env:"LOG_FORMAT"` // json or text
+}
+
+type MetricsConfig struct {
+	Port  int    `yaml:"port" env:"METRICS_PORT"`                 // serve /metrics on this admin port instead of the API port
+	Token string `yaml:"token" env:"METRICS_TOKEN" secret:"true"` // bearer token required for /metrics, if set
 }
 
-func getEnv(key, defaultVal string) string {
-	val := os.Getenv(key)
-	if val == "" {
-		return defaultVal
+type TracingConfig struct {
+	Exporter string `yaml:"exporter" env:"OTEL_TRACES_EXPORTER"` // otlp, stdout, file or none
+	File     string `yaml:"file" env:"TRACES_FILE"`              // output of the file exporter
+}
+
+var AppConfig *Config
+
+// Defaults returns the configuration used for settings that are not set elsewhere
+func Defaults() *Config {
+	return &Config{
+		Version: "development",
+		Server: ServerConfig{
+			Port:              8000,
+			GRPCPort:          9090,
+			ReadTimeout:       30 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       120 * time.Second,
+			ShutdownTimeout:   25 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Driver:          "postgres",
+			Path:            "lunch_menu.db",
+			Host:            "localhost",
+			Port:            5432,
+			User:            "db_user",
+			Name:            "lunch_menu",
+			SSLMode:         "disable",
+			TimeZone:        "UTC",
+			LogLevel:        "warn",
+			SlowQuery:       200 * time.Millisecond,
+			ConnectAttempts: 10,
+			ConnectBackoff:  time.Second,
+			MaxOpenConns:    25,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+		},
+		Cookie: CookieConfig{
+			Path:              "/",
+			Secure:            true,
+			HTTPOnly:          true,
+			SameSite:          "Lax",
+			AccessCookieName:  "access_token",
+			AccessCookieAge:   1200,
+			RefreshCookieName: "refresh_token",
+			RefreshCookieAge:  604800,
+			CSRFCookieName:    "csrf_token",
+			CSRFCookieAge:     3600,
+		},
+		CORS: CORSConfig{
+			AllowedOrigins:   []string{},
+			AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+			AllowedHeaders:   []string{"Origin", "Content-Type", "Accept", "Authorization", "X-CSRF-Token", "X-Request-ID", "Last-Event-ID"},
+			ExposedHeaders:   []string{"X-Request-ID", "X-New-Access-Token", "Content-Disposition"},
+			AllowCredentials: true,
+			MaxAge:           12 * time.Hour,
+		},
+		Security: SecurityConfig{
+			HSTSMaxAge:            365 * 24 * time.Hour,
+			ContentSecurityPolicy: "default-src 'none'; frame-ancestors 'none'",
+			ReferrerPolicy:        "no-referrer",
+		},
+		RateLimit: RateLimitConfig{
+			Store: "memory",
+			Rate:  "10-S",
+			User:  "20-S",
+			Auth:  "5-M",
+			Write: "60-M",
+		},
+		Pricing: PricingConfig{
+			Currency:         "SEK",
+			VATRate:          "12",
+			CategoryVATRates: []string{},
+			ScheduleInterval: time.Minute,
+		},
+		Stats:   StatsConfig{CacheTTL: time.Minute, RefreshInterval: 5 * time.Minute},
+		Log:     LogConfig{Level: "info", Format: "json"},
+		Tracing: TracingConfig{Exporter: "none", File: "traces.jsonl"},
 	}
-	return val
 }
 
-func MustLoadConfig() {
-	LoadConfig()
-	if AppConfig.DBPassword == "" || AppConfig.JWTSecret == "" {
-		log.Fatal("Critical environment variables missing: DB_PASSWORD or JWT_SECRET")
+// LoadConfig loads the configuration from CONFIG_FILE (if set) and the
+// environment into AppConfig
+func LoadConfig() error {
+	cfg, err := Load(os.Getenv("CONFIG_FILE"))
+	if err != nil {
+		return err
 	}
+	AppConfig = cfg
+	return nil
 }
 
-func GetCookieConfig() CookieConfig {
-	secure, _ := strconv.ParseBool(os.Getenv("COOKIE_SECURE"))
-	httpOnly, _ := strconv.ParseBool(os.Getenv("COOKIE_HTTPONLY"))
-	maxAge, _ := strconv.Atoi(os.Getenv("COOKIE_AGE"))
-	accessAge, _ := strconv.Atoi(os.Getenv("ACCESS_COOKIE_AGE"))
-	refreshAge, _ := strconv.Atoi(os.Getenv("REFRESH_COOKIE_AGE"))
-	csrAge, _ := strconv.Atoi(os.Getenv("CSRF_COOKIE_AGE"))
-	return CookieConfig{
-		Domain:            os.Getenv("COOKIE_DOMAIN"),
-		Path:              os.Getenv("COOKIE_PATH"),
-		Secure:            secure,
-		HTTPOnly:          httpOnly,
-		SameSite:          os.Getenv("COOKIE_SAMESITE"),
-		MaxAge:            maxAge,
-		AccessCookieName:  os.Getenv("ACCESS_COOKIE_NAME"),
-		AccessCookieAge:   accessAge,
-		RefreshCookieName: os.Getenv("REFRESH_COOKIE_NAME"),
-		RefreshCookieAge:  refreshAge,
-		CSRFCookieName:    os.Getenv("CSRF_COOKIE_NAME"),
-		CSRFCookieAge:     csrAge,
+// MustLoadConfig loads and validates the configuration, exiting with the
+// list of problems if it is invalid
+func MustLoadConfig() {
+	if err := LoadConfig(); err != nil {
+		log.Fatalf("Invalid configuration:\n%v", err)
+	}
+	if err := AppConfig.Validate(); err != nil {
+		log.Fatalf("Invalid configuration:\n%v", err)
 	}
 }
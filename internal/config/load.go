@@ -0,0 +1,202 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// setting is a leaf of the configuration tree
+type setting struct {
+	path   string // dotted file path, e.g. "database.port"
+	env    string
+	secret bool
+	value  reflect.Value
+}
+
+// settings returns the leaves of cfg in declaration order
+func settings(cfg *Config) []setting {
+	var out []setting
+	var walk func(v reflect.Value, prefix string)
+	walk = func(v reflect.Value, prefix string) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			path := prefix + f.Tag.Get("yaml")
+			if f.Type.Kind() == reflect.Struct && f.Type != reflect.TypeOf(time.Duration(0)) {
+				walk(v.Field(i), path+".")
+				continue
+			}
+			out = append(out, setting{
+				path:   path,
+				env:    f.Tag.Get("env"),
+				secret: f.Tag.Get("secret") == "true",
+				value:  v.Field(i),
+			})
+		}
+	}
+	walk(reflect.ValueOf(cfg).Elem(), "")
+	return out
+}
+
+// Load returns the defaults overridden by the file at path (if not empty),
+// environment variables and <VAR>_FILE secret files. All problems found are
+// returned together; Validate checks the resulting values.
+func Load(path string) (*Config, error) {
+	cfg := Defaults()
+	byPath := make(map[string]setting)
+	for _, s := range settings(cfg) {
+		byPath[s.path] = s
+	}
+
+	var errs []error
+	if path != "" {
+		values, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			s, ok := byPath[key]
+			if !ok {
+				errs = append(errs, fmt.Errorf("%s: %s: unknown setting", path, key))
+				continue
+			}
+			if err := setValue(s.value, values[key]); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %s: %w", path, key, err))
+			}
+		}
+	}
+
+	for _, s := range settings(cfg) {
+		raw, source, err := lookupEnv(s.env)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if source == "" {
+			continue
+		}
+		if err := setValue(s.value, raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s (%s): %w", source, s.path, err))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return cfg, nil
+}
+
+// lookupEnv returns the value of the variable name or the content of the file
+// named by name_FILE, and which of them was used ("" if neither is set)
+func lookupEnv(name string) (string, string, error) {
+	value, hasValue := os.LookupEnv(name)
+	file, hasFile := os.LookupEnv(name + "_FILE")
+	switch {
+	case hasValue && hasFile && value != "" && file != "":
+		return "", "", fmt.Errorf("%s and %s_FILE are both set; use one of them", name, name)
+	case hasFile && file != "":
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return "", "", fmt.Errorf("%s_FILE: %w", name, err)
+		}
+		return strings.TrimRight(string(content), "\r\n"), name + "_FILE", nil
+	case hasValue && value != "":
+		// Empty variables, e.g. "METRICS_PORT=" in .env, keep the default
+		return value, name, nil
+	}
+	return "", "", nil
+}
+
+// readFile decodes a YAML or TOML file into dotted paths and values
+func readFile(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config file: %w", err)
+	}
+	tree := make(map[string]interface{})
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &tree)
+	case ".toml":
+		err = toml.Unmarshal(data, &tree)
+	default:
+		return nil, fmt.Errorf("config file %s: unsupported format, use .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config file %s: %w", path, err)
+	}
+	values := make(map[string]interface{})
+	flatten(tree, "", values)
+	return values, nil
+}
+
+func flatten(tree map[string]interface{}, prefix string, out map[string]interface{}) {
+	for key, value := range tree {
+		if nested, ok := value.(map[string]interface{}); ok {
+			flatten(nested, prefix+key+".", out)
+			continue
+		}
+		out[prefix+key] = value
+	}
+}
+
+// setValue converts raw, a string from the environment or a decoded file
+// value, to the type of v. Durations are strings such as "30s"; lists are
+// file lists or comma-separated strings.
+func setValue(v reflect.Value, raw interface{}) error {
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(fmt.Sprint(raw))
+		if err != nil {
+			return fmt.Errorf("invalid duration %q (e.g. 30s, 5m)", fmt.Sprint(raw))
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(fmt.Sprint(raw))
+	case reflect.Int:
+		n, err := strconv.Atoi(fmt.Sprint(raw))
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", fmt.Sprint(raw))
+		}
+		v.SetInt(int64(n))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(fmt.Sprint(raw))
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q (use true or false)", fmt.Sprint(raw))
+		}
+		v.SetBool(b)
+	case reflect.Slice:
+		var items []string
+		if list, ok := raw.([]interface{}); ok {
+			for _, item := range list {
+				items = append(items, fmt.Sprint(item))
+			}
+		} else {
+			for _, item := range strings.Split(fmt.Sprint(raw), ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported setting type %s", v.Type())
+	}
+	return nil
+}
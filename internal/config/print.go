@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+)
+
+// Redacted replaces secret values in Print
+const Redacted = "[REDACTED]"
+
+// Print writes the configuration as YAML, in the format of the config file,
+// with secrets redacted
+func (c *Config) Print(w io.Writer) error {
+	root := yaml.MapSlice{}
+	for _, s := range settings(c) {
+		root = insert(root, strings.Split(s.path, "."), printValue(s))
+	}
+	out, err := yaml.Marshal(root)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}
+
+func printValue(s setting) interface{} {
+	if s.secret {
+		if s.value.IsZero() {
+			return ""
+		}
+		return Redacted
+	}
+	if d, ok := s.value.Interface().(time.Duration); ok {
+		return d.String()
+	}
+	return s.value.Interface()
+}
+
+// insert adds value at path, keeping the declaration order of the sections
+func insert(m yaml.MapSlice, path []string, value interface{}) yaml.MapSlice {
+	if len(path) == 1 {
+		return append(m, yaml.MapItem{Key: path[0], Value: value})
+	}
+	for i := range m {
+		if m[i].Key == path[0] {
+			m[i].Value = insert(m[i].Value.(yaml.MapSlice), path[1:], value)
+			return m
+		}
+	}
+	return append(m, yaml.MapItem{Key: path[0], Value: insert(yaml.MapSlice{}, path[1:], value)})
+}
+
+// String returns the redacted YAML form of the configuration
+func (c *Config) String() string {
+	var b strings.Builder
+	if err := c.Print(&b); err != nil {
+		return fmt.Sprintf("config: %v", err)
+	}
+	return b.String()
+}
@@ -0,0 +1,174 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"lunch_menu/internal/money"
+)
+
+// rateFormat is the limiter rate format, e.g. "10-S" or "1000-H"
+var rateFormat = regexp.MustCompile(`^[0-9]+-[SMHD]$`)
+
+// originFormat is a CORS origin: scheme, host (optionally "*." for any
+// subdomain) and port, without a path
+var originFormat = regexp.MustCompile(`^https?://(\*\.)?[A-Za-z0-9.-]+(:[0-9]+)?$`)
+
+// minJWTSecretLength is the minimum length of the HMAC signing key
+const minJWTSecretLength = 32
+
+// Validate checks that the settings required to serve requests are present
+// and well-formed, reporting every problem with its setting path
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	v.port("server.port", c.Server.Port, false)
+	v.port("server.grpc_port", c.Server.GRPCPort, false)
+	v.positive("server.read_timeout", c.Server.ReadTimeout)
+	v.positive("server.read_header_timeout", c.Server.ReadHeaderTimeout)
+	v.positive("server.shutdown_timeout", c.Server.ShutdownTimeout)
+	v.notNegative("server.write_timeout", c.Server.WriteTimeout)
+	v.notNegative("server.idle_timeout", c.Server.IdleTimeout)
+
+	v.oneOf("database.driver", c.Database.Driver, "postgres", "sqlite")
+	if c.Database.Driver == "sqlite" {
+		v.required("database.path", c.Database.Path)
+	} else {
+		v.required("database.host", c.Database.Host)
+		v.port("database.port", c.Database.Port, false)
+		v.required("database.user", c.Database.User)
+		v.required("database.password", c.Database.Password)
+		v.required("database.name", c.Database.Name)
+		v.oneOf("database.sslmode", c.Database.SSLMode, "disable", "allow", "prefer", "require", "verify-ca", "verify-full")
+	}
+	v.oneOf("database.log_level", c.Database.LogLevel, "silent", "error", "warn", "info")
+	if c.Database.ConnectAttempts < 1 {
+		v.add("database.connect_attempts", "must be at least 1")
+	}
+	v.positive("database.connect_backoff", c.Database.ConnectBackoff)
+	if c.Database.MaxOpenConns > 0 && c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		v.add("database.max_idle_conns", "must not exceed database.max_open_conns")
+	}
+
+	if len(c.Auth.JWTSecret) < minJWTSecretLength {
+		v.add("auth.jwt_secret", fmt.Sprintf("must be at least %d characters (openssl rand -base64 48)", minJWTSecretLength))
+	}
+
+	v.oneOf("cookie.same_site", c.Cookie.SameSite, "", "Lax", "Strict", "None")
+	if strings.EqualFold(c.Cookie.SameSite, "None") && !c.Cookie.Secure {
+		v.add("cookie.secure", "must be true when cookie.same_site is None")
+	}
+	v.required("cookie.access_cookie_name", c.Cookie.AccessCookieName)
+	v.required("cookie.refresh_cookie_name", c.Cookie.RefreshCookieName)
+	v.required("cookie.csrf_cookie_name", c.Cookie.CSRFCookieName)
+
+	for _, origin := range c.CORS.AllowedOrigins {
+		switch {
+		case origin == "*" && len(c.CORS.AllowedOrigins) > 1:
+			v.add("cors.allowed_origins", "\"*\" cannot be combined with other origins")
+		case origin == "*" && c.CORS.AllowCredentials:
+			v.add("cors.allowed_origins", "\"*\" cannot be used with cors.allow_credentials, list the origins instead")
+		case origin != "*" && !originFormat.MatchString(origin):
+			v.add("cors.allowed_origins", fmt.Sprintf("invalid origin %q (e.g. https://lunch.example.com or https://*.example.com)", origin))
+		}
+	}
+	v.notNegative("cors.max_age", c.CORS.MaxAge)
+
+	v.notNegative("security.hsts_max_age", c.Security.HSTSMaxAge)
+	v.oneOf("security.referrer_policy", c.Security.ReferrerPolicy, "", "no-referrer", "no-referrer-when-downgrade", "origin",
+		"origin-when-cross-origin", "same-origin", "strict-origin", "strict-origin-when-cross-origin", "unsafe-url")
+
+	v.oneOf("rate_limit.store", c.RateLimit.Store, "memory", "redis")
+	if c.RateLimit.Store == "redis" {
+		v.required("rate_limit.redis_url", c.RateLimit.RedisURL)
+	}
+	v.rate("rate_limit.rate", c.RateLimit.Rate)
+	v.rate("rate_limit.user", c.RateLimit.User)
+	v.rate("rate_limit.auth", c.RateLimit.Auth)
+	v.rate("rate_limit.write", c.RateLimit.Write)
+
+	if !money.IsCurrency(c.Pricing.Currency) {
+		v.add("pricing.currency", fmt.Sprintf("invalid currency %q (an ISO 4217 code, e.g. SEK)", c.Pricing.Currency))
+	}
+	v.vatRate("pricing.vat_rate", c.Pricing.VATRate)
+	for _, entry := range c.Pricing.CategoryVATRates {
+		category, rate, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(category) == "" {
+			v.add("pricing.category_vat_rates", fmt.Sprintf("invalid entry %q (e.g. Beverages=25)", entry))
+			continue
+		}
+		v.vatRate("pricing.category_vat_rates", rate)
+	}
+	v.positive("pricing.schedule_interval", c.Pricing.ScheduleInterval)
+	v.positive("stats.cache_ttl", c.Stats.CacheTTL)
+	v.positive("stats.refresh_interval", c.Stats.RefreshInterval)
+
+	v.oneOf("log.level", c.Log.Level, "debug", "info", "warn", "error")
+	v.oneOf("log.format", c.Log.Format, "json", "text")
+	v.port("metrics.port", c.Metrics.Port, true)
+	v.oneOf("tracing.exporter", c.Tracing.Exporter, "none", "otlp", "stdout", "file")
+	if c.Tracing.Exporter == "file" {
+		v.required("tracing.file", c.Tracing.File)
+	}
+
+	return errors.Join(v.errs...)
+}
+
+type validator struct {
+	errs []error
+}
+
+func (v *validator) add(path, msg string) {
+	v.errs = append(v.errs, fmt.Errorf("%s: %s", path, msg))
+}
+
+func (v *validator) required(path, value string) {
+	if value == "" {
+		v.add(path, "is required")
+	}
+}
+
+func (v *validator) port(path string, port int, optional bool) {
+	if optional && port == 0 {
+		return
+	}
+	if port < 1 || port > 65535 {
+		v.add(path, fmt.Sprintf("invalid port %d", port))
+	}
+}
+
+func (v *validator) rate(path, rate string) {
+	if !rateFormat.MatchString(rate) {
+		v.add(path, fmt.Sprintf("invalid rate %q (e.g. 10-S, 100-M)", rate))
+	}
+}
+
+func (v *validator) vatRate(path, rate string) {
+	if _, err := money.ParseRate(rate); err != nil {
+		v.add(path, fmt.Sprintf("invalid VAT rate %q (a percentage, e.g. 12)", rate))
+	}
+}
+
+func (v *validator) positive(path string, d time.Duration) {
+	if d <= 0 {
+		v.add(path, "must be positive")
+	}
+}
+
+func (v *validator) notNegative(path string, d time.Duration) {
+	if d < 0 {
+		v.add(path, "must not be negative")
+	}
+}
+
+func (v *validator) oneOf(path, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.add(path, fmt.Sprintf("invalid value %q (one of %s)", value, strings.Join(allowed, ", ")))
+}
@@ -1,42 +1,126 @@
 package database
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"lunch_menu/internal/config"
+	"lunch_menu/internal/logging"
 	"lunch_menu/internal/models"
+	"lunch_menu/internal/tracing"
+	"sync/atomic"
+	"time"
 
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
 var DB *gorm.DB
 
-func InitDatabase() error {
-	host := config.AppConfig.DBHost
-	port := config.AppConfig.DBPort
-	user := config.AppConfig.DBUser
-	password := config.AppConfig.DBPassword
-	dbname := config.AppConfig.DBName
-	sslmode := config.AppConfig.DBSSLmode
-	timezone := config.AppConfig.DBTimeZone
-
-	// fmt.Printf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s TimeZone=%s\n", host, port, user, password, dbname, sslmode, timezone)
-	dsn := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s TimeZone=%s",
+// sqliteOptions enables foreign keys and lets concurrent writers wait for each
+// other instead of failing with SQLITE_BUSY
+const sqliteOptions = "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_txlock=immediate"
+
+// DSN returns the Postgres connection string built from the configuration
+func DSN() string {
+	cfg := config.AppConfig.Database
+	host := cfg.Host
+	port := cfg.Port
+	user := cfg.User
+	password := cfg.Password
+	dbname := cfg.Name
+	sslmode := cfg.SSLMode
+	timezone := cfg.TimeZone
+
+	// fmt.Printf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s TimeZone=%s\n", host, port, user, password, dbname, sslmode, timezone)
+	return fmt.Sprintf(
+		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s TimeZone=%s",
 		host, port, user, password, dbname, sslmode, timezone,
 	)
+}
+
+// dialector returns the GORM dialector of database.driver: Postgres, or SQLite
+// for local development without a database server
+func dialector(cfg config.DatabaseConfig) gorm.Dialector {
+	if cfg.Driver == "sqlite" {
+		return sqlite.Open(cfg.Path + "?" + sqliteOptions)
+	}
+	return postgres.Open(DSN())
+}
+
+// IsPostgres reports whether db is connected to Postgres. Notifications of new
+// outbox events and SKIP LOCKED row locking, which let several replicas share
+// the database, are only used there.
+func IsPostgres(db *gorm.DB) bool {
+	return db.Dialector.Name() == "postgres"
+}
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+// InitDatabase connects to the database. While Postgres is still starting it
+// retries with exponential backoff (database.connect_attempts,
+// database.connect_backoff)
+// instead of failing, so the API does not crash-loop.
+func InitDatabase() error {
+	cfg := config.AppConfig.Database
+	var db *gorm.DB
+	var err error
+	backoff := cfg.ConnectBackoff
+	for attempt := 1; ; attempt++ {
+		db, err = gorm.Open(dialector(cfg), &gorm.Config{
+			Logger: logging.NewGormLogger(cfg.LogLevel, cfg.SlowQuery),
+			// Constraint violations of SQLite as gorm errors, see appError
+			TranslateError: cfg.Driver == "sqlite",
+		})
+		if err == nil {
+			break
+		}
+		if attempt >= cfg.ConnectAttempts {
+			return fmt.Errorf("failed to open database after %d attempts: %w", attempt, err)
+		}
+		slog.Warn("Database not available, retrying",
+			slog.Int("attempt", attempt),
+			slog.Duration("backoff", backoff),
+			slog.Any("error", err),
+		)
+		time.Sleep(backoff)
+		backoff = min(2*backoff, maxConnectBackoff)
+	}
+	sqlDB, err := db.DB()
 	if err != nil {
-		return fmt.Errorf("failed to open database: %w", err)
+		return fmt.Errorf("failed to get database handle: %w", err)
+	}
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	if err := db.Use(tracing.Plugin{}); err != nil {
+		return fmt.Errorf("failed to register tracing plugin: %w", err)
 	}
 	DB = db
 
-	log.Println("Database connection established successfully")
+	slog.Info("Database connection established successfully")
 	return nil
 }
 
+var errNotInitialized = errors.New("database not initialized")
+
+// maxConnectBackoff caps the delay between connection attempts
+const maxConnectBackoff = 30 * time.Second
+
+// Ping checks that the database is reachable within the deadline of ctx
+func Ping(ctx context.Context) error {
+	if DB == nil {
+		return errNotInitialized
+	}
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
 func CloseDatabase() error {
 	sqlDB, err := DB.DB()
 	if err != nil {
@@ -45,106 +129,83 @@ func CloseDatabase() error {
 	return sqlDB.Close()
 }
 
-// Auto-migrate all models
-func Migrate() error {
-	return DB.AutoMigrate(
-		&models.User{},
-		&models.Restaurant{},
-		&models.MenuItem{},
-		&models.RefreshToken{},
-		&models.BlacklistedToken{},
-		&models.AuditLog{},
-	)
+// migratedModels are the models whose tables Migrate creates and updates
+var migratedModels = []interface{}{
+	&models.User{},
+	&models.Restaurant{},
+	&models.MenuItem{},
+	&models.MenuItemPrice{},
+	&models.PriceChange{},
+	&models.StatisticsSnapshot{},
+	&models.ViewEvent{},
+	&models.Review{},
+	&models.Favorite{},
+	&models.RefreshToken{},
+	&models.BlacklistedToken{},
+	&models.AuditLog{},
+	&models.OutboxEvent{},
+	&models.WebhookSubscription{},
+	&models.WebhookDelivery{},
 }
 
-// GetBusinessStatistics retrieves business analytics data
-func GetBusinessStatistics() (*models.BusinessStatistics, error) {
-	stats := &models.BusinessStatistics{}
-
-	// Get restaurant counts
-	var counts struct {
-		Total    int64
-		Active   int64
-		Inactive int64
-	}
-
-	err := DB.Table("restaurants").Select(`
-		COUNT(*) as total,
-		COUNT(CASE WHEN is_active = true THEN 1 END) as active,
-		COUNT(CASE WHEN is_active = false THEN 1 END) as inactive
-	`).Scan(&counts).Error
-	if err != nil {
-		return nil, fmt.Errorf("failed to get restaurant stats: %w", err)
+// Auto-migrate all models
+func Migrate() error {
+	if err := DB.AutoMigrate(migratedModels...); err != nil {
+		return err
 	}
+	return backfillPriceHistory(DB)
+}
 
-	stats.TotalRestaurants = counts.Total
-	stats.ActiveRestaurants = counts.Active
-	stats.InactiveRestaurants = counts.Inactive
+// backfillPriceHistory starts the price history of menu items that have none,
+// e.g. created before it was kept or by seed scripts, with their current
+// price effective from their creation
+func backfillPriceHistory(db *gorm.DB) error {
+	return db.Exec(`
+		INSERT INTO price_changes (menu_item_id, price, currency, effective_from, applied_at, created_at)
+		SELECT m.id, COALESCE(m.price, 0), m.currency,
+			COALESCE(m.created_at, CURRENT_TIMESTAMP), COALESCE(m.created_at, CURRENT_TIMESTAMP), CURRENT_TIMESTAMP
+		FROM menu_items m
+		WHERE NOT EXISTS (SELECT 1 FROM price_changes c WHERE c.menu_item_id = m.id)
+	`).Error
+}
 
-	// Get menu item count and average price
-	var menuStats struct {
-		TotalMenuItems int64
-		AveragePrice   float64
-	}
+// schemaUpToDate is set once PendingMigrations found nothing to do; the
+// schema does not regress at runtime, so the check is not repeated
+var schemaUpToDate atomic.Bool
 
-	err = DB.Table("menu_items").Select(`
-		COUNT(*) as total_menu_items,
-		COALESCE(AVG(price), 0) as average_price
-	`).Where("is_available = true").Scan(&menuStats).Error
-	if err != nil {
-		return nil, fmt.Errorf("failed to get menu stats: %w", err)
+// PendingMigrations returns the tables and columns of the models that are
+// missing in the database, e.g. while another replica is still migrating
+func PendingMigrations(ctx context.Context) ([]string, error) {
+	if schemaUpToDate.Load() {
+		return nil, nil
 	}
-
-	stats.TotalMenuItems = menuStats.TotalMenuItems
-	stats.AveragePrice = menuStats.AveragePrice
-
-	// Get revenue by category
-	rows, err := DB.Table("menu_items").Select(`
-		category, SUM(price * 100) as estimated_revenue 
-	`).Where("is_available = true").Group("category").Rows()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get revenue by category: %w", err)
+	if DB == nil {
+		return nil, errNotInitialized
 	}
-	defer rows.Close()
-
-	stats.RevenueByCateory = make(map[string]float64)
-	for rows.Next() {
-		var category string
-		var revenue float64
-		if err := rows.Scan(&category, &revenue); err != nil {
-			return nil, fmt.Errorf("failed to scan revenue data: %w", err)
+	db := DB.WithContext(ctx)
+	migrator := db.Migrator()
+	var pending []string
+	for _, model := range migratedModels {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, err
+		}
+		table := stmt.Schema.Table
+		if !migrator.HasTable(model) {
+			pending = append(pending, table)
+			continue
+		}
+		for _, name := range stmt.Schema.DBNames {
+			if !migrator.HasColumn(model, name) {
+				pending = append(pending, table+"."+name)
+			}
 		}
-		stats.RevenueByCateory[category] = revenue
 	}
-
-	// Get detailed per-restaurant business data
-	restaurantRows, err := DB.Table("restaurants r").
-		Select(`
-			r.id, r.name,
-			COUNT(m.id) as menu_count,
-			COALESCE(AVG(m.price), 0) as avg_price,
-			COALESCE(SUM(m.price * 150), 0) as estimated_total_revenue
-		`).
-		Joins("LEFT JOIN menu_items m ON r.id = m.restaurant_id AND m.is_available = true").
-		Where("r.is_active = true").
-		Group("r.id, r.name").
-		Order("estimated_total_revenue DESC").
-		Rows()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get restaurant business data: %w", err)
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
-	defer restaurantRows.Close()
-
-	var restaurantDetails []models.RestaurantBusinessData
-	for restaurantRows.Next() {
-		var detail models.RestaurantBusinessData
-		if err := restaurantRows.Scan(&detail.RestaurantID, &detail.RestaurantName,
-			&detail.MenuItemCount, &detail.AveragePrice, &detail.TotalRevenue); err != nil {
-			return nil, fmt.Errorf("failed to scan restaurant business data: %w", err)
-		}
-		restaurantDetails = append(restaurantDetails, detail)
+	if len(pending) == 0 {
+		schemaUpToDate.Store(true)
 	}
-	stats.RestaurantDetails = restaurantDetails
-
-	return stats, nil
+	return pending, nil
 }
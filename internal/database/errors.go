@@ -0,0 +1,47 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"lunch_menu/internal/apperrors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// Postgres error codes mapped to application errors
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
+
+// appError converts gorm, Postgres and SQLite errors about the given resource
+// (e.g. "restaurant" with ID id) into application errors: missing rows become
+// not-found errors and unique violations conflicts. Other errors are returned as they are.
+func appError(err error, resource string, id interface{}) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperrors.NotFound(resource, id)
+	}
+	uniqueViolation := errors.Is(err, gorm.ErrDuplicatedKey)
+	foreignKeyViolation := errors.Is(err, gorm.ErrForeignKeyViolated)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		uniqueViolation = pgErr.Code == pgUniqueViolation
+		foreignKeyViolation = pgErr.Code == pgForeignKeyViolation
+	}
+	switch {
+	case uniqueViolation:
+		conflict := apperrors.Conflict(fmt.Sprintf("%s already exists", resource))
+		conflict.Err = err
+		return conflict
+	case foreignKeyViolation:
+		invalid := apperrors.Validation(fmt.Sprintf("%s references a record that does not exist", resource))
+		invalid.Err = err
+		return invalid
+	}
+	return err
+}
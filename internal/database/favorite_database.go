@@ -0,0 +1,53 @@
+package database
+
+import (
+	"context"
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// favoriteStore is the GORM implementation of repository.FavoriteRepository
+type favoriteStore struct {
+	db *gorm.DB
+}
+
+// NewFavoriteRepository returns a favorite repository backed by db
+func NewFavoriteRepository(db *gorm.DB) repository.FavoriteRepository {
+	return &favoriteStore{db: db}
+}
+
+// Add stars a target unless the user already did
+func (s *favoriteStore) Add(ctx context.Context, favorite *models.Favorite) (*models.Favorite, error) {
+	err := s.db.WithContext(ctx).Where(models.Favorite{
+		UserID:     favorite.UserID,
+		TargetType: favorite.TargetType,
+		TargetID:   favorite.TargetID,
+	}).FirstOrCreate(favorite).Error
+	if err != nil {
+		return nil, appError(err, "favorite", favorite.TargetID)
+	}
+	return favorite, nil
+}
+
+// Remove unstars a target
+func (s *favoriteStore) Remove(ctx context.Context, userID uint, targetType string, targetID uint) error {
+	result := s.db.WithContext(ctx).Where("user_id = ? AND target_type = ? AND target_id = ?", userID, targetType, targetID).
+		Delete(&models.Favorite{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NotFound("favorite", targetID)
+	}
+	return nil
+}
+
+// List returns the favorites of a user, oldest first
+func (s *favoriteStore) List(ctx context.Context, userID uint) ([]models.Favorite, error) {
+	favorites := []models.Favorite{}
+	err := s.db.WithContext(ctx).Where("user_id = ?", userID).Order("id").Find(&favorites).Error
+	return favorites, err
+}
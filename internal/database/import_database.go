@@ -0,0 +1,179 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"lunch_menu/internal/config"
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/repository"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// errImportRollback aborts the import transaction without reporting a failure
+var errImportRollback = errors.New("import rolled back")
+
+// importStore is the GORM implementation of repository.ImportRepository
+type importStore struct {
+	db *gorm.DB
+}
+
+// NewImportRepository returns an import repository backed by db
+func NewImportRepository(db *gorm.DB) repository.ImportRepository {
+	return &importStore{db: db}
+}
+
+// Import upserts restaurants and menu items in a single transaction.
+// Restaurants are matched by name and menu items by restaurant name and item
+// name (both case-insensitive); matching a deleted restaurant updates it
+// without restoring it. Every row is validated; if any row is invalid,
+// or dryRun is set, the transaction is rolled back and nothing is applied.
+func (s *importStore) Import(ctx context.Context, data *models.CatalogueImport, dryRun bool) (*models.ImportReport, error) {
+	report := &models.ImportReport{DryRun: dryRun, Errors: []models.ImportRowError{}}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range data.Restaurants {
+			if err := importRestaurant(tx, i+1, &data.Restaurants[i], report); err != nil {
+				return err
+			}
+		}
+		for i := range data.MenuItems {
+			if err := importMenuItem(tx, i+1, &data.MenuItems[i], report); err != nil {
+				return err
+			}
+		}
+		if dryRun || len(report.Errors) > 0 {
+			return errImportRollback
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errImportRollback) {
+		return nil, err
+	}
+	report.Applied = err == nil
+	return report, nil
+}
+
+func importRestaurant(tx *gorm.DB, row int, input *models.RestaurantInput, report *models.ImportReport) error {
+	if ok, invalid := input.Validate(); !ok {
+		report.Errors = append(report.Errors, models.ImportRowError{
+			Kind:    "restaurants",
+			Row:     row,
+			Fields:  invalid,
+			Message: "Invalid fields: " + strings.Join(invalid, ", "),
+		})
+		return nil
+	}
+
+	var restaurant models.Restaurant
+	err := tx.Where("LOWER(name) = LOWER(?)", input.Name).Order("id").First(&restaurant).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("restaurants row %d: %w", row, err)
+	}
+	exists := err == nil
+
+	restaurant.Name = input.Name
+	restaurant.Description = input.Description
+	restaurant.Address = input.Address
+	restaurant.Coordinate = input.Coordinate
+	restaurant.Homepage = input.Homepage
+	restaurant.Region = input.Region
+	restaurant.Phone = input.Phone
+	restaurant.Email = input.Email
+	// A deleted restaurant stays deleted; only new ones are active
+	if !exists {
+		restaurant.IsActive = true
+	}
+
+	if err := tx.Save(&restaurant).Error; err != nil {
+		return fmt.Errorf("restaurants row %d: %w", row, err)
+	}
+	eventType := models.EventRestaurantCreated
+	if exists {
+		eventType = models.EventRestaurantUpdated
+		report.Restaurants.Updated++
+	} else {
+		report.Restaurants.Created++
+	}
+	return recordEvent(tx, eventType, restaurant.ID, &restaurant)
+}
+
+func importMenuItem(tx *gorm.DB, row int, input *models.MenuItemImportRow, report *models.ImportReport) error {
+	if ok, invalid := input.Validate(); !ok {
+		report.Errors = append(report.Errors, models.ImportRowError{
+			Kind:    "menu_items",
+			Row:     row,
+			Fields:  invalid,
+			Message: "Invalid fields: " + strings.Join(invalid, ", "),
+		})
+		return nil
+	}
+
+	var restaurant models.Restaurant
+	err := tx.Where("LOWER(name) = LOWER(?)", input.RestaurantName).Order("id").First(&restaurant).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		report.Errors = append(report.Errors, models.ImportRowError{
+			Kind:    "menu_items",
+			Row:     row,
+			Fields:  []string{"restaurant_name"},
+			Message: fmt.Sprintf("restaurant %q does not exist", input.RestaurantName),
+		})
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("menu_items row %d: %w", row, err)
+	}
+
+	var item models.MenuItem
+	err = tx.Where("restaurant_id = ? AND LOWER(name) = LOWER(?)", restaurant.ID, input.Name).Order("id").First(&item).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("menu_items row %d: %w", row, err)
+	}
+	exists := err == nil
+	price, currency := item.Price, item.Currency
+
+	item.RestaurantID = restaurant.ID
+	item.Name = input.Name
+	item.Description = input.Description
+	item.Price = input.Price
+	item.Currency = input.Currency
+	if item.Currency == "" {
+		item.Currency = config.AppConfig.Pricing.Currency
+	}
+	item.Category = input.Category
+	item.IsAvailable = true
+	if input.IsAvailable != nil {
+		item.IsAvailable = *input.IsAvailable
+	}
+
+	if err := tx.Save(&item).Error; err != nil {
+		return fmt.Errorf("menu_items row %d: %w", row, err)
+	}
+	// is_available has a database default, so GORM skips a false value on insert
+	if !exists && !item.IsAvailable {
+		if err := tx.Model(&item).Update("is_available", false).Error; err != nil {
+			return fmt.Errorf("menu_items row %d: %w", row, err)
+		}
+	}
+	if !exists || item.Price != price || item.Currency != currency {
+		if err := recordPriceChange(tx, &item, item.UpdatedAt); err != nil {
+			return fmt.Errorf("menu_items row %d: %w", row, err)
+		}
+		// The default price variant, if any, is the item's price
+		err := tx.Model(&models.MenuItemPrice{}).
+			Where("menu_item_id = ? AND is_default = ?", item.ID, true).
+			Update("price", item.Price).Error
+		if err != nil {
+			return fmt.Errorf("menu_items row %d: %w", row, err)
+		}
+	}
+	eventType := models.EventMenuItemCreated
+	if exists {
+		eventType = models.EventMenuItemUpdated
+		report.MenuItems.Updated++
+	} else {
+		report.MenuItems.Created++
+	}
+	return recordEvent(tx, eventType, item.RestaurantID, &item)
+}
@@ -1,65 +1,105 @@
 package database
 
 import (
-	"errors"
+	"context"
+	"fmt"
+	"strings"
+
+	"lunch_menu/internal/apperrors"
 	"lunch_menu/internal/models"
+	"lunch_menu/internal/repository"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-// CreateMenuItem inserts a new menu item into the database
-func CreateMenuItem(item *models.MenuItem) (*models.MenuItem, error) {
+// menuItemStore is the GORM implementation of repository.MenuItemRepository
+type menuItemStore struct {
+	db *gorm.DB
+}
+
+// NewMenuItemRepository returns a menu item repository backed by db
+func NewMenuItemRepository(db *gorm.DB) repository.MenuItemRepository {
+	return &menuItemStore{db: db}
+}
+
+// Create inserts a new menu item into the database
+func (s *menuItemStore) Create(ctx context.Context, item *models.MenuItem) (*models.MenuItem, error) {
 	item.IsAvailable = true
-	if err := DB.Create(item).Error; err != nil {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(item).Error; err != nil {
+			return appError(err, "menu item", item.Name)
+		}
+		if err := recordPriceChange(tx, item, item.CreatedAt); err != nil {
+			return err
+		}
+		return recordEvent(tx, models.EventMenuItemCreated, item.RestaurantID, item)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return item, nil
 }
 
-// UpdateMenuItem updates an existing menu item by ID
-func UpdateMenuItem(id uint, item *models.MenuItem) (*models.MenuItem, error) {
-	menuItem, err := findMenuItemByID(id)
+// Delete soft deletes a menu item by setting is_available to false
+func (s *menuItemStore) Delete(ctx context.Context, id uint) error {
+	menuItem, err := s.find(ctx, id)
 	if err != nil {
-		return nil, err
-	}
-	menuItem.RestaurantID = item.RestaurantID
-	menuItem.Name = item.Name
-	menuItem.Description = item.Description
-	menuItem.Price = item.Price
-	menuItem.Category = item.Category
-	menuItem.IsAvailable = item.IsAvailable
-
-	if err := DB.Save(menuItem).Error; err != nil {
-		return nil, err
+		return err
 	}
-	return menuItem, nil
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.MenuItem{}).Where("id = ?", id).Update("is_available", false)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.NotFound("menu item", id)
+		}
+		menuItem.IsAvailable = false
+		return recordEvent(tx, models.EventMenuItemDeleted, menuItem.RestaurantID, menuItem)
+	})
 }
 
-// DeleteMenuItem soft deletes a menu item by setting is_available to false
-func DeleteMenuItem(id uint) error {
-	_, err := findMenuItemByID(id)
-	if err != nil {
+// saveMenuItem saves an existing menu item in tx, replacing its price
+// variants, and records the update event. A new price or currency is added to
+// the price history if priceChanged is set.
+func saveMenuItem(tx *gorm.DB, menuItem *models.MenuItem, priceChanged bool) error {
+	if err := tx.Omit(clause.Associations).Save(menuItem).Error; err != nil {
+		return appError(err, "menu item", menuItem.ID)
+	}
+	if err := tx.Where("menu_item_id = ?", menuItem.ID).Delete(&models.MenuItemPrice{}).Error; err != nil {
 		return err
 	}
-	result := DB.Model(&models.MenuItem{}).Where("id = ?", id).Update("is_available", false)
-	if result.Error != nil {
-		return result.Error
+	for i := range menuItem.Prices {
+		menuItem.Prices[i].ID = 0
+		menuItem.Prices[i].MenuItemID = menuItem.ID
+	}
+	if len(menuItem.Prices) > 0 {
+		if err := tx.Create(&menuItem.Prices).Error; err != nil {
+			return appError(err, "menu item price", menuItem.ID)
+		}
 	}
-	if result.RowsAffected == 0 {
-		return errors.New("menu item not found")
+	if priceChanged {
+		if err := recordPriceChange(tx, menuItem, menuItem.UpdatedAt); err != nil {
+			return err
+		}
 	}
-	return nil
+	return recordEvent(tx, models.EventMenuItemUpdated, menuItem.RestaurantID, menuItem)
 }
 
-// GetMenuItems retrieves menu items for a restaurant with pagination
-func GetMenuItems(restaurantID uint, limit, offset int) ([]models.MenuItem, int64, error) {
+// List retrieves menu items for a restaurant with pagination
+func (s *menuItemStore) List(ctx context.Context, restaurantID uint, limit, offset int) ([]models.MenuItem, int64, error) {
 	var items []models.MenuItem
 	var total int64
 
 	// Query for menu items
-	err := DB.Model(&models.MenuItem{}).
-		Where("restaurant_id = ?", restaurantID).
+	query := s.db.WithContext(ctx).Model(&models.MenuItem{}).Preload("Prices", orderPrices)
+	if restaurantID != 0 {
+		query = query.Where("restaurant_id = ?", restaurantID)
+	}
+	err := query.
 		Count(&total).
+		Order("id").
 		Limit(limit).
 		Offset(offset).
 		Find(&items).Error
@@ -67,60 +107,151 @@ func GetMenuItems(restaurantID uint, limit, offset int) ([]models.MenuItem, int6
 	return items, total, err
 }
 
-// GetMenuItemByID retrieves a menu item by its ID
-func GetMenuItemByID(id uint) (*models.MenuItem, error) {
+// ListByRestaurantIDs retrieves the available menu items of several restaurants in a single query
+func (s *menuItemStore) ListByRestaurantIDs(ctx context.Context, restaurantIDs []uint) ([]models.MenuItem, error) {
+	var items []models.MenuItem
+	err := s.db.WithContext(ctx).Preload("Prices", orderPrices).
+		Where("restaurant_id IN ? AND is_available = ?", restaurantIDs, true).
+		Order("restaurant_id, id").
+		Find(&items).Error
+	return items, err
+}
+
+// GetByID retrieves a menu item by its ID
+func (s *menuItemStore) GetByID(ctx context.Context, id uint) (*models.MenuItem, error) {
 	var menuItem models.MenuItem
-	if err := DB.Where("id = ? AND is_available = ?", id, true).First(&menuItem).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("menu item not found")
-		}
-		return nil, err
+	if err := s.db.WithContext(ctx).Preload("Prices", orderPrices).Where("id = ? AND is_available = ?", id, true).First(&menuItem).Error; err != nil {
+		return nil, appError(err, "menu item", id)
 	}
 	return &menuItem, nil
 }
 
-func findMenuItemByID(id uint) (*models.MenuItem, error) {
+func (s *menuItemStore) find(ctx context.Context, id uint) (*models.MenuItem, error) {
 	var menuItem models.MenuItem
-	if err := DB.First(&menuItem, id).Error; err != nil {
-		return nil, err
+	if err := s.db.WithContext(ctx).Preload("Prices", orderPrices).First(&menuItem, id).Error; err != nil {
+		return nil, appError(err, "menu item", id)
 	}
 	return &menuItem, nil
 }
 
-// PartialUpdateMenuItem updates specific fields of an existing menu item by ID
-func PartialUpdateMenuItem(id uint, input *models.MenuItemUpdateInput) (*models.MenuItem, error) {
-	menuItem, err := findMenuItemByID(id)
+// orderPrices lists the price variants of menu items in the order they were given
+func orderPrices(db *gorm.DB) *gorm.DB {
+	return db.Order("id")
+}
+
+// Update updates specific fields of an existing menu item by ID
+func (s *menuItemStore) Update(ctx context.Context, id uint, input *models.MenuItemUpdateInput) (*models.MenuItem, error) {
+	menuItem, err := s.find(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 	if input.RestaurantID != nil {
-		if *input.RestaurantID == 0 {
-			return nil, errors.New("invalid restaurant_id")
-		}
-		// Optionally, check if restaurant exists
+		// Check that the restaurant exists
 		var restaurant models.Restaurant
-		if err := DB.First(&restaurant, *input.RestaurantID).Error; err != nil {
-			return nil, errors.New("restaurant_id does not exist")
+		if *input.RestaurantID == 0 || s.db.WithContext(ctx).First(&restaurant, *input.RestaurantID).Error != nil {
+			return nil, apperrors.InvalidFields([]string{"restaurant_id"})
+		}
+	}
+	price, currency := menuItem.Price, menuItem.Currency
+	input.Apply(menuItem)
+	priceChanged := menuItem.Price != price || menuItem.Currency != currency
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return saveMenuItem(tx, menuItem, priceChanged)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return menuItem, nil
+}
+
+// Stream calls fn for each menu item, in ID order, reading rows one at a time
+// instead of loading the whole result into memory. A restaurantID of 0
+// selects items of all restaurants and a negative limit means no limit.
+func (s *menuItemStore) Stream(ctx context.Context, restaurantID uint, limit, offset int, fn func(*models.MenuItemWithRestaurant) error) error {
+	query := s.db.WithContext(ctx).Table("menu_items").
+		Select("menu_items.*, restaurants.name AS restaurant_name").
+		Joins("JOIN restaurants ON restaurants.id = menu_items.restaurant_id")
+	if restaurantID != 0 {
+		query = query.Where("menu_items.restaurant_id = ?", restaurantID)
+	}
+	rows, err := query.Order("menu_items.id").Limit(limit).Offset(offset).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item models.MenuItemWithRestaurant
+		if err := s.db.WithContext(ctx).ScanRows(rows, &item); err != nil {
+			return err
+		}
+		if err := fn(&item); err != nil {
+			return err
 		}
-		menuItem.RestaurantID = *input.RestaurantID
 	}
-	if input.Name != nil {
-		menuItem.Name = *input.Name
+	return rows.Err()
+}
+
+// Feed ranks the available menu items of active restaurants in the query,
+// so that only the requested page is loaded, with its price variants
+func (s *menuItemStore) Feed(ctx context.Context, q models.FeedQuery, limit, offset int) ([]models.FeedItem, int64, error) {
+	query := s.db.WithContext(ctx).Table("menu_items").
+		Joins("JOIN restaurants ON restaurants.id = menu_items.restaurant_id").
+		Where("menu_items.is_available = ? AND restaurants.is_active = ?", true, true)
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
 	}
-	if input.Description != nil {
-		menuItem.Description = *input.Description
+
+	score, args := feedScore(q)
+	items := []models.FeedItem{}
+	err := query.Select("menu_items.*, restaurants.name AS restaurant_name, restaurants.region AS region, "+score+" AS feed_score", args...).
+		Order("feed_score DESC, menu_items.restaurant_id, menu_items.id").
+		Limit(limit).Offset(offset).
+		Scan(&items).Error
+	if err != nil || len(items) == 0 {
+		return items, total, err
 	}
-	if input.Price != nil {
-		menuItem.Price = *input.Price
+
+	ids := make([]uint, len(items))
+	for i := range items {
+		ids[i] = items[i].ID
 	}
-	if input.Category != nil {
-		menuItem.Category = *input.Category
+	var prices []models.MenuItemPrice
+	if err := s.db.WithContext(ctx).Where("menu_item_id IN ?", ids).Order("id").Find(&prices).Error; err != nil {
+		return nil, 0, err
 	}
-	if input.IsAvailable != nil {
-		menuItem.IsAvailable = *input.IsAvailable
+	for i := range items {
+		for _, p := range prices {
+			if p.MenuItemID == items[i].ID {
+				items[i].Prices = append(items[i].Prices, p)
+			}
+		}
+		items[i].Reasons = q.Reasons(&items[i].MenuItem, items[i].Region)
 	}
-	if err := DB.Save(menuItem).Error; err != nil {
-		return nil, err
+	return items, total, nil
+}
+
+// feedScore returns the SQL expression of the models.FeedScore of a menu
+// item joined with its restaurant, and its arguments
+func feedScore(q models.FeedQuery) (string, []interface{}) {
+	terms := []string{"0"}
+	var args []interface{}
+	add := func(reason, condition string, arg interface{}) {
+		terms = append(terms, fmt.Sprintf("CASE WHEN %s THEN %d ELSE 0 END", condition, models.FeedWeights[reason]))
+		args = append(args, arg)
 	}
-	return menuItem, nil
+	if len(q.FavoriteMenuItems) > 0 {
+		add(models.FeedFavoriteMenuItem, "menu_items.id IN ?", q.FavoriteMenuItems)
+	}
+	if len(q.FavoriteRestaurants) > 0 {
+		add(models.FeedFavoriteRestaurant, "menu_items.restaurant_id IN ?", q.FavoriteRestaurants)
+	}
+	if len(q.Dietary) > 0 {
+		add(models.FeedDietary, "LOWER(menu_items.category) IN ?", q.Dietary)
+	}
+	if q.HomeRegion != "" {
+		add(models.FeedHomeRegion, "LOWER(restaurants.region) = ?", q.HomeRegion)
+	}
+	return "(" + strings.Join(terms, " + ") + ")", args
 }
@@ -0,0 +1,135 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/repository"
+	"slices"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EventsChannel is the Postgres NOTIFY channel on which the IDs of new outbox events are published
+const EventsChannel = "lunch_menu_events"
+
+// skipLocked locks the selected rows FOR UPDATE SKIP LOCKED on Postgres, so
+// that concurrent replicas claim different rows. SQLite has no row locks; its
+// write transactions already run one at a time.
+func skipLocked(tx *gorm.DB) *gorm.DB {
+	if !IsPostgres(tx) {
+		return tx
+	}
+	return tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+}
+
+// recordEvent writes a change event to the outbox using the caller's
+// transaction, so the event is committed if and only if the change is.
+// On Postgres, listeners on EventsChannel are notified when the transaction commits.
+func recordEvent(tx *gorm.DB, eventType string, restaurantID uint, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", eventType, err)
+	}
+	var regions []string
+	if err := tx.Model(&models.Restaurant{}).Where("id = ?", restaurantID).Pluck("region", &regions).Error; err != nil {
+		return err
+	}
+	event := models.OutboxEvent{
+		EventType:    eventType,
+		RestaurantID: restaurantID,
+		Payload:      string(payload),
+	}
+	if len(regions) > 0 {
+		event.Region = regions[0]
+	}
+	if err := tx.Create(&event).Error; err != nil {
+		return err
+	}
+	if !IsPostgres(tx) {
+		return nil
+	}
+	return tx.Exec("SELECT pg_notify(?, ?)", EventsChannel, strconv.FormatUint(uint64(event.ID), 10)).Error
+}
+
+// outboxStore is the GORM implementation of repository.OutboxRepository
+type outboxStore struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository returns an outbox repository backed by db
+func NewOutboxRepository(db *gorm.DB) repository.OutboxRepository {
+	return &outboxStore{db: db}
+}
+
+// Dispatch turns up to limit undispatched outbox events into pending webhook
+// deliveries for every matching active subscription and marks the events as
+// dispatched. Rows are locked with SKIP LOCKED so that several API replicas
+// can dispatch concurrently. It returns the number of events handled.
+func (s *outboxStore) Dispatch(ctx context.Context, limit int) (int, error) {
+	var handled int
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var events []models.OutboxEvent
+		if err := skipLocked(tx).
+			Where("dispatched_at IS NULL").
+			Order("id").
+			Limit(limit).
+			Find(&events).Error; err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		var subscriptions []models.WebhookSubscription
+		if err := tx.Where("is_active = ?", true).Find(&subscriptions).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		ids := make([]uint, 0, len(events))
+		var deliveries []models.WebhookDelivery
+		for _, event := range events {
+			ids = append(ids, event.ID)
+			deliveries = append(deliveries, models.NewWebhookDeliveries(&event, subscriptions, now)...)
+		}
+		if len(deliveries) > 0 {
+			if err := tx.Create(&deliveries).Error; err != nil {
+				return err
+			}
+		}
+		handled = len(events)
+		return tx.Model(&models.OutboxEvent{}).Where("id IN ?", ids).Update("dispatched_at", now).Error
+	})
+	return handled, err
+}
+
+// GetByID retrieves an outbox event by its ID
+func (s *outboxStore) GetByID(ctx context.Context, id uint) (*models.OutboxEvent, error) {
+	var event models.OutboxEvent
+	if err := s.db.WithContext(ctx).First(&event, id).Error; err != nil {
+		return nil, appError(err, "event", id)
+	}
+	return &event, nil
+}
+
+// After retrieves up to limit outbox events with an ID greater than afterID, oldest first
+func (s *outboxStore) After(ctx context.Context, afterID uint, limit int) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	err := s.db.WithContext(ctx).Where("id > ?", afterID).Order("id").Limit(limit).Find(&events).Error
+	return events, err
+}
+
+// Latest retrieves the limit most recent outbox events, oldest first
+func (s *outboxStore) Latest(ctx context.Context, limit int) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	if err := s.db.WithContext(ctx).Order("id DESC").Limit(limit).Find(&events).Error; err != nil {
+		return nil, err
+	}
+	slices.Reverse(events)
+	return events, nil
+}
@@ -0,0 +1,145 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// priceHistoryStore is the GORM implementation of repository.PriceHistoryRepository
+type priceHistoryStore struct {
+	db *gorm.DB
+}
+
+// NewPriceHistoryRepository returns a price history repository backed by db
+func NewPriceHistoryRepository(db *gorm.DB) repository.PriceHistoryRepository {
+	return &priceHistoryStore{db: db}
+}
+
+// recordPriceChange adds the current price of item to its history as a change
+// that took effect at the given time
+func recordPriceChange(tx *gorm.DB, item *models.MenuItem, at time.Time) error {
+	return tx.Create(&models.PriceChange{
+		MenuItemID:    item.ID,
+		Price:         item.Price,
+		Currency:      item.Currency,
+		EffectiveFrom: at,
+		AppliedAt:     &at,
+	}).Error
+}
+
+// History returns the applied and scheduled changes of a menu item
+func (s *priceHistoryStore) History(ctx context.Context, menuItemID uint) ([]models.PriceChange, error) {
+	var changes []models.PriceChange
+	err := s.db.WithContext(ctx).Where("menu_item_id = ?", menuItemID).Order("effective_from, id").Find(&changes).Error
+	return changes, err
+}
+
+// Schedule stores a future price change of a menu item
+func (s *priceHistoryStore) Schedule(ctx context.Context, change *models.PriceChange) (*models.PriceChange, error) {
+	change.AppliedAt = nil
+	if err := s.db.WithContext(ctx).Create(change).Error; err != nil {
+		return nil, appError(err, "price change", change.MenuItemID)
+	}
+	return change, nil
+}
+
+// CancelScheduled deletes a scheduled price change of a menu item
+func (s *priceHistoryStore) CancelScheduled(ctx context.Context, menuItemID, changeID uint) error {
+	var change models.PriceChange
+	if err := s.db.WithContext(ctx).Where("id = ? AND menu_item_id = ?", changeID, menuItemID).First(&change).Error; err != nil {
+		return appError(err, "price change", changeID)
+	}
+	result := s.db.WithContext(ctx).Where("id = ? AND applied_at IS NULL", changeID).Delete(&models.PriceChange{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.Conflict("price change has already taken effect")
+	}
+	return nil
+}
+
+// ApplyDue applies the scheduled price changes that took effect at or before
+// now, each in a transaction with the update of its menu item and the outbox
+// event, in the order they took effect. A failing change does not hold up
+// the others: it is rolled back, retried on the next run and reported in the
+// returned error. A change whose menu item no longer exists stays claimed,
+// so it is not retried.
+func (s *priceHistoryStore) ApplyDue(ctx context.Context, now time.Time) (int, error) {
+	var due []models.PriceChange
+	err := s.db.WithContext(ctx).Where("applied_at IS NULL AND effective_from <= ?", now).
+		Order("effective_from, id").
+		Find(&due).Error
+	if err != nil {
+		return 0, err
+	}
+
+	applied := 0
+	var errs []error
+	for i := range due {
+		change := &due[i]
+		claimed := false
+		err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			// Claim the change first, so that it is applied once across replicas
+			result := tx.Model(&models.PriceChange{}).
+				Where("id = ? AND applied_at IS NULL", change.ID).
+				Update("applied_at", now)
+			if result.Error != nil || result.RowsAffected == 0 {
+				return result.Error
+			}
+			claimed = true
+
+			var menuItem models.MenuItem
+			err := tx.Preload("Prices", orderPrices).First(&menuItem, change.MenuItemID).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				slog.Warn("pricing: menu item of scheduled price change not found, skipped",
+					slog.Uint64("price_change_id", uint64(change.ID)), slog.Uint64("menu_item_id", uint64(change.MenuItemID)))
+				claimed = false
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			input := models.MenuItemUpdateInput{Price: &change.Price, Currency: &change.Currency}
+			input.Apply(&menuItem)
+			// The claimed change is the history entry of the new price
+			return saveMenuItem(tx, &menuItem, false)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("price change %d: %w", change.ID, err))
+			continue
+		}
+		if claimed {
+			applied++
+		}
+	}
+	return applied, errors.Join(errs...)
+}
+
+// Changes returns the applied price changes from from up to to, and the last
+// one of each menu item before from, with the attributes of their menu items
+func (s *priceHistoryStore) Changes(ctx context.Context, from, to time.Time) ([]models.PriceChangeWithItem, error) {
+	db := s.db.WithContext(ctx)
+	previous := db.Table("price_changes p").Select("p.id").
+		Where("p.menu_item_id = c.menu_item_id AND p.applied_at IS NOT NULL AND p.effective_from < ?", from).
+		Order("p.effective_from DESC, p.id DESC").Limit(1)
+	var changes []models.PriceChangeWithItem
+	err := db.Table("price_changes c").
+		Select("c.*, m.restaurant_id, r.name AS restaurant_name, r.region, m.category").
+		Joins("JOIN menu_items m ON m.id = c.menu_item_id").
+		Joins("JOIN restaurants r ON r.id = m.restaurant_id").
+		Where("c.applied_at IS NOT NULL AND c.effective_from <= ?", to).
+		Where("c.effective_from >= ? OR c.id = (?)", from, previous).
+		Order("c.effective_from, c.id").
+		Scan(&changes).Error
+	return changes, err
+}
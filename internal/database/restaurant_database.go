@@ -1,115 +1,137 @@
 package database
 
 import (
-	"errors"
+	"context"
+	"lunch_menu/internal/apperrors"
 	"lunch_menu/internal/models"
+	"lunch_menu/internal/repository"
 
 	"gorm.io/gorm"
 )
 
-// CreateRestaurant inserts a new restaurant into the database
-func CreateRestaurant(r *models.Restaurant) (*models.Restaurant, error) {
+// restaurantStore is the GORM implementation of repository.RestaurantRepository
+type restaurantStore struct {
+	db *gorm.DB
+}
+
+// NewRestaurantRepository returns a restaurant repository backed by db
+func NewRestaurantRepository(db *gorm.DB) repository.RestaurantRepository {
+	return &restaurantStore{db: db}
+}
+
+// Create inserts a new restaurant into the database
+func (s *restaurantStore) Create(ctx context.Context, r *models.Restaurant) (*models.Restaurant, error) {
 	r.IsActive = true
-	if err := DB.Create(r).Error; err != nil {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(r).Error; err != nil {
+			return appError(err, "restaurant", r.Name)
+		}
+		return recordEvent(tx, models.EventRestaurantCreated, r.ID, r)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return r, nil
 }
 
-// UpdateRestaurant updates an existing restaurant by ID
-func UpdateRestaurant(id uint, r *models.Restaurant) (*models.Restaurant, error) {
-	var restaurant models.Restaurant
-	if err := DB.First(&restaurant, id).Error; err != nil {
-		return nil, err
-	}
-	// Update fields
-	restaurant.Name = r.Name
-	restaurant.Description = r.Description
-	restaurant.Address = r.Address
-	restaurant.Coordinate = r.Coordinate
-	restaurant.Homepage = r.Homepage
-	restaurant.Region = r.Region
-	restaurant.Phone = r.Phone
-	restaurant.Email = r.Email
-	restaurant.IsActive = r.IsActive
-
-	if err := DB.Save(&restaurant).Error; err != nil {
-		return nil, err
-	}
-	return &restaurant, nil
+// Delete soft deletes a restaurant by setting is_active to false
+func (s *restaurantStore) Delete(ctx context.Context, id uint) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Restaurant{}).
+			Where("id = ?", id).
+			Update("is_active", false)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.NotFound("restaurant", id)
+		}
+		var restaurant models.Restaurant
+		if err := tx.First(&restaurant, id).Error; err != nil {
+			return err
+		}
+		return recordEvent(tx, models.EventRestaurantDeleted, restaurant.ID, &restaurant)
+	})
 }
 
-// DeleteRestaurant soft deletes a restaurant by setting is_active to false
-func DeleteRestaurant(id uint) error {
-	return DB.Model(&models.Restaurant{}).
-		Where("id = ?", id).
-		Update("is_active", false).Error
+// save saves an existing restaurant and records the update event
+func (s *restaurantStore) save(ctx context.Context, restaurant *models.Restaurant) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(restaurant).Error; err != nil {
+			return appError(err, "restaurant", restaurant.ID)
+		}
+		return recordEvent(tx, models.EventRestaurantUpdated, restaurant.ID, restaurant)
+	})
 }
 
-// GetRestaurants retrieves all active restaurants with pagination
-func GetRestaurants(limit, offset int) ([]models.Restaurant, int64, error) {
+// List retrieves all active restaurants with pagination
+func (s *restaurantStore) List(ctx context.Context, limit, offset int) ([]models.Restaurant, int64, error) {
 	var restaurants []models.Restaurant
 	var total int64
 
-	if err := DB.Model(&models.Restaurant{}).Where("is_active = ?", true).Count(&total).Error; err != nil {
+	if err := s.db.WithContext(ctx).Model(&models.Restaurant{}).Where("is_active = ?", true).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	if err := DB.Where("is_active = ?", true).Order("id").Limit(limit).Offset(offset).Find(&restaurants).Error; err != nil {
+	if err := s.db.WithContext(ctx).Where("is_active = ?", true).Order("id").Limit(limit).Offset(offset).Find(&restaurants).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return restaurants, total, nil
 }
 
-// GetRestaurantByID retrieves a single active restaurant by ID
-func GetRestaurantByID(id uint) (*models.Restaurant, error) {
+// GetByID retrieves a single active restaurant by ID
+func (s *restaurantStore) GetByID(ctx context.Context, id uint) (*models.Restaurant, error) {
 	var restaurant models.Restaurant
-	if err := DB.Where("id = ? AND is_active = ?", id, true).First(&restaurant).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("restaurant not found")
-		}
-		return nil, err
+	if err := s.db.WithContext(ctx).Where("id = ? AND is_active = ?", id, true).First(&restaurant).Error; err != nil {
+		return nil, appError(err, "restaurant", id)
 	}
 	return &restaurant, nil
 }
 
-// PartialUpdateRestaurant updates only the non-zero fields of an existing restaurant
-func PartialUpdateRestaurant(id uint, input *models.RestaurantUpdateInput) (*models.Restaurant, error) {
+// GetByIDs retrieves the active restaurants with the given IDs in a single query
+func (s *restaurantStore) GetByIDs(ctx context.Context, ids []uint) ([]models.Restaurant, error) {
+	var restaurants []models.Restaurant
+	err := s.db.WithContext(ctx).Where("id IN ? AND is_active = ?", ids, true).Order("id").Find(&restaurants).Error
+	return restaurants, err
+}
+
+// Update updates only the non-nil fields of an existing restaurant
+func (s *restaurantStore) Update(ctx context.Context, id uint, input *models.RestaurantUpdateInput) (*models.Restaurant, error) {
 	var restaurant models.Restaurant
-	if err := DB.First(&restaurant, id).Error; err != nil {
-		return nil, err
-	}
-	// Only update fields that are not nil
-	if input.Name != nil {
-		restaurant.Name = *input.Name
-	}
-	if input.Description != nil {
-		restaurant.Description = *input.Description
-	}
-	if input.Address != nil {
-		restaurant.Address = *input.Address
-	}
-	if input.Coordinate != nil {
-		restaurant.Coordinate = *input.Coordinate
-	}
-	if input.Homepage != nil {
-		restaurant.Homepage = *input.Homepage
-	}
-	if input.Region != nil {
-		restaurant.Region = *input.Region
+	if err := s.db.WithContext(ctx).First(&restaurant, id).Error; err != nil {
+		return nil, appError(err, "restaurant", id)
 	}
-	if input.Phone != nil {
-		restaurant.Phone = *input.Phone
-	}
-	if input.Email != nil {
-		restaurant.Email = *input.Email
-	}
-	if input.IsActive != nil {
-		restaurant.IsActive = *input.IsActive
-	}
-	if err := DB.Save(&restaurant).Error; err != nil {
+	input.Apply(&restaurant)
+	if err := s.save(ctx, &restaurant); err != nil {
 		return nil, err
 	}
 	return &restaurant, nil
 }
+
+// Stream calls fn for each active restaurant, in ID order, reading rows one at
+// a time instead of loading the whole result into memory.
+// A negative limit means no limit.
+func (s *restaurantStore) Stream(ctx context.Context, limit, offset int, fn func(*models.Restaurant) error) error {
+	rows, err := s.db.WithContext(ctx).Model(&models.Restaurant{}).
+		Where("is_active = ?", true).
+		Order("id").
+		Limit(limit).
+		Offset(offset).
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var restaurant models.Restaurant
+		if err := s.db.WithContext(ctx).ScanRows(rows, &restaurant); err != nil {
+			return err
+		}
+		if err := fn(&restaurant); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,125 @@
+package database
+
+import (
+	"context"
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// reviewStore is the GORM implementation of repository.ReviewRepository
+type reviewStore struct {
+	db *gorm.DB
+}
+
+// NewReviewRepository returns a review repository backed by db
+func NewReviewRepository(db *gorm.DB) repository.ReviewRepository {
+	return &reviewStore{db: db}
+}
+
+// Create inserts a new review; the unique index on the user, the target and
+// the date rejects a second review of the same day
+func (s *reviewStore) Create(ctx context.Context, review *models.Review) (*models.Review, error) {
+	review.Hidden, review.Flagged = false, false
+	if err := s.db.WithContext(ctx).Create(review).Error; err != nil {
+		return nil, appError(err, "review", review.TargetID)
+	}
+	return review, nil
+}
+
+// Update changes the rating or comment of a review
+func (s *reviewStore) Update(ctx context.Context, id uint, input *models.ReviewUpdateInput) (*models.Review, error) {
+	return s.update(ctx, id, input.Columns())
+}
+
+// Moderate hides, flags or restores a review
+func (s *reviewStore) Moderate(ctx context.Context, id uint, input *models.ReviewModerationInput) (*models.Review, error) {
+	return s.update(ctx, id, input.Columns())
+}
+
+// update sets only the given columns of a review, so that an edit and a
+// moderation of the same review do not undo each other
+func (s *reviewStore) update(ctx context.Context, id uint, columns map[string]interface{}) (*models.Review, error) {
+	if len(columns) > 0 {
+		result := s.db.WithContext(ctx).Model(&models.Review{ID: id}).Updates(columns)
+		if result.Error != nil {
+			return nil, appError(result.Error, "review", id)
+		}
+		if result.RowsAffected == 0 {
+			return nil, apperrors.NotFound("review", id)
+		}
+	}
+	return s.GetByID(ctx, id)
+}
+
+// Delete removes a review
+func (s *reviewStore) Delete(ctx context.Context, id uint) error {
+	result := s.db.WithContext(ctx).Delete(&models.Review{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NotFound("review", id)
+	}
+	return nil
+}
+
+// GetByID returns a review
+func (s *reviewStore) GetByID(ctx context.Context, id uint) (*models.Review, error) {
+	var review models.Review
+	if err := s.db.WithContext(ctx).First(&review, id).Error; err != nil {
+		return nil, appError(err, "review", id)
+	}
+	return &review, nil
+}
+
+// List returns a page of the reviews selected by filter, newest first
+func (s *reviewStore) List(ctx context.Context, filter models.ReviewFilter, limit, offset int) ([]models.Review, int64, error) {
+	query := s.db.WithContext(ctx).Model(&models.Review{})
+	if filter.TargetType != "" {
+		query = query.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.TargetID != 0 {
+		query = query.Where("target_id = ?", filter.TargetID)
+	}
+	if filter.Hidden != nil {
+		query = query.Where("hidden = ?", *filter.Hidden)
+	}
+	if filter.Flagged != nil {
+		query = query.Where("flagged = ?", *filter.Flagged)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	reviews := []models.Review{}
+	err := query.Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&reviews).Error
+	return reviews, total, err
+}
+
+// Ratings summarizes the visible reviews of the given targets
+func (s *reviewStore) Ratings(ctx context.Context, targetType string, targetIDs []uint) (map[uint]models.RatingSummary, error) {
+	ratings := make(map[uint]models.RatingSummary, len(targetIDs))
+	if len(targetIDs) == 0 {
+		return ratings, nil
+	}
+	var rows []struct {
+		TargetID uint
+		Total    int64
+		Count    int64
+	}
+	err := s.db.WithContext(ctx).Model(&models.Review{}).
+		Select("target_id, SUM(rating) AS total, COUNT(*) AS count").
+		Where("target_type = ? AND target_id IN ? AND hidden = ?", targetType, targetIDs, false).
+		Group("target_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		ratings[row.TargetID] = models.NewRatingSummary(row.Total, row.Count)
+	}
+	return ratings, nil
+}
@@ -0,0 +1,154 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"lunch_menu/internal/config"
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/repository"
+	"lunch_menu/internal/tracing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// statisticsStore is the GORM implementation of repository.StatisticsRepository
+type statisticsStore struct {
+	db *gorm.DB
+}
+
+// NewStatisticsRepository returns a statistics repository backed by db
+func NewStatisticsRepository(db *gorm.DB) repository.StatisticsRepository {
+	return &statisticsStore{db: db}
+}
+
+// tierPriceJoin joins the price variant of a tier to the menu items m, and
+// tierPrice selects it or the default price of items without the variant
+const (
+	tierPriceJoin = "LEFT JOIN menu_item_prices p ON p.menu_item_id = m.id AND p.tier = ?"
+	tierPrice     = "COALESCE(p.price, m.price)"
+)
+
+// Compute retrieves business analytics data selected by filter.
+// Restaurants are counted in SQL; the menu items of the active restaurants are
+// loaded with the price of the requested tier and their medians, percentiles
+// and histogram are computed in Go, the same on every database. Each of its
+// queries is traced as a child span of ctx.
+func (s *statisticsStore) Compute(ctx context.Context, filter models.StatisticsFilter) (stats *models.BusinessStatistics, err error) {
+	ctx, span := tracing.Start(ctx, "database.ComputeStatistics")
+	defer func() { tracing.End(span, err) }()
+	now := time.Now()
+	stats = models.NewBusinessStatistics(filter, config.AppConfig.Pricing.Currency, now)
+
+	// Get restaurant counts
+	var counts struct {
+		Total    int64
+		Active   int64
+		Inactive int64
+	}
+
+	countsCtx, countsSpan := tracing.Start(ctx, "statistics.restaurant_counts")
+	countsQuery := s.db.WithContext(countsCtx).Table("restaurants").Select(`
+		COUNT(*) as total,
+		COUNT(CASE WHEN is_active = ? THEN 1 END) as active,
+		COUNT(CASE WHEN is_active = ? THEN 1 END) as inactive
+	`, true, false)
+	if filter.Region != "" {
+		countsQuery = countsQuery.Where("region = ?", filter.Region)
+	}
+	err = countsQuery.Scan(&counts).Error
+	tracing.End(countsSpan, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get restaurant stats: %w", err)
+	}
+
+	stats.TotalRestaurants = counts.Total
+	stats.ActiveRestaurants = counts.Active
+	stats.InactiveRestaurants = counts.Inactive
+
+	// Get the active restaurants with the time their menu last changed,
+	// regardless of the date range, and their views within it. The time is
+	// selected from the most recently updated item rather than with MAX,
+	// which SQLite returns as text.
+	views := "SELECT COUNT(*) FROM view_events v WHERE v.restaurant_id = r.id"
+	var viewArgs []interface{}
+	if !filter.From.IsZero() {
+		views += " AND v.viewed_at >= ?"
+		viewArgs = append(viewArgs, filter.From)
+	}
+	if !filter.To.IsZero() {
+		views += " AND v.viewed_at < ?"
+		viewArgs = append(viewArgs, filter.To)
+	}
+	var restaurants []models.StatisticsRestaurant
+	restaurantsCtx, restaurantsSpan := tracing.Start(ctx, "statistics.restaurants")
+	restaurantsQuery := s.db.WithContext(restaurantsCtx).Table("restaurants r").
+		Select("r.id, r.name, r.region, m.updated_at AS last_menu_update, ("+views+") AS views", viewArgs...).
+		Joins(`LEFT JOIN menu_items m ON m.id = (
+			SELECT l.id FROM menu_items l WHERE l.restaurant_id = r.id ORDER BY l.updated_at DESC, l.id DESC LIMIT 1
+		)`).
+		Where("r.is_active = ?", true).
+		Order("r.name, r.id")
+	if filter.Region != "" {
+		restaurantsQuery = restaurantsQuery.Where("r.region = ?", filter.Region)
+	}
+	err = restaurantsQuery.Scan(&restaurants).Error
+	tracing.End(restaurantsSpan, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get restaurants: %w", err)
+	}
+
+	// Get the available menu items of the active restaurants with the price
+	// of the requested tier, the default price for items that do not have
+	// it; no variant has an empty tier, so without a tier the join matches
+	// nothing.
+	var items []models.StatisticsMenuItem
+	itemsCtx, itemsSpan := tracing.Start(ctx, "statistics.menu_items")
+	itemsQuery := s.db.WithContext(itemsCtx).Table("menu_items m").
+		Select("m.id, m.name, m.restaurant_id, m.category, "+tierPrice+" AS price, m.currency").
+		Joins("JOIN restaurants r ON r.id = m.restaurant_id").
+		Joins(tierPriceJoin, filter.PriceTier).
+		Where("m.is_available = ? AND r.is_active = ?", true, true).
+		Order("m.id")
+	if filter.Region != "" {
+		itemsQuery = itemsQuery.Where("r.region = ?", filter.Region)
+	}
+	if !filter.From.IsZero() {
+		itemsQuery = itemsQuery.Where("m.created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		itemsQuery = itemsQuery.Where("m.created_at < ?", filter.To)
+	}
+	err = itemsQuery.Scan(&items).Error
+	tracing.End(itemsSpan, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get menu items: %w", err)
+	}
+
+	stats.Summarize(restaurants, items, filter.BucketWidth, now)
+	return stats, nil
+}
+
+// Snapshot retrieves the precomputed statistics stored under key, nil if
+// there are none
+func (s *statisticsStore) Snapshot(ctx context.Context, key string) (*models.StatisticsSnapshot, error) {
+	var snapshots []models.StatisticsSnapshot
+	if err := s.db.WithContext(ctx).Where("key = ?", key).Limit(1).Find(&snapshots).Error; err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, nil
+	}
+	return &snapshots[0], nil
+}
+
+// SaveSnapshot stores precomputed statistics, replacing those with the same
+// key
+func (s *statisticsStore) SaveSnapshot(ctx context.Context, snapshot *models.StatisticsSnapshot) error {
+	return s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"data", "generated_at"}),
+	}).Create(snapshot).Error
+}
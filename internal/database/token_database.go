@@ -0,0 +1,93 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// tokenStore is the GORM implementation of repository.TokenRepository
+type tokenStore struct {
+	db *gorm.DB
+}
+
+// NewTokenRepository returns a token repository backed by db
+func NewTokenRepository(db *gorm.DB) repository.TokenRepository {
+	return &tokenStore{db: db}
+}
+
+// SaveRefreshToken saves a hashed refresh token in the database.
+func (s *tokenStore) SaveRefreshToken(ctx context.Context, userID uint, token, userAgent, ip string, expiresAt time.Time) error {
+	tokenHash := models.HashRefreshToken(token)
+
+	if userAgent == "" {
+		userAgent = "unknown"
+	}
+	if ip == "" {
+		ip = "unknown"
+	}
+
+	// Upsert using GORM
+	var rt models.RefreshToken
+	result := s.db.WithContext(ctx).Where("user_id = ? AND user_agent = ? AND ip_address = ?", userID, userAgent, ip).First(&rt)
+	if result.Error == nil {
+		// Update existing
+		rt.TokenHash = tokenHash
+		rt.ExpiresAt = expiresAt
+		rt.RevokedAt = nil
+		return s.db.WithContext(ctx).Save(&rt).Error
+	} else if !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return result.Error
+	}
+
+	// Create new
+	rt = models.RefreshToken{
+		UserID:    userID,
+		TokenHash: tokenHash,
+		UserAgent: userAgent,
+		IPAddress: ip,
+		ExpiresAt: expiresAt,
+	}
+	return s.db.WithContext(ctx).Create(&rt).Error
+}
+
+// GetRefreshToken finds a refresh token by its raw value and user ID.
+func (s *tokenStore) GetRefreshToken(ctx context.Context, userID int, token string) (*models.RefreshToken, error) {
+	var rt models.RefreshToken
+	err := s.db.WithContext(ctx).Where(
+		"user_id = ? AND token_hash = ? AND revoked_at IS NULL AND expires_at > ?",
+		userID, models.HashRefreshToken(token), time.Now(),
+	).First(&rt).Error
+	if err != nil {
+		return nil, appError(err, "refresh token", userID)
+	}
+	return &rt, nil
+}
+
+// DeleteRefreshToken deletes a refresh token for a user
+func (s *tokenStore) DeleteRefreshToken(ctx context.Context, userID uint, token string) error {
+	return s.db.WithContext(ctx).Where("user_id = ? AND token_hash = ?", userID, models.HashRefreshToken(token)).
+		Delete(&models.RefreshToken{}).Error
+}
+
+// BlacklistToken adds an access token to the blacklist
+func (s *tokenStore) BlacklistToken(ctx context.Context, token string, expiresAt time.Time) error {
+	return s.db.WithContext(ctx).Create(&models.BlacklistedToken{
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}).Error
+}
+
+// IsTokenBlacklisted checks if an access token is blacklisted
+func (s *tokenStore) IsTokenBlacklisted(ctx context.Context, token string) (bool, error) {
+	var count int64
+	err := s.db.WithContext(ctx).Model(&models.BlacklistedToken{}).
+		Where("token = ? AND expires_at > ?", token, time.Now()).
+		Count(&count).Error
+	return count > 0, err
+}
@@ -1,43 +1,53 @@
 package database
 
 import (
-	"errors"
+	"context"
 	"lunch_menu/internal/models"
+	"lunch_menu/internal/repository"
 
 	"gorm.io/gorm"
 )
 
-// CreateUser inserts a new user into the database
-func CreateUser(user *models.User) (*models.User, error) {
-	if err := DB.Create(user).Error; err != nil {
-		return nil, err
+// userStore is the GORM implementation of repository.UserRepository
+type userStore struct {
+	db *gorm.DB
+}
+
+// NewUserRepository returns a user repository backed by db
+func NewUserRepository(db *gorm.DB) repository.UserRepository {
+	return &userStore{db: db}
+}
+
+// Create inserts a new user into the database
+func (s *userStore) Create(ctx context.Context, user *models.User) (*models.User, error) {
+	if err := s.db.WithContext(ctx).Create(user).Error; err != nil {
+		return nil, appError(err, "user", user.Username)
 	}
 	return user, nil
 }
 
-// GetUserByUsername retrieves a user by username
-func GetUserByUsername(username string) (*models.User, error) {
+// GetByUsername retrieves a user by username
+func (s *userStore) GetByUsername(ctx context.Context, username string) (*models.User, error) {
 	var user models.User
-	err := DB.Where("username = ?", username).First(&user).Error
-	return &user, err
+	err := s.db.WithContext(ctx).Where("username = ?", username).First(&user).Error
+	return &user, appError(err, "user", username)
 }
 
-// GetUserByID retrieves a user by their ID
-func GetUserByID(userID int) (*models.User, error) {
+// GetByID retrieves a user by their ID
+func (s *userStore) GetByID(ctx context.Context, userID int) (*models.User, error) {
 	var user models.User
-	err := DB.First(&user, userID).Error
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, errors.New("user not found")
+	if err := s.db.WithContext(ctx).First(&user, userID).Error; err != nil {
+		return nil, appError(err, "user", userID)
 	}
-	return &user, err
+	return &user, nil
 }
 
-// UpdateUser updates an existing user in the database
-func UpdateUser(user *models.User) error {
-	return DB.Save(user).Error
+// Update updates an existing user in the database
+func (s *userStore) Update(ctx context.Context, user *models.User) error {
+	return s.db.WithContext(ctx).Save(user).Error
 }
 
-// DeleteUser deletes a user from the database
-func DeleteUser(userID uint) error {
-	return DB.Delete(&models.User{}, userID).Error
+// Delete deletes a user from the database
+func (s *userStore) Delete(ctx context.Context, userID uint) error {
+	return s.db.WithContext(ctx).Delete(&models.User{}, userID).Error
 }
@@ -0,0 +1,64 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// viewBatchSize bounds the number of rows inserted by one statement
+const viewBatchSize = 500
+
+// viewStore is the GORM implementation of repository.ViewRepository
+type viewStore struct {
+	db *gorm.DB
+}
+
+// NewViewRepository returns a view event repository backed by db
+func NewViewRepository(db *gorm.DB) repository.ViewRepository {
+	return &viewStore{db: db}
+}
+
+// Record inserts a batch of view events
+func (s *viewStore) Record(ctx context.Context, views []models.ViewEvent) error {
+	if len(views) == 0 {
+		return nil
+	}
+	return s.db.WithContext(ctx).CreateInBatches(views, viewBatchSize).Error
+}
+
+// Trending returns the most viewed active restaurants and available menu
+// items since the given time
+func (s *viewStore) Trending(ctx context.Context, since time.Time, limit int) ([]models.TrendingRestaurant, []models.TrendingMenuItem, error) {
+	restaurants := []models.TrendingRestaurant{}
+	err := s.db.WithContext(ctx).Table("view_events v").
+		Select("v.restaurant_id, r.name AS restaurant_name, r.region, COUNT(*) AS views").
+		Joins("JOIN restaurants r ON r.id = v.restaurant_id").
+		Where("v.viewed_at >= ? AND r.is_active = ?", since, true).
+		Group("v.restaurant_id, r.name, r.region").
+		Order("views DESC, v.restaurant_id").
+		Limit(limit).
+		Scan(&restaurants).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items := []models.TrendingMenuItem{}
+	err = s.db.WithContext(ctx).Table("view_events v").
+		Select("v.menu_item_id, m.name, m.restaurant_id, r.name AS restaurant_name, COUNT(*) AS views").
+		Joins("JOIN menu_items m ON m.id = v.menu_item_id").
+		Joins("JOIN restaurants r ON r.id = m.restaurant_id").
+		Where("v.kind = ? AND v.viewed_at >= ? AND m.is_available = ? AND r.is_active = ?", models.ViewMenuItem, since, true, true).
+		Group("v.menu_item_id, m.name, m.restaurant_id, r.name").
+		Order("views DESC, v.menu_item_id").
+		Limit(limit).
+		Scan(&items).Error
+	if err != nil {
+		return nil, nil, err
+	}
+	return restaurants, items, nil
+}
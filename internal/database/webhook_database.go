@@ -0,0 +1,148 @@
+package database
+
+import (
+	"context"
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// webhookStore is the GORM implementation of repository.WebhookRepository
+type webhookStore struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository returns a webhook repository backed by db
+func NewWebhookRepository(db *gorm.DB) repository.WebhookRepository {
+	return &webhookStore{db: db}
+}
+
+// Create inserts a new webhook subscription into the database
+func (s *webhookStore) Create(ctx context.Context, sub *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	if err := s.db.WithContext(ctx).Create(sub).Error; err != nil {
+		return nil, err
+	}
+	// is_active has a database default, so GORM skips a false value on insert
+	if !sub.IsActive {
+		if err := s.db.WithContext(ctx).Model(sub).Update("is_active", false).Error; err != nil {
+			return nil, err
+		}
+	}
+	return sub, nil
+}
+
+// List retrieves webhook subscriptions with pagination
+func (s *webhookStore) List(ctx context.Context, limit, offset int) ([]models.WebhookSubscription, int64, error) {
+	var subs []models.WebhookSubscription
+	var total int64
+
+	if err := s.db.WithContext(ctx).Model(&models.WebhookSubscription{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := s.db.WithContext(ctx).Order("id").Limit(limit).Offset(offset).Find(&subs).Error; err != nil {
+		return nil, 0, err
+	}
+	return subs, total, nil
+}
+
+// GetByID retrieves a webhook subscription by its ID
+func (s *webhookStore) GetByID(ctx context.Context, id uint) (*models.WebhookSubscription, error) {
+	var sub models.WebhookSubscription
+	if err := s.db.WithContext(ctx).First(&sub, id).Error; err != nil {
+		return nil, appError(err, "webhook subscription", id)
+	}
+	return &sub, nil
+}
+
+// Update updates only the non-nil fields of a webhook subscription
+func (s *webhookStore) Update(ctx context.Context, id uint, input *models.WebhookSubscriptionUpdateInput) (*models.WebhookSubscription, error) {
+	sub, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	input.Apply(sub)
+	if err := s.db.WithContext(ctx).Save(sub).Error; err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// Delete deletes a webhook subscription; its delivery log is kept
+func (s *webhookStore) Delete(ctx context.Context, id uint) error {
+	result := s.db.WithContext(ctx).Delete(&models.WebhookSubscription{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NotFound("webhook subscription", id)
+	}
+	return nil
+}
+
+// Deliveries retrieves the delivery log of a subscription, newest first.
+// An empty status selects deliveries in every state.
+func (s *webhookStore) Deliveries(ctx context.Context, subscriptionID uint, status string, limit, offset int) ([]models.WebhookDelivery, int64, error) {
+	var deliveries []models.WebhookDelivery
+	var total int64
+
+	query := s.db.WithContext(ctx).Model(&models.WebhookDelivery{}).Where("subscription_id = ?", subscriptionID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&deliveries).Error; err != nil {
+		return nil, 0, err
+	}
+	return deliveries, total, nil
+}
+
+// RetryDelivery puts a delivery of the given subscription back into the
+// pending state so that it is attempted again, e.g. after it went dead.
+func (s *webhookStore) RetryDelivery(ctx context.Context, subscriptionID, deliveryID uint) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := s.db.WithContext(ctx).Where("id = ? AND subscription_id = ?", deliveryID, subscriptionID).First(&delivery).Error; err != nil {
+		return nil, appError(err, "webhook delivery", deliveryID)
+	}
+	delivery.Retry(time.Now())
+	if err := s.db.WithContext(ctx).Save(&delivery).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// ClaimDueDeliveries returns up to limit pending deliveries whose next
+// attempt is due and pushes their next attempt forward by lease, so that other
+// replicas do not pick them up while they are being delivered.
+func (s *webhookStore) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := skipLocked(tx).
+			Where("status = ? AND next_attempt_at <= ?", models.DeliveryPending, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&deliveries).Error; err != nil {
+			return err
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+		ids := make([]uint, len(deliveries))
+		for i, d := range deliveries {
+			ids[i] = d.ID
+		}
+		return tx.Model(&models.WebhookDelivery{}).Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	return deliveries, err
+}
+
+// SaveDelivery stores the outcome of a delivery attempt
+func (s *webhookStore) SaveDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return s.db.WithContext(ctx).Save(delivery).Error
+}
@@ -0,0 +1,314 @@
+// Package events fans out restaurant and menu item change events to live
+// subscribers (e.g. the SSE stream). Events come from the transactional outbox;
+// on Postgres every API replica listens for NOTIFY messages, so a change made
+// through one replica reaches the subscribers of all replicas. On SQLite,
+// which serves a single instance, and in memory the outbox is polled instead.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"lunch_menu/internal/database"
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Event is a change event as sent to live subscribers
+type Event struct {
+	ID           uint            `json:"id"`
+	Type         string          `json:"type"`
+	RestaurantID uint            `json:"restaurant_id"`
+	Region       string          `json:"region"`
+	OccurredAt   time.Time       `json:"occurred_at"`
+	Data         json.RawMessage `json:"data"`
+}
+
+func fromOutbox(e *models.OutboxEvent) Event {
+	return Event{
+		ID:           e.ID,
+		Type:         e.EventType,
+		RestaurantID: e.RestaurantID,
+		Region:       e.Region,
+		OccurredAt:   e.CreatedAt,
+		Data:         json.RawMessage(e.Payload),
+	}
+}
+
+// Filter selects the events a subscriber receives; zero values match everything
+type Filter struct {
+	Region       string
+	RestaurantID uint
+}
+
+// Matches reports whether the event passes the filter
+func (f Filter) Matches(e *Event) bool {
+	if f.Region != "" && f.Region != e.Region {
+		return false
+	}
+	if f.RestaurantID != 0 && f.RestaurantID != e.RestaurantID {
+		return false
+	}
+	return true
+}
+
+// defaultHeartbeat is the heartbeat interval if Options.Heartbeat is zero
+const defaultHeartbeat = 15 * time.Second
+
+// Options configures the Broker
+type Options struct {
+	HistorySize      int           // number of recent events kept for Last-Event-ID resumption
+	SubscriberBuffer int           // events buffered per subscriber before it is dropped as too slow
+	ReconnectDelay   time.Duration // delay before re-establishing a lost LISTEN connection
+	PollInterval     time.Duration // outbox polling interval if ListenDSN is empty
+	Heartbeat        time.Duration // interval of the comments sent on idle streams, 15 seconds if zero
+	ListenDSN        string        // Postgres DSN to LISTEN for new events on; the outbox is polled if empty
+}
+
+// DefaultOptions returns the default broker settings
+func DefaultOptions() Options {
+	return Options{
+		HistorySize:      500,
+		SubscriberBuffer: 64,
+		ReconnectDelay:   5 * time.Second,
+		PollInterval:     time.Second,
+		Heartbeat:        defaultHeartbeat,
+	}
+}
+
+// Subscription receives matching events on C. C is closed when the
+// subscription is cancelled or the subscriber fell too far behind.
+type Subscription struct {
+	C      <-chan Event
+	ch     chan Event
+	filter Filter
+}
+
+// Broker keeps a bounded history of recent events and fans new ones out to subscribers
+type Broker struct {
+	outbox  repository.OutboxRepository
+	opts    Options
+	mu      sync.Mutex
+	history []Event // in publish order, at most opts.HistorySize
+	seen    map[uint]struct{}
+	lastID  uint
+	subs    map[*Subscription]struct{}
+}
+
+// Default is the broker used by the HTTP handlers; nil until Start is called
+var Default *Broker
+
+// NewBroker creates a Broker that publishes the events of outbox
+func NewBroker(outbox repository.OutboxRepository, opts Options) *Broker {
+	return &Broker{
+		outbox: outbox,
+		opts:   opts,
+		seen:   make(map[uint]struct{}),
+		subs:   make(map[*Subscription]struct{}),
+	}
+}
+
+// Start creates the Default broker, loads the recent history from the outbox
+// and listens for new events until ctx is cancelled.
+func Start(ctx context.Context, outbox repository.OutboxRepository, opts Options) *Broker {
+	b := NewBroker(outbox, opts)
+	if recent, err := outbox.Latest(ctx, opts.HistorySize); err != nil {
+		slog.Error("events: failed to load event history", slog.Any("error", err))
+	} else {
+		for i := range recent {
+			b.Publish(fromOutbox(&recent[i]))
+		}
+	}
+	Default = b
+	if opts.ListenDSN != "" {
+		go b.listen(ctx)
+	} else {
+		go b.poll(ctx)
+	}
+	return b
+}
+
+// Subscribe registers a subscriber and returns it together with the buffered
+// events after lastEventID that match the filter. A lastEventID of 0 replays nothing.
+// If lastEventID is older than the history, replay starts at the oldest kept event.
+func (b *Broker) Subscribe(filter Filter, lastEventID uint) (*Subscription, []Event) {
+	ch := make(chan Event, b.opts.SubscriberBuffer)
+	sub := &Subscription{C: ch, ch: ch, filter: filter}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var replay []Event
+	if lastEventID != 0 {
+		for i := range b.history {
+			if b.history[i].ID > lastEventID && filter.Matches(&b.history[i]) {
+				replay = append(replay, b.history[i])
+			}
+		}
+	}
+	b.subs[sub] = struct{}{}
+	return sub, replay
+}
+
+// Unsubscribe removes a subscriber and closes its channel
+func (b *Broker) Unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subs[sub]; ok {
+		delete(b.subs, sub)
+		close(sub.ch)
+	}
+}
+
+// Close disconnects all subscribers
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subs {
+		delete(b.subs, sub)
+		close(sub.ch)
+	}
+}
+
+// Publish adds an event to the history and sends it to matching subscribers.
+// Events that are already in the history (by ID) are ignored.
+func (b *Broker) Publish(e Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.seen[e.ID]; ok {
+		return
+	}
+	b.seen[e.ID] = struct{}{}
+	if e.ID > b.lastID {
+		b.lastID = e.ID
+	}
+	b.history = append(b.history, e)
+	if len(b.history) > b.opts.HistorySize {
+		drop := len(b.history) - b.opts.HistorySize
+		for _, old := range b.history[:drop] {
+			delete(b.seen, old.ID)
+		}
+		b.history = append([]Event(nil), b.history[drop:]...)
+	}
+	for sub := range b.subs {
+		if !sub.filter.Matches(&e) {
+			continue
+		}
+		select {
+		case sub.ch <- e:
+		default:
+			// Too slow: drop the subscriber, it can reconnect with Last-Event-ID
+			delete(b.subs, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+// Heartbeat returns how often a comment is sent on an idle stream so that
+// proxies and load balancers keep the connection open
+func (b *Broker) Heartbeat() time.Duration {
+	if b.opts.Heartbeat <= 0 {
+		return defaultHeartbeat
+	}
+	return b.opts.Heartbeat
+}
+
+// LastID returns the ID of the newest event seen
+func (b *Broker) LastID() uint {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.lastID
+}
+
+func (b *Broker) hasSeen(id uint) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	_, ok := b.seen[id]
+	return ok
+}
+
+// listen keeps a dedicated connection LISTENing on the events channel and
+// reconnects when it is lost, catching up on events missed in between.
+func (b *Broker) listen(ctx context.Context) {
+	for {
+		err := b.listenOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		slog.Warn("events: listener stopped, reconnecting", slog.Any("error", err), slog.Duration("delay", b.opts.ReconnectDelay))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(b.opts.ReconnectDelay):
+		}
+	}
+}
+
+func (b *Broker) listenOnce(ctx context.Context) error {
+	conn, err := pgx.Connect(ctx, b.opts.ListenDSN)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{database.EventsChannel}.Sanitize()); err != nil {
+		return err
+	}
+	// Events committed while we were not listening
+	b.catchUp(ctx)
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		id, err := strconv.ParseUint(n.Payload, 10, 64)
+		if err != nil || b.hasSeen(uint(id)) {
+			continue
+		}
+		// IDs are allocated before commit, so transactions can commit out of
+		// ID order; load the notified event itself rather than "everything newer".
+		event, err := b.outbox.GetByID(ctx, uint(id))
+		if err != nil {
+			slog.Error("events: failed to load event", slog.Uint64("event_id", id), slog.Any("error", err))
+			continue
+		}
+		b.Publish(fromOutbox(event))
+	}
+}
+
+// poll publishes new outbox events every opts.PollInterval
+func (b *Broker) poll(ctx context.Context) {
+	ticker := time.NewTicker(b.opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			b.catchUp(ctx)
+		}
+	}
+}
+
+// catchUp publishes all outbox events newer than the newest one seen
+func (b *Broker) catchUp(ctx context.Context) {
+	for {
+		events, err := b.outbox.After(ctx, b.LastID(), b.opts.HistorySize)
+		if err != nil {
+			slog.Error("events: failed to load new events", slog.Any("error", err))
+			return
+		}
+		for i := range events {
+			b.Publish(fromOutbox(&events[i]))
+		}
+		if len(events) < b.opts.HistorySize {
+			return
+		}
+	}
+}
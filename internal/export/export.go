@@ -0,0 +1,90 @@
+// Package export writes tabular data as CSV, JSON Lines or XLSX, row by row,
+// so that large result sets can be streamed straight to the HTTP response.
+package export
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+)
+
+// Supported export formats
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+	FormatXLSX  = "xlsx"
+)
+
+// Content types of the supported export formats
+const (
+	ContentTypeCSV   = "text/csv; charset=utf-8"
+	ContentTypeJSONL = "application/x-ndjson"
+	ContentTypeXLSX  = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// Writer writes a header followed by rows of values.
+// Values may be strings, bools, integers, floats or time.Time.
+type Writer interface {
+	WriteHeader(columns []string) error
+	WriteRow(values []interface{}) error
+	// Close flushes buffered data; it does not close the underlying io.Writer.
+	Close() error
+}
+
+// NewWriter returns a Writer for the given format
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	case FormatJSONL:
+		return newJSONLWriter(w), nil
+	case FormatXLSX:
+		return newXLSXWriter(w), nil
+	}
+	return nil, fmt.Errorf("unsupported export format %q", format)
+}
+
+// ContentType returns the HTTP content type for a format
+func ContentType(format string) string {
+	switch format {
+	case FormatJSONL:
+		return ContentTypeJSONL
+	case FormatXLSX:
+		return ContentTypeXLSX
+	}
+	return ContentTypeCSV
+}
+
+// NegotiateFormat picks the export format from an explicit format value
+// (e.g. the ?format= query parameter) or, if that is empty, from the Accept header.
+// CSV is used when neither selects a supported format.
+func NegotiateFormat(format, accept string) (string, error) {
+	if format != "" {
+		switch strings.ToLower(format) {
+		case FormatCSV:
+			return FormatCSV, nil
+		case FormatJSONL, "ndjson":
+			return FormatJSONL, nil
+		case FormatXLSX:
+			return FormatXLSX, nil
+		}
+		return "", fmt.Errorf("unsupported export format %q (use csv, jsonl or xlsx)", format)
+	}
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case "text/csv":
+			return FormatCSV, nil
+		case "application/x-ndjson", "application/jsonl", "application/jsonlines":
+			return FormatJSONL, nil
+		case ContentTypeXLSX:
+			return FormatXLSX, nil
+		}
+	}
+	return FormatCSV, nil
+}
@@ -0,0 +1,53 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+)
+
+// jsonlWriter writes one JSON object per row with keys in column order
+type jsonlWriter struct {
+	w       *bufio.Writer
+	columns []string
+}
+
+func newJSONLWriter(w io.Writer) *jsonlWriter {
+	return &jsonlWriter{w: bufio.NewWriter(w)}
+}
+
+func (jw *jsonlWriter) WriteHeader(columns []string) error {
+	jw.columns = columns
+	return nil
+}
+
+func (jw *jsonlWriter) WriteRow(values []interface{}) error {
+	if len(values) != len(jw.columns) {
+		return errors.New("jsonl: row length does not match header")
+	}
+	jw.w.WriteByte('{')
+	for i, v := range values {
+		if i > 0 {
+			jw.w.WriteByte(',')
+		}
+		key, _ := json.Marshal(jw.columns[i])
+		jw.w.Write(key)
+		jw.w.WriteByte(':')
+		if t, ok := v.(time.Time); ok {
+			v = t.UTC().Format(time.RFC3339)
+		}
+		val, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		jw.w.Write(val)
+	}
+	jw.w.WriteByte('}')
+	return jw.w.WriteByte('\n')
+}
+
+func (jw *jsonlWriter) Close() error {
+	return jw.w.Flush()
+}
@@ -0,0 +1,148 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"lunch_menu/internal/money"
+)
+
+// Static parts of a minimal single-sheet workbook
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Export" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetEnd = `</sheetData></worksheet>`
+)
+
+// xlsxWriter streams a single worksheet using inline strings, so no shared
+// string table has to be kept in memory. The worksheet is the last zip entry
+// and rows are written to it as they arrive.
+type xlsxWriter struct {
+	zw    *zip.Writer
+	sheet *bufio.Writer
+	row   int
+	err   error
+}
+
+func newXLSXWriter(w io.Writer) *xlsxWriter {
+	xw := &xlsxWriter{zw: zip.NewWriter(w)}
+	for _, part := range []struct{ name, body string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	} {
+		if xw.err = xw.writePart(part.name, part.body); xw.err != nil {
+			return xw
+		}
+	}
+	sheet, err := xw.zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		xw.err = err
+		return xw
+	}
+	xw.sheet = bufio.NewWriter(sheet)
+	_, xw.err = xw.sheet.WriteString(xlsxSheetStart)
+	return xw
+}
+
+func (xw *xlsxWriter) writePart(name, body string) error {
+	f, err := xw.zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(f, body)
+	return err
+}
+
+func (xw *xlsxWriter) WriteHeader(columns []string) error {
+	values := make([]interface{}, len(columns))
+	for i, c := range columns {
+		values[i] = c
+	}
+	return xw.WriteRow(values)
+}
+
+func (xw *xlsxWriter) WriteRow(values []interface{}) error {
+	if xw.err != nil {
+		return xw.err
+	}
+	xw.row++
+	var b strings.Builder
+	fmt.Fprintf(&b, `<row r="%d">`, xw.row)
+	for _, v := range values {
+		writeXLSXCell(&b, v)
+	}
+	b.WriteString(`</row>`)
+	_, xw.err = xw.sheet.WriteString(b.String())
+	return xw.err
+}
+
+func (xw *xlsxWriter) Close() error {
+	if xw.err == nil {
+		_, xw.err = xw.sheet.WriteString(xlsxSheetEnd)
+	}
+	if xw.err == nil {
+		xw.err = xw.sheet.Flush()
+	}
+	if err := xw.zw.Close(); xw.err == nil {
+		xw.err = err
+	}
+	return xw.err
+}
+
+func writeXLSXCell(b *strings.Builder, v interface{}) {
+	switch val := v.(type) {
+	case nil:
+		b.WriteString(`<c/>`)
+	case bool:
+		if val {
+			b.WriteString(`<c t="b"><v>1</v></c>`)
+		} else {
+			b.WriteString(`<c t="b"><v>0</v></c>`)
+		}
+	case int, int64, uint, uint64:
+		fmt.Fprintf(b, `<c><v>%d</v></c>`, val)
+	case float64:
+		fmt.Fprintf(b, `<c><v>%s</v></c>`, strconv.FormatFloat(val, 'f', -1, 64))
+	case money.Amount:
+		fmt.Fprintf(b, `<c><v>%s</v></c>`, val)
+	case time.Time:
+		writeXLSXString(b, val.UTC().Format(time.RFC3339))
+	case string:
+		writeXLSXString(b, val)
+	default:
+		writeXLSXString(b, fmt.Sprint(v))
+	}
+}
+
+func writeXLSXString(b *strings.Builder, s string) {
+	b.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+	_ = xml.EscapeText(b, []byte(s))
+	b.WriteString(`</t></is></c>`)
+}
@@ -0,0 +1,70 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"lunch_menu/internal/app"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// Request is a GraphQL request as sent by clients (POST body or GET query parameters)
+type Request struct {
+	Query         string                 `json:"query" form:"query"`
+	OperationName string                 `json:"operationName" form:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+
+	// QueriesOnly rejects mutations, set for GET requests
+	QueriesOnly bool `json:"-" form:"-"`
+}
+
+// Execute parses, validates and limit-checks the request and runs it against
+// Schema with the repositories of a and a fresh set of loaders. claims are the
+// JWT claims of the authenticated user, or nil for anonymous requests.
+func Execute(ctx context.Context, a *app.App, req *Request, claims map[string]interface{}) *graphql.Result {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+	if validation := graphql.ValidateDocument(&Schema, doc, nil); !validation.IsValid {
+		return &graphql.Result{Errors: validation.Errors}
+	}
+	if req.QueriesOnly && isMutation(doc, req.OperationName) {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(errors.New("mutations must be sent with POST"))}
+	}
+	if err := CheckLimits(doc, req.OperationName, req.Variables); err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	ctx = WithApp(ctx, a)
+	ctx = WithLoaders(ctx, NewLoaders(ctx, a))
+	if claims != nil {
+		ctx = WithClaims(ctx, claims)
+	}
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        Schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx,
+	})
+}
+
+// isMutation reports whether the operation selected by operationName is a mutation
+func isMutation(doc *ast.Document, operationName string) bool {
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok || (operationName != "" && (op.Name == nil || op.Name.Value != operationName)) {
+			continue
+		}
+		return op.Operation == ast.OperationTypeMutation
+	}
+	return false
+}
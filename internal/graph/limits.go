@@ -0,0 +1,152 @@
+package graph
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Query limits, checked before a query is executed
+const (
+	MaxDepth      = 6    // maximum nesting of selections
+	MaxComplexity = 1000 // maximum estimated number of resolved fields
+	MaxListLimit  = 100  // largest page a list field resolves, larger limits are clamped
+)
+
+// defaultListSizes estimates the length of list fields that have no "limit" argument
+var defaultListSizes = map[string]int{
+	"restaurants":       10,
+	"menuItems":         20,
+	"categories":        10,
+	"regions":           10,
+	"priceHistogram":    20,
+	"restaurantDetails": 20,
+}
+
+// CheckLimits returns an error if the selected operation of doc nests deeper
+// than MaxDepth or is estimated to resolve more than MaxComplexity fields.
+// Every field costs 1 plus the cost of its selections, times the list length
+// for list fields. Introspection fields are not counted. A "limit" argument
+// below 1 is rejected.
+func CheckLimits(doc *ast.Document, operationName string, variables map[string]interface{}) error {
+	fragments := make(map[string]*ast.FragmentDefinition)
+	var operation *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.FragmentDefinition:
+			fragments[def.Name.Value] = def
+		case *ast.OperationDefinition:
+			if operationName == "" || (def.Name != nil && def.Name.Value == operationName) {
+				if operation == nil {
+					operation = def
+				}
+			}
+		}
+	}
+	if operation == nil {
+		return nil // reported by the executor
+	}
+
+	a := analyzer{fragments: fragments, variables: variables}
+	depth, complexity, err := a.selectionSet(operation.SelectionSet, nil)
+	if err != nil {
+		return err
+	}
+	if depth > MaxDepth {
+		return fmt.Errorf("query depth %d exceeds the maximum of %d", depth, MaxDepth)
+	}
+	if complexity > MaxComplexity {
+		return fmt.Errorf("query complexity %d exceeds the maximum of %d", complexity, MaxComplexity)
+	}
+	return nil
+}
+
+type analyzer struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+}
+
+// selectionSet returns the depth and complexity of set; visiting holds the
+// fragments being expanded, to reject fragment cycles.
+func (a *analyzer) selectionSet(set *ast.SelectionSet, visiting []string) (int, int, error) {
+	if set == nil {
+		return 0, 0, nil
+	}
+	var depth, complexity int
+	for _, selection := range set.Selections {
+		var d, c int
+		var err error
+		switch s := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name.Value, "__") {
+				continue
+			}
+			var size int
+			if size, err = a.listSize(s); err != nil {
+				return 0, 0, err
+			}
+			d, c, err = a.selectionSet(s.SelectionSet, visiting)
+			d++
+			c = 1 + size*c
+		case *ast.InlineFragment:
+			d, c, err = a.selectionSet(s.SelectionSet, visiting)
+		case *ast.FragmentSpread:
+			name := s.Name.Value
+			for _, v := range visiting {
+				if v == name {
+					return 0, 0, fmt.Errorf("fragment %q spreads itself", name)
+				}
+			}
+			fragment, ok := a.fragments[name]
+			if !ok {
+				continue // reported by the validator
+			}
+			d, c, err = a.selectionSet(fragment.SelectionSet, append(visiting, name))
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+		if d > depth {
+			depth = d
+		}
+		complexity += c
+	}
+	return depth, complexity, nil
+}
+
+// listSize returns the "limit" argument of a field, or its default list size
+func (a *analyzer) listSize(field *ast.Field) (int, error) {
+	size, isList := defaultListSizes[field.Name.Value]
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "limit" {
+			continue
+		}
+		n, ok := 0, false
+		switch v := arg.Value.(type) {
+		case *ast.IntValue:
+			if i, err := strconv.Atoi(v.Value); err == nil {
+				n, ok = i, true
+			}
+		case *ast.Variable:
+			switch i := a.variables[v.Name.Value].(type) {
+			case float64:
+				n, ok = int(i), true
+			case int:
+				n, ok = i, true
+			}
+		}
+		if !ok {
+			break
+		}
+		if n < 1 {
+			return 0, fmt.Errorf("limit of %s must be at least 1, got %d", field.Name.Value, n)
+		}
+		return n, nil
+	}
+	if !isList {
+		return 1, nil
+	}
+	return size, nil
+}
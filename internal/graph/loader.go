@@ -0,0 +1,142 @@
+package graph
+
+import (
+	"context"
+	"sync"
+
+	"lunch_menu/internal/app"
+	"lunch_menu/internal/models"
+)
+
+// Loader collects the keys requested by the resolvers of one query level and
+// fetches them with a single call. Resolvers return the thunk from Load; the
+// executor resolves all fields of a level before it calls the thunks, so the
+// first thunk called fetches every key queued so far.
+type Loader[K comparable, V any] struct {
+	fetch   func(keys []K) (map[K]V, error)
+	mu      sync.Mutex
+	pending []K
+	queued  map[K]bool
+	results map[K]V
+	errs    map[K]error
+}
+
+// NewLoader creates a Loader that fetches values with fetch. fetch may leave
+// out keys that do not exist; Load returns the zero value for them.
+func NewLoader[K comparable, V any](fetch func(keys []K) (map[K]V, error)) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:   fetch,
+		queued:  make(map[K]bool),
+		results: make(map[K]V),
+		errs:    make(map[K]error),
+	}
+}
+
+// Load queues key for the next batch and returns a thunk yielding its value
+func (l *Loader[K, V]) Load(key K) func() (V, error) {
+	l.mu.Lock()
+	if !l.queued[key] {
+		l.queued[key] = true
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if len(l.pending) > 0 {
+			l.flush()
+		}
+		return l.results[key], l.errs[key]
+	}
+}
+
+// flush fetches all pending keys; l.mu must be held
+func (l *Loader[K, V]) flush() {
+	keys := l.pending
+	l.pending = nil
+	values, err := l.fetch(keys)
+	for _, key := range keys {
+		if err != nil {
+			l.errs[key] = err
+			continue
+		}
+		l.results[key] = values[key]
+	}
+}
+
+// Loaders holds the per-request loaders; a new set is created for every
+// request so that cached values never outlive it.
+type Loaders struct {
+	Restaurants *Loader[uint, *models.Restaurant]
+	MenuItems   *Loader[uint, []models.MenuItem] // by restaurant ID
+}
+
+// NewLoaders creates the loaders backed by the repositories of a, whose
+// queries are traced as children of the request context ctx
+func NewLoaders(ctx context.Context, a *app.App) *Loaders {
+	return &Loaders{
+		Restaurants: NewLoader(func(ids []uint) (map[uint]*models.Restaurant, error) {
+			restaurants, err := a.Restaurants.GetByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			byID := make(map[uint]*models.Restaurant, len(restaurants))
+			for i := range restaurants {
+				byID[restaurants[i].ID] = &restaurants[i]
+			}
+			return byID, nil
+		}),
+		MenuItems: NewLoader(func(restaurantIDs []uint) (map[uint][]models.MenuItem, error) {
+			items, err := a.MenuItems.ListByRestaurantIDs(ctx, restaurantIDs)
+			if err != nil {
+				return nil, err
+			}
+			byRestaurant := make(map[uint][]models.MenuItem, len(restaurantIDs))
+			for _, item := range items {
+				byRestaurant[item.RestaurantID] = append(byRestaurant[item.RestaurantID], item)
+			}
+			return byRestaurant, nil
+		}),
+	}
+}
+
+type contextKey int
+
+const (
+	loadersKey contextKey = iota
+	claimsKey
+	appKey
+)
+
+// WithApp returns a context carrying the app whose repositories the resolvers use
+func WithApp(ctx context.Context, a *app.App) context.Context {
+	return context.WithValue(ctx, appKey, a)
+}
+
+// WithLoaders returns a context carrying the request's loaders
+func WithLoaders(ctx context.Context, loaders *Loaders) context.Context {
+	return context.WithValue(ctx, loadersKey, loaders)
+}
+
+// WithClaims returns a context carrying the JWT claims of the authenticated user
+func WithClaims(ctx context.Context, claims map[string]interface{}) context.Context {
+	return context.WithValue(ctx, claimsKey, claims)
+}
+
+func loadersFrom(ctx context.Context) *Loaders {
+	if loaders, ok := ctx.Value(loadersKey).(*Loaders); ok {
+		return loaders
+	}
+	return NewLoaders(ctx, appFrom(ctx))
+}
+
+func appFrom(ctx context.Context) *app.App {
+	a, _ := ctx.Value(appKey).(*app.App)
+	return a
+}
+
+func claimsFrom(ctx context.Context) map[string]interface{} {
+	claims, _ := ctx.Value(claimsKey).(map[string]interface{})
+	return claims
+}
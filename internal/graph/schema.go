@@ -0,0 +1,787 @@
+// Package graph serves the GraphQL API over restaurants, menus, business
+// statistics and the current user. Nested lists are loaded in batches per
+// query level (see Loader), so listing restaurants with their menus costs one
+// query per level instead of one per restaurant.
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/money"
+
+	"github.com/graphql-go/graphql"
+)
+
+// Schema is the GraphQL schema served on /graphql
+var Schema = mustBuildSchema()
+
+func mustBuildSchema() graphql.Schema {
+	schema, err := buildSchema()
+	if err != nil {
+		panic("graph: invalid schema: " + err.Error())
+	}
+	return schema
+}
+
+func buildSchema() (graphql.Schema, error) {
+	restaurantType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Restaurant",
+		Fields: graphql.Fields{
+			"id":          &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"description": &graphql.Field{Type: graphql.String},
+			"address":     &graphql.Field{Type: graphql.String},
+			"coordinate":  &graphql.Field{Type: graphql.NewList(graphql.Float), Description: "[latitude, longitude]"},
+			"homepage":    &graphql.Field{Type: graphql.String},
+			"region":      &graphql.Field{Type: graphql.String},
+			"phone":       &graphql.Field{Type: graphql.String},
+			"email":       &graphql.Field{Type: graphql.String},
+			"isActive":    &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"createdAt":   &graphql.Field{Type: graphql.DateTime},
+			"updatedAt":   &graphql.Field{Type: graphql.DateTime},
+		},
+	})
+
+	priceVariantType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "PriceVariant",
+		Description: "A named price of a menu item, e.g. the student price",
+		Fields: graphql.Fields{
+			"tier": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"price": amountField("Price including VAT", func(source interface{}) money.Amount {
+				return source.(models.MenuItemPrice).Price
+			}),
+			"isDefault": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+		},
+	})
+
+	menuItemType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "MenuItem",
+		Fields: graphql.Fields{
+			"id":           &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"restaurantId": &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name":         &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"description":  &graphql.Field{Type: graphql.String},
+			"price": amountField("Price including VAT", func(source interface{}) money.Amount {
+				return source.(*models.MenuItem).Price
+			}),
+			"currency": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Description: "ISO 4217 currency code of the price"},
+			"prices": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(priceVariantType))),
+				Description: "Price variants; the default one's price is price",
+			},
+			"priceTier": &graphql.Field{Type: graphql.String, Description: "Variant whose price is in price, if a price tier was requested"},
+			"vatRate": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Float),
+				Description: "VAT rate of the item's category in percent",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return menuItemVAT(p.Source).Rate.Percent(), nil
+				},
+			},
+			"priceExclVat": amountField("Price excluding VAT", func(source interface{}) money.Amount {
+				return menuItemVAT(source).PriceExclVAT
+			}),
+			"vatAmount": amountField("VAT included in the price", func(source interface{}) money.Amount {
+				return menuItemVAT(source).Amount
+			}),
+			"category":    &graphql.Field{Type: graphql.String},
+			"isAvailable": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"createdAt":   &graphql.Field{Type: graphql.DateTime},
+			"updatedAt":   &graphql.Field{Type: graphql.DateTime},
+			"restaurant": &graphql.Field{
+				Type: restaurantType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					item := p.Source.(*models.MenuItem)
+					load := loadersFrom(p.Context).Restaurants.Load(item.RestaurantID)
+					return func() (interface{}, error) {
+						restaurant, err := load()
+						if err != nil || restaurant == nil {
+							return nil, err
+						}
+						return restaurant, nil
+					}, nil
+				},
+			},
+		},
+	})
+
+	// Added after menuItemType exists, the two types refer to each other
+	restaurantType.AddFieldConfig("menuItems", &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(menuItemType))),
+		Description: "Available menu items of the restaurant",
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			restaurant := p.Source.(*models.Restaurant)
+			load := loadersFrom(p.Context).MenuItems.Load(restaurant.ID)
+			return func() (interface{}, error) {
+				items, err := load()
+				if err != nil {
+					return nil, err
+				}
+				return menuItemPointers(items), nil
+			}, nil
+		},
+	})
+
+	priceSummaryType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "PriceSummary",
+		Description: "Distribution of the prices in the statistics' currency",
+		Fields: graphql.Fields{
+			"pricedItems": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"average": amountField("", func(source interface{}) money.Amount {
+				return source.(models.PriceSummary).Average
+			}),
+			"min": amountField("", func(source interface{}) money.Amount {
+				return source.(models.PriceSummary).Min
+			}),
+			"p25": amountField("25th percentile", func(source interface{}) money.Amount {
+				return source.(models.PriceSummary).P25
+			}),
+			"median": amountField("", func(source interface{}) money.Amount {
+				return source.(models.PriceSummary).Median
+			}),
+			"p75": amountField("75th percentile", func(source interface{}) money.Amount {
+				return source.(models.PriceSummary).P75
+			}),
+			"p90": amountField("90th percentile", func(source interface{}) money.Amount {
+				return source.(models.PriceSummary).P90
+			}),
+			"max": amountField("", func(source interface{}) money.Amount {
+				return source.(models.PriceSummary).Max
+			}),
+		},
+	})
+
+	histogramBucketType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "HistogramBucket",
+		Fields: graphql.Fields{
+			"from": amountField("Lowest price of the bucket", func(source interface{}) money.Amount {
+				return source.(models.HistogramBucket).From
+			}),
+			"to": amountField("Price above the bucket", func(source interface{}) money.Amount {
+				return source.(models.HistogramBucket).To
+			}),
+			"count": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+
+	categoryStatisticsType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "CategoryStatistics",
+		Fields: graphql.Fields{
+			"category":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"menuItems": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"prices":    &graphql.Field{Type: graphql.NewNonNull(priceSummaryType)},
+		},
+	})
+
+	pricedMenuItemType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PricedMenuItem",
+		Fields: graphql.Fields{
+			"menuItemId":     &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name":           &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"restaurantId":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"restaurantName": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"price": amountField("", func(source interface{}) money.Amount {
+				return source.(*models.PricedMenuItem).Price
+			}),
+		},
+	})
+
+	regionStatisticsType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "RegionStatistics",
+		Fields: graphql.Fields{
+			"region":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"restaurants":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"menuItems":     &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"prices":        &graphql.Field{Type: graphql.NewNonNull(priceSummaryType)},
+			"cheapest":      &graphql.Field{Type: pricedMenuItemType},
+			"mostExpensive": &graphql.Field{Type: pricedMenuItemType},
+		},
+	})
+
+	restaurantBusinessDataType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "RestaurantBusinessData",
+		Fields: graphql.Fields{
+			"restaurantId":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"restaurantName": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"region":         &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"menuItemCount":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"averagePrice": amountField("", func(source interface{}) money.Amount {
+				return source.(models.RestaurantBusinessData).AveragePrice
+			}),
+			"medianPrice": amountField("", func(source interface{}) money.Amount {
+				return source.(models.RestaurantBusinessData).MedianPrice
+			}),
+			"minPrice": amountField("", func(source interface{}) money.Amount {
+				return source.(models.RestaurantBusinessData).MinPrice
+			}),
+			"maxPrice": amountField("", func(source interface{}) money.Amount {
+				return source.(models.RestaurantBusinessData).MaxPrice
+			}),
+			"lastMenuUpdate": &graphql.Field{Type: graphql.DateTime, Description: "Latest change of any of its menu items"},
+			"stale":          &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean), Description: "Menu not updated within a week"},
+			"views":          &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Description: "Views of the restaurant, its menu and menu items within from and to"},
+		},
+	})
+
+	statisticsType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "BusinessStatistics",
+		Fields: graphql.Fields{
+			"totalRestaurants":    &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"activeRestaurants":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"inactiveRestaurants": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"staleRestaurants":    &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Description: "Active restaurants whose menu was not updated within a week"},
+			"totalMenuItems":      &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"totalViews":          &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Description: "Views of the active restaurants within from and to"},
+			"currency":            &graphql.Field{Type: graphql.NewNonNull(graphql.String), Description: "Currency of the aggregated prices"},
+			"priceTier":           &graphql.Field{Type: graphql.String, Description: "Price variant the prices are aggregated over"},
+			"region":              &graphql.Field{Type: graphql.String, Description: "Region the statistics are restricted to"},
+			"averagePrice": amountField("", func(source interface{}) money.Amount {
+				return source.(*models.BusinessStatistics).AveragePrice
+			}),
+			"prices":         &graphql.Field{Type: graphql.NewNonNull(priceSummaryType)},
+			"priceHistogram": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(histogramBucketType)))},
+			"categories":     &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(categoryStatisticsType)))},
+			"regions":        &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(regionStatisticsType)))},
+			"lastMenuUpdate": &graphql.Field{Type: graphql.DateTime},
+			"restaurantDetails": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(restaurantBusinessDataType))),
+			},
+		},
+	})
+
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"username":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"email":     &graphql.Field{Type: graphql.String},
+			"role":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"isActive":  &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"createdAt": &graphql.Field{Type: graphql.DateTime},
+			"updatedAt": &graphql.Field{Type: graphql.DateTime},
+		},
+	})
+
+	paging := graphql.FieldConfigArgument{
+		"limit":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 10, Description: fmt.Sprintf("Page size, 1 to %d", MaxListLimit)},
+		"offset": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0},
+	}
+	priceTierArg := &graphql.ArgumentConfig{
+		Type:        graphql.String,
+		Description: "Price variant to show, e.g. student; items without it keep their default price",
+	}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"restaurants": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(restaurantType))),
+				Description: "Active restaurants, ordered by ID",
+				Args:        paging,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					limit, offset := pageArgs(p.Args)
+					restaurants, _, err := appFrom(p.Context).Restaurants.List(p.Context, limit, offset)
+					if err != nil {
+						return nil, err
+					}
+					result := make([]*models.Restaurant, len(restaurants))
+					for i := range restaurants {
+						result[i] = &restaurants[i]
+					}
+					return result, nil
+				},
+			},
+			"restaurant": &graphql.Field{
+				Type: restaurantType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := idArg(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					load := loadersFrom(p.Context).Restaurants.Load(id)
+					return func() (interface{}, error) {
+						restaurant, err := load()
+						if err != nil || restaurant == nil {
+							return nil, err
+						}
+						return restaurant, nil
+					}, nil
+				},
+			},
+			"menuItems": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(menuItemType))),
+				Description: "Menu items of a restaurant",
+				Args: graphql.FieldConfigArgument{
+					"restaurantId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"limit":        paging["limit"],
+					"offset":       paging["offset"],
+					"priceTier":    priceTierArg,
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					restaurantID, err := idArg(p.Args, "restaurantId")
+					if err != nil {
+						return nil, err
+					}
+					tier, err := priceTier(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					limit, offset := pageArgs(p.Args)
+					items, _, err := appFrom(p.Context).MenuItems.List(p.Context, restaurantID, limit, offset)
+					if err != nil {
+						return nil, err
+					}
+					models.ApplyPriceTier(items, tier)
+					return menuItemPointers(items), nil
+				},
+			},
+			"menuItem": &graphql.Field{
+				Type: menuItemType,
+				Args: graphql.FieldConfigArgument{
+					"id":        &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"priceTier": priceTierArg,
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := idArg(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					tier, err := priceTier(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					item, err := appFrom(p.Context).MenuItems.GetByID(p.Context, id)
+					if err != nil {
+						return nil, err
+					}
+					item.ApplyPriceTier(tier)
+					return item, nil
+				},
+			},
+			"statistics": &graphql.Field{
+				Type: graphql.NewNonNull(statisticsType),
+				Args: graphql.FieldConfigArgument{
+					"priceTier": &graphql.ArgumentConfig{
+						Type:        graphql.String,
+						Description: "Aggregate the prices of this variant, the default price of items without it",
+					},
+					"region": &graphql.ArgumentConfig{Type: graphql.String, Description: "Only restaurants of this region"},
+					"from":   &graphql.ArgumentConfig{Type: graphql.DateTime, Description: "Only menu items created at or after"},
+					"to":     &graphql.ArgumentConfig{Type: graphql.DateTime, Description: "Only menu items created before"},
+					"bucketWidth": &graphql.ArgumentConfig{
+						Type:        graphql.Float,
+						Description: "Width of the price histogram buckets, 25.00 by default",
+					},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					tier, err := priceTier(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					filter := models.StatisticsFilter{PriceTier: tier}
+					filter.Region, _ = p.Args["region"].(string)
+					if from, ok := p.Args["from"].(time.Time); ok {
+						filter.From = from
+					}
+					if to, ok := p.Args["to"].(time.Time); ok {
+						filter.To = to
+					}
+					if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+						return nil, errors.New("from must be before to")
+					}
+					if width, ok := p.Args["bucketWidth"].(float64); ok {
+						if filter.BucketWidth, err = money.FromFloat(width); err != nil || filter.BucketWidth <= 0 {
+							return nil, fmt.Errorf("invalid bucketWidth: %v", width)
+						}
+					}
+					return appFrom(p.Context).Statistics.Get(p.Context, filter)
+				},
+			},
+			"me": &graphql.Field{
+				Type:        userType,
+				Description: "The authenticated user, null without a valid Authorization header",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					claims := claimsFrom(p.Context)
+					userID, ok := claims["user_id"].(float64)
+					if !ok {
+						return nil, nil
+					}
+					user, err := appFrom(p.Context).Users.GetByID(p.Context, int(userID))
+					if err != nil {
+						return nil, err
+					}
+					safeUser := user.ToSafeUser()
+					return &safeUser, nil
+				},
+			},
+		},
+	})
+
+	restaurantInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "RestaurantInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"name":        &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"description": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"address":     &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"coordinate":  &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.Float)))},
+			"homepage":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"region":      &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"phone":       &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"email":       &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+
+	restaurantUpdateInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "RestaurantUpdateInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"name":        &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"description": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"address":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"coordinate":  &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.Float))},
+			"homepage":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"region":      &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"phone":       &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"email":       &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"isActive":    &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+		},
+	})
+
+	priceVariantInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "PriceVariantInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"tier":      &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"price":     &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Float), Description: "Including VAT, at most two decimals"},
+			"isDefault": &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+		},
+	})
+	priceVariantsInput := graphql.NewList(graphql.NewNonNull(priceVariantInputType))
+
+	menuItemInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "MenuItemInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"restaurantId": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.ID)},
+			"name":         &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"description":  &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"price":        &graphql.InputObjectFieldConfig{Type: graphql.Float, Description: "Including VAT, at most two decimals; defaults to the default variant's price"},
+			"currency":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"category":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"prices":       &graphql.InputObjectFieldConfig{Type: priceVariantsInput, Description: "Price variants, exactly one of them the default"},
+		},
+	})
+
+	menuItemUpdateInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "MenuItemUpdateInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"restaurantId": &graphql.InputObjectFieldConfig{Type: graphql.ID},
+			"name":         &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"description":  &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"price":        &graphql.InputObjectFieldConfig{Type: graphql.Float},
+			"currency":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"category":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"isAvailable":  &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+			"prices":       &graphql.InputObjectFieldConfig{Type: priceVariantsInput, Description: "Replaces all price variants"},
+		},
+	})
+
+	idArgs := func(extra graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+		args := graphql.FieldConfigArgument{
+			"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+		}
+		for name, arg := range extra {
+			args[name] = arg
+		}
+		return args
+	}
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createRestaurant": &graphql.Field{
+				Type: graphql.NewNonNull(restaurantType),
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(restaurantInputType)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if err := requireAdmin(p.Context); err != nil {
+						return nil, err
+					}
+					var input models.RestaurantInput
+					if err := decodeInput(p.Args["input"], &input); err != nil {
+						return nil, err
+					}
+					if ok, invalid := input.Validate(); !ok {
+						return nil, invalidFieldsError(invalid)
+					}
+					return appFrom(p.Context).Restaurants.Create(p.Context, &models.Restaurant{
+						Name:        input.Name,
+						Description: input.Description,
+						Address:     input.Address,
+						Coordinate:  input.Coordinate,
+						Homepage:    input.Homepage,
+						Region:      input.Region,
+						Phone:       input.Phone,
+						Email:       input.Email,
+					})
+				},
+			},
+			"updateRestaurant": &graphql.Field{
+				Type: graphql.NewNonNull(restaurantType),
+				Args: idArgs(graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(restaurantUpdateInputType)},
+				}),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if err := requireAdmin(p.Context); err != nil {
+						return nil, err
+					}
+					id, err := idArg(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					var input models.RestaurantUpdateInput
+					if err := decodeInput(p.Args["input"], &input); err != nil {
+						return nil, err
+					}
+					return appFrom(p.Context).Restaurants.Update(p.Context, id, &input)
+				},
+			},
+			"deleteRestaurant": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: idArgs(nil),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if err := requireAdmin(p.Context); err != nil {
+						return nil, err
+					}
+					id, err := idArg(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					if err := appFrom(p.Context).Restaurants.Delete(p.Context, id); err != nil {
+						return nil, err
+					}
+					return true, nil
+				},
+			},
+			"createMenuItem": &graphql.Field{
+				Type: graphql.NewNonNull(menuItemType),
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(menuItemInputType)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if err := requireAdmin(p.Context); err != nil {
+						return nil, err
+					}
+					var input models.MenuItemInput
+					if err := decodeInput(p.Args["input"], &input); err != nil {
+						return nil, err
+					}
+					if ok, invalid := input.Validate(); !ok {
+						return nil, invalidFieldsError(invalid)
+					}
+					return appFrom(p.Context).MenuItems.Create(p.Context, &models.MenuItem{
+						RestaurantID: input.RestaurantID,
+						Name:         input.Name,
+						Description:  input.Description,
+						Price:        input.DefaultPrice(),
+						Currency:     input.PriceCurrency(),
+						Category:     input.Category,
+						Prices:       input.Prices,
+					})
+				},
+			},
+			"updateMenuItem": &graphql.Field{
+				Type: graphql.NewNonNull(menuItemType),
+				Args: idArgs(graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(menuItemUpdateInputType)},
+				}),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if err := requireAdmin(p.Context); err != nil {
+						return nil, err
+					}
+					id, err := idArg(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					var input models.MenuItemUpdateInput
+					if err := decodeInput(p.Args["input"], &input); err != nil {
+						return nil, err
+					}
+					if ok, invalid := input.Validate(); !ok {
+						return nil, invalidFieldsError(invalid)
+					}
+					return appFrom(p.Context).MenuItems.Update(p.Context, id, &input)
+				},
+			},
+			"deleteMenuItem": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: idArgs(nil),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if err := requireAdmin(p.Context); err != nil {
+						return nil, err
+					}
+					id, err := idArg(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					if err := appFrom(p.Context).MenuItems.Delete(p.Context, id); err != nil {
+						return nil, err
+					}
+					return true, nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+// Errors of mutations called without a valid token or by a customer
+var (
+	ErrUnauthenticated = errors.New("authentication required")
+	ErrForbidden       = errors.New("admin access required")
+)
+
+// requireAdmin checks that the request was authenticated as an admin, the
+// same requirement AuthMiddleware and AdminMiddleware enforce on the REST
+// write endpoints
+func requireAdmin(ctx context.Context) error {
+	claims := claimsFrom(ctx)
+	if claims == nil {
+		return ErrUnauthenticated
+	}
+	if claims["role"] != models.RoleAdmin {
+		return ErrForbidden
+	}
+	return nil
+}
+
+func invalidFieldsError(invalid []string) error {
+	return fmt.Errorf("invalid fields: %s", strings.Join(invalid, ", "))
+}
+
+func idArg(args map[string]interface{}, name string) (uint, error) {
+	id, err := strconv.ParseUint(fmt.Sprint(args[name]), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %v", name, args[name])
+	}
+	return uint(id), nil
+}
+
+// pageArgs returns the limit clamped to 1..MaxListLimit and a non-negative
+// offset, as the repositories treat a negative limit as no limit
+func pageArgs(args map[string]interface{}) (int, int) {
+	limit, _ := args["limit"].(int)
+	offset, _ := args["offset"].(int)
+	return min(max(limit, 1), MaxListLimit), max(offset, 0)
+}
+
+// priceTier returns the optional priceTier argument, "" if absent
+func priceTier(args map[string]interface{}) (string, error) {
+	tier, _ := args["priceTier"].(string)
+	if tier != "" && !models.IsPriceTier(tier) {
+		return "", fmt.Errorf("invalid priceTier: %q", tier)
+	}
+	return tier, nil
+}
+
+// amountField resolves a money amount of the source as a Float in currency units
+func amountField(description string, amount func(source interface{}) money.Amount) *graphql.Field {
+	return &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.Float),
+		Description: description,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return amount(p.Source).Float64(), nil
+		},
+	}
+}
+
+// menuItemVAT returns the VAT breakdown of a menu item source
+func menuItemVAT(source interface{}) money.VAT {
+	item := source.(*models.MenuItem)
+	if item.VAT == nil {
+		item.SetVAT()
+	}
+	return *item.VAT
+}
+
+func menuItemPointers(items []models.MenuItem) []*models.MenuItem {
+	result := make([]*models.MenuItem, len(items))
+	for i := range items {
+		result[i] = &items[i]
+	}
+	return result
+}
+
+// decodeInput copies a GraphQL input object into one of the API input structs
+// by their JSON tags: camelCase field names, also of nested input objects,
+// become snake_case and ID values (strings in GraphQL) of "...Id" fields
+// become numbers.
+func decodeInput(in interface{}, out interface{}) error {
+	fields, _ := in.(map[string]interface{})
+	converted, err := convertFields(fields)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(converted)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, out)
+}
+
+func convertFields(fields map[string]interface{}) (map[string]interface{}, error) {
+	converted := make(map[string]interface{}, len(fields))
+	for name, value := range fields {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			nested, err := convertFields(v)
+			if err != nil {
+				return nil, err
+			}
+			value = nested
+		case []interface{}:
+			list := make([]interface{}, len(v))
+			for i, elem := range v {
+				list[i] = elem
+				if object, ok := elem.(map[string]interface{}); ok {
+					nested, err := convertFields(object)
+					if err != nil {
+						return nil, err
+					}
+					list[i] = nested
+				}
+			}
+			value = list
+		}
+		if strings.HasSuffix(name, "Id") {
+			id, err := idArg(fields, name)
+			if err != nil {
+				return nil, err
+			}
+			value = id
+		}
+		converted[snakeCase(name)] = value
+	}
+	return converted, nil
+}
+
+func snakeCase(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if unicode.IsUpper(r) {
+			b.WriteByte('_')
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
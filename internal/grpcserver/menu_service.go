@@ -0,0 +1,229 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"lunch_menu/internal/app"
+	"lunch_menu/internal/events"
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/money"
+	pb "lunch_menu/internal/pb/lunchmenu/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type menuService struct {
+	pb.UnimplementedMenuServiceServer
+	app *app.App
+}
+
+func (s *menuService) ListMenuItems(ctx context.Context, req *pb.ListMenuItemsRequest) (*pb.ListMenuItemsResponse, error) {
+	if err := checkPriceTier(req.GetPriceTier()); err != nil {
+		return nil, err
+	}
+	limit, offset := pageArgs(req.GetLimit(), req.GetOffset())
+	items, total, err := s.app.MenuItems.List(ctx, uint(req.GetRestaurantId()), limit, offset)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &pb.ListMenuItemsResponse{Total: total}
+	for i := range items {
+		items[i].ApplyPriceTier(req.GetPriceTier())
+		resp.MenuItems = append(resp.MenuItems, menuItemToProto(&items[i]))
+	}
+	return resp, nil
+}
+
+func (s *menuService) GetMenuItem(ctx context.Context, req *pb.GetMenuItemRequest) (*pb.GetMenuItemResponse, error) {
+	if err := checkPriceTier(req.GetPriceTier()); err != nil {
+		return nil, err
+	}
+	item, err := s.app.MenuItems.GetByID(ctx, uint(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	item.ApplyPriceTier(req.GetPriceTier())
+	return &pb.GetMenuItemResponse{MenuItem: menuItemToProto(item)}, nil
+}
+
+func (s *menuService) CreateMenuItem(ctx context.Context, req *pb.CreateMenuItemRequest) (*pb.CreateMenuItemResponse, error) {
+	price, err := money.FromFloat(req.GetPrice())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid fields: price")
+	}
+	prices, err := pricesFromProto(req.GetPrices())
+	if err != nil {
+		return nil, err
+	}
+	input := models.MenuItemInput{
+		RestaurantID: uint(req.GetRestaurantId()),
+		Name:         req.GetName(),
+		Description:  req.GetDescription(),
+		Price:        price,
+		Currency:     req.GetCurrency(),
+		Category:     req.GetCategory(),
+		Prices:       prices,
+	}
+	if ok, invalid := input.Validate(); !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid fields: %s", strings.Join(invalid, ", "))
+	}
+	created, err := s.app.MenuItems.Create(ctx, &models.MenuItem{
+		RestaurantID: input.RestaurantID,
+		Name:         input.Name,
+		Description:  input.Description,
+		Price:        input.DefaultPrice(),
+		Currency:     input.PriceCurrency(),
+		Category:     input.Category,
+		Prices:       input.Prices,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.CreateMenuItemResponse{MenuItem: menuItemToProto(created)}, nil
+}
+
+func (s *menuService) UpdateMenuItem(ctx context.Context, req *pb.UpdateMenuItemRequest) (*pb.UpdateMenuItemResponse, error) {
+	input := models.MenuItemUpdateInput{
+		Name:        req.Name,
+		Description: req.Description,
+		Currency:    req.Currency,
+		Category:    req.Category,
+		IsAvailable: req.IsAvailable,
+	}
+	if req.Price != nil {
+		price, err := money.FromFloat(req.GetPrice())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid fields: price")
+		}
+		input.Price = &price
+	}
+	if req.Prices != nil {
+		prices, err := pricesFromProto(req.GetPrices().GetVariants())
+		if err != nil {
+			return nil, err
+		}
+		input.Prices = &prices
+	}
+	if req.RestaurantId != nil {
+		restaurantID := uint(req.GetRestaurantId())
+		input.RestaurantID = &restaurantID
+	}
+	if ok, invalid := input.Validate(); !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid fields: %s", strings.Join(invalid, ", "))
+	}
+	updated, err := s.app.MenuItems.Update(ctx, uint(req.GetId()), &input)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.UpdateMenuItemResponse{MenuItem: menuItemToProto(updated)}, nil
+}
+
+func (s *menuService) DeleteMenuItem(ctx context.Context, req *pb.DeleteMenuItemRequest) (*pb.DeleteMenuItemResponse, error) {
+	if err := s.app.MenuItems.Delete(ctx, uint(req.GetId())); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.DeleteMenuItemResponse{}, nil
+}
+
+// WatchMenuChanges streams menu item events from the events broker, the same
+// source as the SSE stream, so changes made through any replica are included.
+func (s *menuService) WatchMenuChanges(req *pb.WatchMenuChangesRequest, stream grpc.ServerStreamingServer[pb.WatchMenuChangesResponse]) error {
+	broker := events.Default
+	if broker == nil {
+		return status.Error(codes.Unavailable, "event broker is not running")
+	}
+	filter := events.Filter{Region: req.GetRegion(), RestaurantID: uint(req.GetRestaurantId())}
+	sub, replay := broker.Subscribe(filter, uint(req.GetLastChangeId()))
+	defer broker.Unsubscribe(sub)
+
+	for i := range replay {
+		if err := sendMenuChange(stream, &replay[i]); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case e, ok := <-sub.C:
+			if !ok {
+				return status.Error(codes.Unavailable, "stream closed, resume with last_change_id")
+			}
+			if err := sendMenuChange(stream, &e); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// sendMenuChange sends a menu item event; other events are skipped
+func sendMenuChange(stream grpc.ServerStreamingServer[pb.WatchMenuChangesResponse], e *events.Event) error {
+	if !strings.HasPrefix(e.Type, "menu_item.") {
+		return nil
+	}
+	var item models.MenuItem
+	if err := json.Unmarshal(e.Data, &item); err != nil {
+		return status.Errorf(codes.Internal, "failed to decode event %d: %v", e.ID, err)
+	}
+	return stream.Send(&pb.WatchMenuChangesResponse{
+		Id:         uint32(e.ID),
+		Type:       e.Type,
+		Region:     e.Region,
+		OccurredAt: timestamppb.New(e.OccurredAt),
+		MenuItem:   menuItemToProto(&item),
+	})
+}
+
+func menuItemToProto(item *models.MenuItem) *pb.MenuItem {
+	item.SetVAT()
+	return &pb.MenuItem{
+		Id:           uint32(item.ID),
+		RestaurantId: uint32(item.RestaurantID),
+		Name:         item.Name,
+		Description:  item.Description,
+		Price:        item.Price.Float64(),
+		Currency:     item.Currency,
+		VatRate:      item.VAT.Rate.Percent(),
+		PriceExclVat: item.VAT.PriceExclVAT.Float64(),
+		VatAmount:    item.VAT.Amount.Float64(),
+		Prices:       pricesToProto(item.Prices),
+		PriceTier:    item.PriceTier,
+		Category:     item.Category,
+		IsAvailable:  item.IsAvailable,
+		CreatedAt:    timestamppb.New(item.CreatedAt),
+		UpdatedAt:    timestamppb.New(item.UpdatedAt),
+	}
+}
+
+func pricesToProto(prices []models.MenuItemPrice) []*pb.PriceVariant {
+	var variants []*pb.PriceVariant
+	for _, p := range prices {
+		variants = append(variants, &pb.PriceVariant{Tier: p.Tier, Price: p.Price.Float64(), IsDefault: p.IsDefault})
+	}
+	return variants
+}
+
+func pricesFromProto(variants []*pb.PriceVariant) ([]models.MenuItemPrice, error) {
+	prices := make([]models.MenuItemPrice, 0, len(variants))
+	for _, v := range variants {
+		price, err := money.FromFloat(v.GetPrice())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid fields: prices")
+		}
+		prices = append(prices, models.MenuItemPrice{Tier: v.GetTier(), Price: price, IsDefault: v.GetIsDefault()})
+	}
+	return prices, nil
+}
+
+// checkPriceTier rejects a malformed price tier; an empty one is allowed
+func checkPriceTier(tier string) error {
+	if tier != "" && !models.IsPriceTier(tier) {
+		return status.Error(codes.InvalidArgument, "invalid fields: price_tier")
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package grpcserver
+
+import (
+	"context"
+	"strings"
+
+	"lunch_menu/internal/app"
+	"lunch_menu/internal/models"
+	pb "lunch_menu/internal/pb/lunchmenu/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type restaurantService struct {
+	pb.UnimplementedRestaurantServiceServer
+	app *app.App
+}
+
+func (s *restaurantService) ListRestaurants(ctx context.Context, req *pb.ListRestaurantsRequest) (*pb.ListRestaurantsResponse, error) {
+	limit, offset := pageArgs(req.GetLimit(), req.GetOffset())
+	restaurants, total, err := s.app.Restaurants.List(ctx, limit, offset)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &pb.ListRestaurantsResponse{Total: total}
+	for i := range restaurants {
+		resp.Restaurants = append(resp.Restaurants, restaurantToProto(&restaurants[i]))
+	}
+	return resp, nil
+}
+
+func (s *restaurantService) GetRestaurant(ctx context.Context, req *pb.GetRestaurantRequest) (*pb.GetRestaurantResponse, error) {
+	restaurant, err := s.app.Restaurants.GetByID(ctx, uint(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.GetRestaurantResponse{Restaurant: restaurantToProto(restaurant)}, nil
+}
+
+func (s *restaurantService) CreateRestaurant(ctx context.Context, req *pb.CreateRestaurantRequest) (*pb.CreateRestaurantResponse, error) {
+	input := models.RestaurantInput{
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		Address:     req.GetAddress(),
+		Coordinate:  []float64{req.GetLatitude(), req.GetLongitude()},
+		Homepage:    req.GetHomepage(),
+		Region:      req.GetRegion(),
+		Phone:       req.GetPhone(),
+		Email:       req.GetEmail(),
+	}
+	if ok, invalid := input.Validate(); !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid fields: %s", strings.Join(invalid, ", "))
+	}
+	created, err := s.app.Restaurants.Create(ctx, &models.Restaurant{
+		Name:        input.Name,
+		Description: input.Description,
+		Address:     input.Address,
+		Coordinate:  input.Coordinate,
+		Homepage:    input.Homepage,
+		Region:      input.Region,
+		Phone:       input.Phone,
+		Email:       input.Email,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.CreateRestaurantResponse{Restaurant: restaurantToProto(created)}, nil
+}
+
+func (s *restaurantService) UpdateRestaurant(ctx context.Context, req *pb.UpdateRestaurantRequest) (*pb.UpdateRestaurantResponse, error) {
+	input := models.RestaurantUpdateInput{
+		Name:        req.Name,
+		Description: req.Description,
+		Address:     req.Address,
+		Homepage:    req.Homepage,
+		Region:      req.Region,
+		Phone:       req.Phone,
+		Email:       req.Email,
+		IsActive:    req.IsActive,
+	}
+	if (req.Latitude == nil) != (req.Longitude == nil) {
+		return nil, status.Error(codes.InvalidArgument, "invalid fields: latitude and longitude must be set together")
+	}
+	if req.Latitude != nil {
+		coordinate := []float64{req.GetLatitude(), req.GetLongitude()}
+		input.Coordinate = &coordinate
+	}
+	if ok, invalid := input.Validate(); !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid fields: %s", strings.Join(invalid, ", "))
+	}
+	updated, err := s.app.Restaurants.Update(ctx, uint(req.GetId()), &input)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.UpdateRestaurantResponse{Restaurant: restaurantToProto(updated)}, nil
+}
+
+func (s *restaurantService) DeleteRestaurant(ctx context.Context, req *pb.DeleteRestaurantRequest) (*pb.DeleteRestaurantResponse, error) {
+	if err := s.app.Restaurants.Delete(ctx, uint(req.GetId())); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.DeleteRestaurantResponse{}, nil
+}
+
+func restaurantToProto(r *models.Restaurant) *pb.Restaurant {
+	msg := &pb.Restaurant{
+		Id:          uint32(r.ID),
+		Name:        r.Name,
+		Description: r.Description,
+		Address:     r.Address,
+		Homepage:    r.Homepage,
+		Region:      r.Region,
+		Phone:       r.Phone,
+		Email:       r.Email,
+		IsActive:    r.IsActive,
+		CreatedAt:   timestamppb.New(r.CreatedAt),
+		UpdatedAt:   timestamppb.New(r.UpdatedAt),
+	}
+	if len(r.Coordinate) == 2 {
+		msg.Latitude, msg.Longitude = r.Coordinate[0], r.Coordinate[1]
+	}
+	return msg
+}
@@ -0,0 +1,161 @@
+// Package grpcserver serves the gRPC API defined in proto/lunchmenu/v1. It
+// shares the repositories and JWT authentication with the REST API: write
+// methods require the Bearer token of an admin in the "authorization"
+// metadata, like the REST endpoints behind AuthMiddleware and AdminMiddleware.
+package grpcserver
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"lunch_menu/internal/app"
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/models"
+	pb "lunch_menu/internal/pb/lunchmenu/v1"
+	"lunch_menu/internal/utils"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// protectedMethods are the methods that require an authenticated admin
+var protectedMethods = map[string]bool{
+	pb.RestaurantService_CreateRestaurant_FullMethodName: true,
+	pb.RestaurantService_UpdateRestaurant_FullMethodName: true,
+	pb.RestaurantService_DeleteRestaurant_FullMethodName: true,
+	pb.MenuService_CreateMenuItem_FullMethodName:         true,
+	pb.MenuService_UpdateMenuItem_FullMethodName:         true,
+	pb.MenuService_DeleteMenuItem_FullMethodName:         true,
+}
+
+// New creates a gRPC server with all services backed by the repositories of
+// a, the health service (reporting SERVING) and server reflection registered.
+func New(a *app.App) *grpc.Server {
+	auth := &authenticator{app: a}
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(auth.unaryInterceptor),
+		grpc.StreamInterceptor(auth.streamInterceptor),
+	)
+	pb.RegisterRestaurantServiceServer(server, &restaurantService{app: a})
+	pb.RegisterMenuServiceServer(server, &menuService{app: a})
+	pb.RegisterStatisticsServiceServer(server, &statisticsService{app: a})
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	for name := range server.GetServiceInfo() {
+		healthServer.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+	reflection.Register(server)
+	return server
+}
+
+type claimsKey struct{}
+
+// ClaimsFromContext returns the JWT claims of the authenticated caller, or nil
+func ClaimsFromContext(ctx context.Context) map[string]interface{} {
+	claims, _ := ctx.Value(claimsKey{}).(map[string]interface{})
+	return claims
+}
+
+// authenticator checks the tokens of incoming calls against the blacklist of
+// the app's token repository
+type authenticator struct {
+	app *app.App
+}
+
+// authenticate validates the Bearer token in the "authorization" metadata, if
+// any, and stores its claims in the context. It fails for invalid or revoked
+// tokens, and for missing or non-admin tokens when the method is protected.
+func (a *authenticator) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		if protectedMethods[fullMethod] {
+			return nil, status.Error(codes.Unauthenticated, "missing authorization metadata")
+		}
+		return ctx, nil
+	}
+	if !strings.HasPrefix(values[0], "Bearer ") {
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization metadata")
+	}
+	tokenString := strings.TrimPrefix(values[0], "Bearer ")
+
+	if blacklisted, err := a.app.Tokens.IsTokenBlacklisted(ctx, tokenString); err == nil && blacklisted {
+		return nil, status.Error(codes.Unauthenticated, "token has been revoked")
+	}
+	claims, err := utils.ParseJWT(tokenString)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if protectedMethods[fullMethod] && claims["role"] != models.RoleAdmin {
+		return nil, status.Error(codes.PermissionDenied, "admin access required")
+	}
+	return context.WithValue(ctx, claimsKey{}, claims), nil
+}
+
+func (a *authenticator) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := a.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *authenticator) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticatedStream replaces the context of a stream with the authenticated one
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// toStatus converts an error of the repositories into a gRPC status error
+func toStatus(err error) error {
+	appErr := apperrors.As(err)
+	switch appErr.Kind {
+	case apperrors.KindNotFound:
+		return status.Error(codes.NotFound, appErr.Message)
+	case apperrors.KindValidation, apperrors.KindUnprocessable:
+		return status.Error(codes.InvalidArgument, appErr.Message)
+	case apperrors.KindConflict:
+		return status.Error(codes.AlreadyExists, appErr.Message)
+	case apperrors.KindUnauthorized:
+		return status.Error(codes.Unauthenticated, appErr.Message)
+	case apperrors.KindForbidden:
+		return status.Error(codes.PermissionDenied, appErr.Message)
+	case apperrors.KindUnavailable:
+		return status.Error(codes.Unavailable, appErr.Message)
+	case apperrors.KindTooManyRequests:
+		return status.Error(codes.ResourceExhausted, appErr.Message)
+	default:
+		slog.Error("grpc: internal error", slog.Any("error", appErr))
+		return status.Error(codes.Internal, appErr.Message)
+	}
+}
+
+// pageArgs applies the REST defaults to limit and offset
+func pageArgs(limit, offset int32) (int, int) {
+	if limit <= 0 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return int(limit), int(offset)
+}
@@ -0,0 +1,142 @@
+package grpcserver
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"lunch_menu/internal/app"
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/money"
+	pb "lunch_menu/internal/pb/lunchmenu/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type statisticsService struct {
+	pb.UnimplementedStatisticsServiceServer
+	app *app.App
+}
+
+func (s *statisticsService) GetBusinessStatistics(ctx context.Context, req *pb.GetBusinessStatisticsRequest) (*pb.GetBusinessStatisticsResponse, error) {
+	if err := checkPriceTier(req.GetPriceTier()); err != nil {
+		return nil, err
+	}
+	filter := models.StatisticsFilter{PriceTier: req.GetPriceTier(), Region: req.GetRegion()}
+	var invalid []string
+	if req.GetFrom() != nil {
+		filter.From = req.GetFrom().AsTime()
+	}
+	if req.GetTo() != nil {
+		filter.To = req.GetTo().AsTime()
+		if !filter.From.IsZero() && !filter.From.Before(filter.To) {
+			invalid = append(invalid, "from")
+		}
+	}
+	if req.GetBucketWidth() != 0 {
+		width, err := money.FromFloat(req.GetBucketWidth())
+		if err != nil || width <= 0 {
+			invalid = append(invalid, "bucket_width")
+		}
+		filter.BucketWidth = width
+	}
+	if len(invalid) > 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid fields: %s", strings.Join(invalid, ", "))
+	}
+
+	stats, err := s.app.Statistics.Get(ctx, filter)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &pb.GetBusinessStatisticsResponse{
+		TotalRestaurants:    stats.TotalRestaurants,
+		ActiveRestaurants:   stats.ActiveRestaurants,
+		InactiveRestaurants: stats.InactiveRestaurants,
+		StaleRestaurants:    stats.StaleRestaurants,
+		TotalMenuItems:      stats.TotalMenuItems,
+		TotalViews:          stats.TotalViews,
+		Currency:            stats.Currency,
+		PriceTier:           stats.PriceTier,
+		Region:              stats.Region,
+		AveragePrice:        stats.AveragePrice.Float64(),
+		Prices:              priceSummaryToProto(stats.Prices),
+		LastMenuUpdate:      optionalTimestamp(stats.LastMenuUpdate),
+		GeneratedAt:         timestamppb.New(stats.GeneratedAt),
+	}
+	for _, b := range stats.PriceHistogram {
+		resp.PriceHistogram = append(resp.PriceHistogram, &pb.HistogramBucket{
+			From:  b.From.Float64(),
+			To:    b.To.Float64(),
+			Count: b.Count,
+		})
+	}
+	for _, c := range stats.Categories {
+		resp.Categories = append(resp.Categories, &pb.CategoryStatistics{
+			Category:  c.Category,
+			MenuItems: c.MenuItems,
+			Prices:    priceSummaryToProto(c.Prices),
+		})
+	}
+	for _, r := range stats.Regions {
+		resp.Regions = append(resp.Regions, &pb.RegionStatistics{
+			Region:        r.Region,
+			Restaurants:   r.Restaurants,
+			MenuItems:     r.MenuItems,
+			Prices:        priceSummaryToProto(r.Prices),
+			Cheapest:      pricedMenuItemToProto(r.Cheapest),
+			MostExpensive: pricedMenuItemToProto(r.MostExpensive),
+		})
+	}
+	for _, d := range stats.RestaurantDetails {
+		resp.RestaurantDetails = append(resp.RestaurantDetails, &pb.RestaurantBusinessData{
+			RestaurantId:   uint32(d.RestaurantID),
+			RestaurantName: d.RestaurantName,
+			Region:         d.Region,
+			MenuItemCount:  d.MenuItemCount,
+			AveragePrice:   d.AveragePrice.Float64(),
+			MedianPrice:    d.MedianPrice.Float64(),
+			MinPrice:       d.MinPrice.Float64(),
+			MaxPrice:       d.MaxPrice.Float64(),
+			LastMenuUpdate: optionalTimestamp(d.LastMenuUpdate),
+			Stale:          d.Stale,
+			Views:          d.Views,
+		})
+	}
+	return resp, nil
+}
+
+func priceSummaryToProto(s models.PriceSummary) *pb.PriceSummary {
+	return &pb.PriceSummary{
+		PricedItems: s.PricedItems,
+		Average:     s.Average.Float64(),
+		Min:         s.Min.Float64(),
+		P25:         s.P25.Float64(),
+		Median:      s.Median.Float64(),
+		P75:         s.P75.Float64(),
+		P90:         s.P90.Float64(),
+		Max:         s.Max.Float64(),
+	}
+}
+
+func pricedMenuItemToProto(item *models.PricedMenuItem) *pb.PricedMenuItem {
+	if item == nil {
+		return nil
+	}
+	return &pb.PricedMenuItem{
+		MenuItemId:     uint32(item.MenuItemID),
+		Name:           item.Name,
+		RestaurantId:   uint32(item.RestaurantID),
+		RestaurantName: item.RestaurantName,
+		Price:          item.Price.Float64(),
+	}
+}
+
+// optionalTimestamp converts t, nil if it is nil
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/export"
+	"lunch_menu/internal/logging"
+	"lunch_menu/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	restaurantExportColumns = []string{"id", "name", "description", "address", "latitude", "longitude", "homepage", "region", "phone", "email", "is_active", "created_at", "updated_at"}
+	menuItemExportColumns   = []string{"id", "restaurant_id", "restaurant_name", "name", "description", "price", "currency", "vat_rate", "price_excl_vat", "vat_amount", "category", "is_available", "created_at", "updated_at"}
+	statisticsExportColumns = []string{"restaurant_id", "restaurant_name", "region", "menu_item_count", "average_price", "median_price", "min_price", "max_price", "last_menu_update", "stale", "views"}
+)
+
+// ExportRestaurants godoc
+// @Summary      Export restaurants
+// @Description  Stream active restaurants as CSV, JSON Lines or XLSX. The format is taken from ?format= or the Accept header (CSV by default).
+// @Tags         export
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        format  query     string  false  "csv, jsonl or xlsx"
+// @Param        limit   query     int     false  "Limit (all rows if omitted)"
+// @Param        offset  query     int     false  "Offset"
+// @Success      200  {file}    file
+// @Failure      400  {object}  models.Problem
+// @Router       /export/restaurants [get]
+func (h *Handlers) ExportRestaurants(c *gin.Context) {
+	const method = "ExportRestaurants"
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "-1"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	streamExport(c, method, "restaurants", restaurantExportColumns, func(w export.Writer) error {
+		return h.Restaurants.Stream(c.Request.Context(), limit, offset, func(r *models.Restaurant) error {
+			var lat, lng interface{}
+			if len(r.Coordinate) == 2 {
+				lat, lng = r.Coordinate[0], r.Coordinate[1]
+			}
+			return w.WriteRow([]interface{}{
+				r.ID, r.Name, r.Description, r.Address, lat, lng, r.Homepage,
+				r.Region, r.Phone, r.Email, r.IsActive, r.CreatedAt, r.UpdatedAt,
+			})
+		})
+	})
+}
+
+// ExportMenuItems godoc
+// @Summary      Export menu items
+// @Description  Stream menu items as CSV, JSON Lines or XLSX. The format is taken from ?format= or the Accept header (CSV by default).
+// @Tags         export
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        format         query     string  false  "csv, jsonl or xlsx"
+// @Param        restaurant_id  query     int     false  "Only items of this restaurant"
+// @Param        limit          query     int     false  "Limit (all rows if omitted)"
+// @Param        offset         query     int     false  "Offset"
+// @Success      200  {file}    file
+// @Failure      400  {object}  models.Problem
+// @Router       /export/menu-items [get]
+func (h *Handlers) ExportMenuItems(c *gin.Context) {
+	const method = "ExportMenuItems"
+
+	restaurantID, err := queryID(c, "restaurant_id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "-1"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	streamExport(c, method, "menu-items", menuItemExportColumns, func(w export.Writer) error {
+		return h.MenuItems.Stream(c.Request.Context(), restaurantID, limit, offset, func(m *models.MenuItemWithRestaurant) error {
+			m.SetVAT()
+			return w.WriteRow([]interface{}{
+				m.ID, m.RestaurantID, m.RestaurantName, m.Name, m.Description,
+				m.Price, m.Currency, m.VAT.Rate.Percent(), m.VAT.PriceExclVAT, m.VAT.Amount,
+				m.Category, m.IsAvailable, m.CreatedAt, m.UpdatedAt,
+			})
+		})
+	})
+}
+
+// ExportStatistics godoc
+// @Summary      Export business statistics
+// @Description  Export per-restaurant business statistics as CSV, JSON Lines or XLSX. The format is taken from ?format= or the Accept header (CSV by default).
+// @Tags         export
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        format      query     string  false  "csv, jsonl or xlsx"
+// @Param        price_tier  query     string  false  "Aggregate the prices of this variant, e.g. student"
+// @Param        region      query     string  false  "Only restaurants of this region"
+// @Param        from        query     string  false  "Only menu items created at or after, RFC 3339 or YYYY-MM-DD"
+// @Param        to          query     string  false  "Only menu items created before, RFC 3339 or YYYY-MM-DD (that day included)"
+// @Success      200  {file}    file
+// @Failure      400  {object}  models.Problem
+// @Failure      500  {object}  models.Problem
+// @Router       /export/stats [get]
+func (h *Handlers) ExportStatistics(c *gin.Context) {
+	const method = "ExportStatistics"
+
+	// Statistics are a small aggregate, so they are computed before streaming
+	// to be able to report a database error with a proper status code.
+	if _, err := export.NegotiateFormat(c.Query("format"), c.GetHeader("Accept")); err != nil {
+		_ = c.Error(exportFormatError(err))
+		return
+	}
+	filter, err := statisticsFilter(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	stats, err := h.Statistics.Get(c.Request.Context(), filter)
+	if err != nil {
+		_ = c.Error(apperrors.Internal("Failed to retrieve business statistics", err))
+		return
+	}
+
+	streamExport(c, method, "statistics", statisticsExportColumns, func(w export.Writer) error {
+		for _, d := range stats.RestaurantDetails {
+			if err := w.WriteRow([]interface{}{
+				d.RestaurantID, d.RestaurantName, d.Region, d.MenuItemCount, d.AveragePrice, d.MedianPrice,
+				d.MinPrice, d.MaxPrice, optionalTime(d.LastMenuUpdate), d.Stale, d.Views,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// streamExport negotiates the format, writes the response headers and the
+// header row, and then lets produce write the data rows. Once streaming has
+// started the status can no longer change, so later errors abort the response.
+func streamExport(c *gin.Context, method, name string, columns []string, produce func(export.Writer) error) {
+	format, err := export.NegotiateFormat(c.Query("format"), c.GetHeader("Accept"))
+	if err != nil {
+		_ = c.Error(exportFormatError(err))
+		return
+	}
+
+	disableWriteDeadline(c)
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().UTC().Format("20060102"), format)
+	c.Header("Content-Type", export.ContentType(format))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Header("Vary", "Accept")
+	c.Status(http.StatusOK)
+
+	logger := logging.FromContext(c.Request.Context()).With(slog.String("handler", method))
+	w, err := export.NewWriter(format, c.Writer)
+	if err != nil {
+		logger.Error("export aborted", slog.Any("error", err))
+		c.Abort()
+		return
+	}
+	err = w.WriteHeader(columns)
+	if err == nil {
+		err = produce(w)
+	}
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		logger.Error("export aborted", slog.Any("error", err))
+		_ = c.Error(err)
+		c.Abort()
+	}
+}
+
+func exportFormatError(err error) error {
+	return apperrors.Validation("Invalid export format", models.FieldError{Field: "format", Message: err.Error()})
+}
+
+// optionalTime returns t as an export value, an empty cell if it is nil
+func optionalTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return *t
+}
@@ -0,0 +1,322 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetMyPreferences godoc
+// @Summary      Get your preferences
+// @Description  Returns your home region and dietary preferences, the menu item categories your feed ranks first
+// @Tags         users
+// @Produce      json
+// @Success      200  {object}  models.StandardResponse{data=models.UserPreferences}
+// @Failure      401  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
+// @Router       /user/me/preferences [get]
+// @Security     BearerAuth
+func (h *Handlers) GetMyPreferences(c *gin.Context) {
+	user, err := h.me(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	utils.Respond(c, http.StatusOK, "Preferences fetched successfully", user.Preferences(), nil)
+}
+
+// UpdateMyPreferences godoc
+// @Summary      Update your preferences
+// @Description  Set your home region or your dietary preferences; an empty home region or list clears them
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        preferences  body      models.UserPreferencesInput  true  "Changes"
+// @Success      200  {object}  models.StandardResponse{data=models.UserPreferences}
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
+// @Router       /user/me/preferences [put]
+// @Security     BearerAuth
+func (h *Handlers) UpdateMyPreferences(c *gin.Context) {
+	user, err := h.me(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	var input models.UserPreferencesInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		_ = c.Error(apperrors.FromBinding(err))
+		return
+	}
+	if ok, invalid := input.Validate(); !ok {
+		_ = c.Error(apperrors.InvalidFields(invalid))
+		return
+	}
+
+	input.Apply(user)
+	if err := h.Users.Update(c.Request.Context(), user); err != nil {
+		_ = c.Error(apperrors.Internal("Failed to update preferences", err))
+		return
+	}
+
+	utils.Respond(c, http.StatusOK, "Preferences updated successfully", user.Preferences(), nil)
+}
+
+// GetMyFavorites godoc
+// @Summary      List your favorites
+// @Description  Returns your favorite restaurants and menu items, oldest first; deleted ones are left out
+// @Tags         users
+// @Produce      json
+// @Success      200  {object}  models.StandardResponse{data=models.Favorites}
+// @Failure      401  {object}  models.Problem
+// @Failure      500  {object}  models.Problem
+// @Router       /user/me/favorites [get]
+// @Security     BearerAuth
+func (h *Handlers) GetMyFavorites(c *gin.Context) {
+	userID, _, err := currentUser(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	favorites, err := h.Favorites.List(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	result := models.Favorites{Restaurants: []models.Restaurant{}, MenuItems: []models.MenuItem{}}
+	for _, f := range favorites {
+		switch f.TargetType {
+		case models.FavoriteRestaurant:
+			restaurant, err := h.Restaurants.GetByID(c.Request.Context(), f.TargetID)
+			if errors.Is(err, apperrors.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				_ = c.Error(err)
+				return
+			}
+			result.Restaurants = append(result.Restaurants, *restaurant)
+		case models.FavoriteMenuItem:
+			item, err := h.MenuItems.GetByID(c.Request.Context(), f.TargetID)
+			if errors.Is(err, apperrors.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				_ = c.Error(err)
+				return
+			}
+			result.MenuItems = append(result.MenuItems, *item)
+		}
+	}
+	models.SetVAT(result.MenuItems)
+
+	utils.Respond(c, http.StatusOK, "Favorites fetched successfully", result, nil)
+}
+
+// AddFavoriteRestaurant godoc
+// @Summary      Star a restaurant
+// @Description  Add a restaurant to your favorites; starring it again has no effect
+// @Tags         users
+// @Produce      json
+// @Param        id   path      int  true  "Restaurant ID"
+// @Success      200  {object}  models.StandardResponse{data=models.Favorite}
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
+// @Router       /user/me/favorites/restaurants/{id} [put]
+// @Security     BearerAuth
+func (h *Handlers) AddFavoriteRestaurant(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if _, err := h.Restaurants.GetByID(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	h.addFavorite(c, models.FavoriteRestaurant, id)
+}
+
+// RemoveFavoriteRestaurant godoc
+// @Summary      Unstar a restaurant
+// @Description  Remove a restaurant from your favorites
+// @Tags         users
+// @Produce      json
+// @Param        id   path      int  true  "Restaurant ID"
+// @Success      200  {object}  models.StandardResponse
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
+// @Router       /user/me/favorites/restaurants/{id} [delete]
+// @Security     BearerAuth
+func (h *Handlers) RemoveFavoriteRestaurant(c *gin.Context) {
+	h.removeFavorite(c, models.FavoriteRestaurant)
+}
+
+// AddFavoriteMenuItem godoc
+// @Summary      Star a menu item
+// @Description  Add a menu item to your favorites; starring it again has no effect
+// @Tags         users
+// @Produce      json
+// @Param        id   path      int  true  "Menu item ID"
+// @Success      200  {object}  models.StandardResponse{data=models.Favorite}
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
+// @Router       /user/me/favorites/menu-items/{id} [put]
+// @Security     BearerAuth
+func (h *Handlers) AddFavoriteMenuItem(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if _, err := h.MenuItems.GetByID(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	h.addFavorite(c, models.FavoriteMenuItem, id)
+}
+
+// RemoveFavoriteMenuItem godoc
+// @Summary      Unstar a menu item
+// @Description  Remove a menu item from your favorites
+// @Tags         users
+// @Produce      json
+// @Param        id   path      int  true  "Menu item ID"
+// @Success      200  {object}  models.StandardResponse
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
+// @Router       /user/me/favorites/menu-items/{id} [delete]
+// @Security     BearerAuth
+func (h *Handlers) RemoveFavoriteMenuItem(c *gin.Context) {
+	h.removeFavorite(c, models.FavoriteMenuItem)
+}
+
+// GetMyFeed godoc
+// @Summary      Get your lunch feed
+// @Description  Returns the menu items available today at active restaurants, ranked for you: your favorite menu items first, then the items of your favorite restaurants, those in your dietary preferences and those in your home region, and then the rest. Each item lists the reasons it is ranked first.
+// @Tags         users
+// @Produce      json
+// @Param        limit       query     int     false  "Items to return (default 10, at most 100)"
+// @Param        offset      query     int     false  "Offset"
+// @Param        price_tier  query     string  false  "Price variant to return as price, e.g. student; items without it keep their default price"
+// @Success      200  {object}  models.StandardResponse{data=models.FeedResponse}
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
+// @Failure      500  {object}  models.Problem
+// @Router       /user/me/feed [get]
+// @Security     BearerAuth
+func (h *Handlers) GetMyFeed(c *gin.Context) {
+	tier, err := queryPriceTier(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	user, err := h.me(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	favorites, err := h.Favorites.List(c.Request.Context(), user.ID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > models.MaxFeedLimit {
+		_ = c.Error(apperrors.Validation("Invalid feed query", models.FieldError{
+			Field:   "limit",
+			Message: fmt.Sprintf("must be between 1 and %d", models.MaxFeedLimit),
+		}))
+		return
+	}
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	feed, total, err := h.MenuItems.Feed(c.Request.Context(), models.NewFeedQuery(user.Preferences(), favorites), limit, max(offset, 0))
+	if err != nil {
+		_ = c.Error(apperrors.Internal("Failed to retrieve the feed", err))
+		return
+	}
+	// Prices and ratings only for the page
+	items := make([]models.MenuItem, len(feed))
+	for i := range feed {
+		items[i] = feed[i].MenuItem
+	}
+	models.ApplyPriceTier(items, tier)
+	models.SetVAT(items)
+	if err := h.setRatings(c.Request.Context(), items); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	for i := range feed {
+		feed[i].MenuItem = items[i]
+	}
+
+	utils.Respond(c, http.StatusOK, "Feed fetched successfully", models.FeedResponse{
+		Date:  time.Now().UTC().Format(time.DateOnly),
+		Items: feed,
+		Total: total,
+	}, nil)
+}
+
+// me returns the authenticated user
+func (h *Handlers) me(c *gin.Context) (*models.User, error) {
+	userID, _, err := currentUser(c)
+	if err != nil {
+		return nil, err
+	}
+	return h.Users.GetByID(c.Request.Context(), int(userID))
+}
+
+// addFavorite stars a target for the authenticated user
+func (h *Handlers) addFavorite(c *gin.Context, targetType string, targetID uint) {
+	userID, _, err := currentUser(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	favorite, err := h.Favorites.Add(c.Request.Context(), &models.Favorite{UserID: userID, TargetType: targetType, TargetID: targetID})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	utils.Respond(c, http.StatusOK, "Favorite added successfully", favorite, nil)
+}
+
+// removeFavorite unstars the target in the path for the authenticated user;
+// deleted restaurants and menu items can still be unstarred
+func (h *Handlers) removeFavorite(c *gin.Context, targetType string) {
+	userID, _, err := currentUser(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	id, err := paramID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := h.Favorites.Remove(c.Request.Context(), userID, targetType, id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	utils.Respond(c, http.StatusOK, "Favorite removed successfully", nil, nil)
+}
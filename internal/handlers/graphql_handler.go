@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/graph"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GraphQL godoc
+// @Summary      GraphQL endpoint
+// @Description  Queries restaurants (with their menu items), menu items, business statistics and the current user (me).
+// @Description  Mutations create, update and delete restaurants and menu items and require a Bearer token.
+// @Description  Queries deeper than 6 levels or with an estimated complexity above 1000 fields are rejected.
+// @Description  Errors are reported in the GraphQL "errors" array with status 200.
+// @Tags         graphql
+// @Accept       json
+// @Produce      json
+// @Param        request  body      graph.Request  true  "GraphQL request"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Router       /graphql [post]
+// @Security     BearerAuth
+func (h *Handlers) GraphQL(c *gin.Context) {
+	var req graph.Request
+
+	var err error
+	if c.Request.Method == http.MethodGet {
+		// GET only serves queries, so that mutations cannot be triggered by links
+		err = c.ShouldBindQuery(&req)
+		req.QueriesOnly = true
+		if variables := c.Query("variables"); err == nil && variables != "" {
+			err = json.Unmarshal([]byte(variables), &req.Variables)
+		}
+	} else {
+		err = c.ShouldBindJSON(&req)
+	}
+	if err == nil && req.Query == "" {
+		err = fmt.Errorf("query is required")
+	}
+	if err != nil {
+		_ = c.Error(apperrors.Validation("Invalid GraphQL request: " + err.Error()))
+		return
+	}
+
+	var claims map[string]interface{}
+	if value, exists := c.Get("userClaims"); exists {
+		claims, _ = value.(map[string]interface{})
+	}
+
+	c.JSON(http.StatusOK, graph.Execute(c.Request.Context(), h.App, &req, claims))
+}
@@ -1,15 +1,31 @@
 package handlers
 
 import (
-	"lunch_menu/internal/database"
+	"lunch_menu/internal/app"
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/config"
 	"lunch_menu/internal/models"
+	"lunch_menu/internal/money"
 	"lunch_menu/internal/utils"
 	"net/http"
-	"os"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// Handlers serves the endpoints that read and write through the repositories
+// of an app.App. Endpoints without repositories, such as the probes and the
+// event stream, are plain functions.
+type Handlers struct {
+	*app.App
+}
+
+// New returns the handlers backed by the repositories of a
+func New(a *app.App) *Handlers {
+	return &Handlers{App: a}
+}
+
 // GetAPIInfo godoc
 // @Summary      Get API information
 // @Description  Returns basic API information and version
@@ -18,35 +34,119 @@ import (
 // @Success      200  {object}  models.StandardResponse
 // @Router       /api [get]
 func GetAPIInfo(c *gin.Context) {
-	version := os.Getenv("VERSION")
-	if version == "" {
-		version = "development"
-	}
-
 	response := models.APIResponse{
 		Message: "Restaurant Management API",
-		Version: version,
+		Version: config.AppConfig.Version,
 	}
 	utils.Respond(c, http.StatusOK, "API info fetched successfully", response, nil)
 }
 
 // GetBusinessStatistics godoc
 // @Summary      Get business statistics
-// @Description  Returns business analytics and statistics
+// @Description  Returns restaurant counts, the price distribution (average, median, percentiles and histogram) overall, per category and per region with its cheapest and most expensive item, and per-restaurant figures with the time each menu last changed. Prices are those in the configured currency.
 // @Tags         statistics
 // @Produce      json
-// @Success      200  {object}  models.StandardResponse
-// @Failure      500  {object}  models.StandardResponse
+// @Param        price_tier    query     string  false  "Aggregate the prices of this variant, e.g. student"
+// @Param        region        query     string  false  "Only restaurants of this region"
+// @Param        from          query     string  false  "Only menu items created at or after, RFC 3339 or YYYY-MM-DD"
+// @Param        to            query     string  false  "Only menu items created before, RFC 3339 or YYYY-MM-DD (that day included)"
+// @Param        bucket_width  query     number  false  "Width of the price histogram buckets (default 25.00)"
+// @Success      200  {object}  models.StandardResponse{data=models.BusinessStatistics}
+// @Failure      400  {object}  models.Problem
+// @Failure      500  {object}  models.Problem
 // @Router       /api/statistics [get]
-func GetBusinessStatistics(c *gin.Context) {
-	stats, err := database.GetBusinessStatistics()
+func (h *Handlers) GetBusinessStatistics(c *gin.Context) {
+	filter, err := statisticsFilter(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	stats, err := h.Statistics.Get(c.Request.Context(), filter)
 	if err != nil {
-		utils.Respond(c, http.StatusInternalServerError, "Failed to retrieve business statistics", nil, &models.ErrorResponse{
-			Error:   "DATABASE_ERROR",
-			Message: "Failed to retrieve business statistics",
-		})
+		_ = c.Error(apperrors.Internal("Failed to retrieve business statistics", err))
 		return
 	}
 
 	utils.Respond(c, http.StatusOK, "Business statistics fetched successfully", stats, nil)
 }
+
+// currentUser returns the ID and role of the user authenticated by
+// AuthMiddleware
+func currentUser(c *gin.Context) (uint, string, error) {
+	value, _ := c.Get("userClaims")
+	claims, _ := value.(map[string]interface{})
+	userID, ok := claims["user_id"].(float64)
+	if !ok {
+		return 0, "", apperrors.Unauthorized("UNAUTHORIZED", "Authentication required")
+	}
+	role, _ := claims["role"].(string)
+	return uint(userID), role, nil
+}
+
+// paramID parses the path parameter name as a numeric ID
+func paramID(c *gin.Context, name string) (uint, error) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 32)
+	if err != nil {
+		return 0, apperrors.Validation("Invalid ID", models.FieldError{Field: name, Message: "must be a positive integer"})
+	}
+	return uint(id), nil
+}
+
+// disableWriteDeadline lifts the server's write timeout for long-lived
+// streaming responses such as SSE and exports
+func disableWriteDeadline(c *gin.Context) {
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+}
+
+// queryID parses the optional query parameter name as a numeric ID, 0 if absent
+func queryID(c *gin.Context, name string) (uint, error) {
+	id, err := strconv.ParseUint(c.DefaultQuery(name, "0"), 10, 32)
+	if err != nil {
+		return 0, apperrors.Validation("Invalid ID", models.FieldError{Field: name, Message: "must be a positive integer"})
+	}
+	return uint(id), nil
+}
+
+// queryPriceTier returns the optional price_tier query parameter, "" if absent
+func queryPriceTier(c *gin.Context) (string, error) {
+	tier := c.Query("price_tier")
+	if tier != "" && !models.IsPriceTier(tier) {
+		return "", apperrors.Validation("Invalid price tier",
+			models.FieldError{Field: "price_tier", Message: "must be a lowercase name such as student"})
+	}
+	return tier, nil
+}
+
+// statisticsFilter reads the query parameters that select the data of the
+// business statistics. A date-only to includes that day.
+func statisticsFilter(c *gin.Context) (models.StatisticsFilter, error) {
+	tier, err := queryPriceTier(c)
+	if err != nil {
+		return models.StatisticsFilter{}, err
+	}
+	filter := models.StatisticsFilter{PriceTier: tier, Region: c.Query("region")}
+
+	var fields []models.FieldError
+	if filter.From, err = queryTime(c, "from", time.Time{}); err != nil {
+		fields = append(fields, models.FieldError{Field: "from", Message: err.Error()})
+	}
+	if filter.To, err = queryTime(c, "to", time.Time{}); err != nil {
+		fields = append(fields, models.FieldError{Field: "to", Message: err.Error()})
+	} else if _, dateErr := time.Parse(time.DateOnly, c.Query("to")); dateErr == nil {
+		filter.To = filter.To.AddDate(0, 0, 1)
+	}
+	if len(fields) == 0 && !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		fields = append(fields, models.FieldError{Field: "from", Message: "must be before to"})
+	}
+	if width := c.Query("bucket_width"); width != "" {
+		amount, err := money.Parse(width)
+		if err != nil || amount <= 0 {
+			fields = append(fields, models.FieldError{Field: "bucket_width", Message: "must be a positive amount with at most 2 decimals"})
+		}
+		filter.BucketWidth = amount
+	}
+	if len(fields) > 0 {
+		return models.StatisticsFilter{}, apperrors.Validation("Invalid statistics query", fields...)
+	}
+	return filter, nil
+}
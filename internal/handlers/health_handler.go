@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"lunch_menu/internal/config"
+	"lunch_menu/internal/database"
+	"lunch_menu/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readinessTimeout bounds all readiness checks together
+const readinessTimeout = 2 * time.Second
+
+// readinessChecks are run by Readyz; a failing check makes the instance unready
+var readinessChecks = map[string]func(ctx context.Context) error{
+	"database": database.Ping,
+	"migrations": func(ctx context.Context) error {
+		pending, err := database.PendingMigrations(ctx)
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			return fmt.Errorf("pending: %s", strings.Join(pending, ", "))
+		}
+		return nil
+	},
+	"config": func(ctx context.Context) error {
+		if config.AppConfig == nil {
+			return errors.New("configuration not loaded")
+		}
+		return config.AppConfig.Validate()
+	},
+}
+
+// Healthz godoc
+// @Summary      Liveness probe
+// @Description  Reports that the process is alive; it does not check dependencies
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  models.HealthResponse
+// @Router       /healthz [get]
+func Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, models.HealthResponse{Status: "ok"})
+}
+
+// Readyz godoc
+// @Summary      Readiness probe
+// @Description  Checks the database connection, pending migrations and the configuration.
+// @Description  Returns 503 with the per-check status if any check fails.
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  models.HealthResponse
+// @Failure      503  {object}  models.HealthResponse
+// @Router       /readyz [get]
+func Readyz(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readinessTimeout)
+	defer cancel()
+
+	resp := models.HealthResponse{Status: "ok", Checks: make(map[string]models.HealthCheck, len(readinessChecks))}
+	for name, check := range readinessChecks {
+		start := time.Now()
+		result := models.HealthCheck{Status: "ok"}
+		if err := check(ctx); err != nil {
+			result.Status = "failed"
+			result.Error = err.Error()
+			resp.Status = "unavailable"
+		}
+		result.DurationMs = float64(time.Since(start).Microseconds()) / 1000
+		resp.Checks[name] = result
+	}
+
+	status := http.StatusOK
+	if resp.Status != "ok" {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, resp)
+}
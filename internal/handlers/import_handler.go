@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/importer"
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImportCatalogue godoc
+// @Summary      Bulk import restaurants and menu items
+// @Description  Admin only: upsert restaurants (by name) and menu items (by restaurant name and item name) from CSV or JSON.
+// @Description  Send either a JSON body {"restaurants": [...], "menu_items": [...]} or a multipart form with "restaurants" and/or "menu_items" files.
+// @Description  All rows are applied in a single transaction, or none are if any row is invalid.
+// @Tags         import
+// @Accept       json
+// @Accept       mpfd
+// @Produce      json
+// @Param        dry_run     query     bool    false  "Validate and report without applying"
+// @Param        format      query     string  false  "File format (csv or json); detected from the file extension if omitted"
+// @Param        restaurants formData  file    false  "Restaurants file"
+// @Param        menu_items  formData  file    false  "Menu items file"
+// @Success      200  {object}  models.StandardResponse{data=models.ImportReport}
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Failure      422  {object}  models.Problem  "Invalid rows, one error per invalid field (e.g. restaurants[3].email)"
+// @Router       /import [post]
+// @Security     BearerAuth
+func (h *Handlers) ImportCatalogue(c *gin.Context) {
+	dryRun, _ := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+
+	input, err := readImportInput(c)
+	if err != nil {
+		if apperrors.KindOf(err) == apperrors.KindInternal {
+			err = apperrors.Validation("Invalid import data: " + err.Error())
+		}
+		_ = c.Error(err)
+		return
+	}
+
+	report, err := h.Imports.Import(c.Request.Context(), input, dryRun)
+	if err != nil {
+		_ = c.Error(apperrors.Internal("Failed to import catalogue", err))
+		return
+	}
+	if len(report.Errors) > 0 {
+		_ = c.Error(importRowsError(report))
+		return
+	}
+
+	message := "Import applied successfully"
+	if dryRun {
+		message = "Dry run completed, nothing was applied"
+	}
+	utils.Respond(c, http.StatusOK, message, report, nil)
+}
+
+// importRowsError reports the invalid rows of an import, with one field error
+// per invalid field named like "restaurants[3].email" (rows are 1-based)
+func importRowsError(report *models.ImportReport) error {
+	var fields []models.FieldError
+	for _, rowErr := range report.Errors {
+		row := fmt.Sprintf("%s[%d]", rowErr.Kind, rowErr.Row)
+		if len(rowErr.Fields) == 0 {
+			fields = append(fields, models.FieldError{Field: row, Message: rowErr.Message})
+			continue
+		}
+		for _, field := range rowErr.Fields {
+			fields = append(fields, models.FieldError{Field: row + "." + field, Message: apperrors.FieldMessage(field)})
+		}
+	}
+	return apperrors.Unprocessable("INVALID_ROWS",
+		fmt.Sprintf("Import rejected, nothing was applied: %d invalid row(s)", len(report.Errors)), fields...)
+}
+
+// readImportInput reads the import rows from a JSON body or multipart files
+func readImportInput(c *gin.Context) (*models.CatalogueImport, error) {
+	var input models.CatalogueImport
+
+	if !strings.HasPrefix(c.ContentType(), "multipart/") {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			return nil, apperrors.FromBinding(err)
+		}
+		return &input, nil
+	}
+
+	found := false
+	for _, kind := range []string{importer.KindRestaurants, importer.KindMenuItems} {
+		fileHeader, err := c.FormFile(kind)
+		if err != nil {
+			continue
+		}
+		found = true
+		format, err := importer.DetectFormat(c.Query("format"), fileHeader.Filename)
+		if err != nil {
+			return nil, err
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			return nil, err
+		}
+		err = importer.Parse(kind, format, file, &input)
+		file.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("expected a %q or %q file", importer.KindRestaurants, importer.KindMenuItems)
+	}
+	return &input, nil
+}
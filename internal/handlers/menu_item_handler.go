@@ -1,11 +1,10 @@
 package handlers
 
 import (
-	"fmt"
 	"net/http"
 	"strconv"
 
-	"lunch_menu/internal/database"
+	"lunch_menu/internal/apperrors"
 	"lunch_menu/internal/models"
 	"lunch_menu/internal/utils"
 
@@ -14,106 +13,95 @@ import (
 
 // CreateMenuItem godoc
 // @Summary      Create a new menu item
-// @Description  Admin only: create a new menu item
+// @Description  Admin only: create a new menu item. The price includes VAT and has at most two decimals. Optional price variants, such as a student price, need exactly one default, whose price is the item's price.
 // @Tags         menu-items
 // @Accept       json
 // @Produce      json
-// @Param        menu_item  body      models.MenuItem  true  "Menu Item Input"
+// @Param        menu_item  body      models.MenuItemInput  true  "Menu Item Input"
 // @Success      201  {object}  models.StandardResponse
-// @Failure      400  {object}  models.StandardResponse
-// @Failure      401  {object}  models.StandardResponse
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
 // @Router       /menu-items [post]
 // @Security     BearerAuth
-func CreateMenuItem(c *gin.Context) {
-	const method = "CreateMenuItem"
-	var input models.MenuItem
-	var status = http.StatusCreated
-	var message = "Menu item created successfully"
-	var data interface{}
-	var errResp *models.ErrorResponse
-
+func (h *Handlers) CreateMenuItem(c *gin.Context) {
+	var input models.MenuItemInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		status = http.StatusBadRequest
-		message = "Invalid menu item data"
-		errResp = &models.ErrorResponse{
-			Error:   "INVALID_INPUT",
-			Message: fmt.Sprintf("%s: %v", method, err.Error()),
-		}
-	} else {
-		created, err := database.CreateMenuItem(&input)
-		if err != nil {
-			status = http.StatusInternalServerError
-			message = "Failed to create menu item"
-			errResp = &models.ErrorResponse{
-				Error:   "DATABASE_ERROR",
-				Message: fmt.Sprintf("%s: %v", method, err.Error()),
-			}
-		} else {
-			data = created
+		_ = c.Error(apperrors.FromBinding(err))
+		return
+	}
+	if ok, invalid := input.Validate(); !ok {
+		_ = c.Error(apperrors.InvalidFields(invalid))
+		return
+	}
+	if _, err := h.Restaurants.GetByID(c.Request.Context(), input.RestaurantID); err != nil {
+		if apperrors.KindOf(err) == apperrors.KindNotFound {
+			err = apperrors.InvalidFields([]string{"restaurant_id"})
 		}
+		_ = c.Error(err)
+		return
 	}
 
-	utils.Respond(c, status, message, data, errResp)
+	created, err := h.MenuItems.Create(c.Request.Context(), &models.MenuItem{
+		RestaurantID: input.RestaurantID,
+		Name:         input.Name,
+		Description:  input.Description,
+		Price:        input.DefaultPrice(),
+		Currency:     input.PriceCurrency(),
+		Category:     input.Category,
+		Prices:       input.Prices,
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	created.SetVAT()
+	utils.Respond(c, http.StatusCreated, "Menu item created successfully", created, nil)
 }
 
 // UpdateMenuItem godoc
 // @Summary      Update a menu item
-// @Description  Admin only: update an existing menu item
+// @Description  Admin only: update an existing menu item. Prices replace all price variants; a new price of an item with variants is that of its default variant.
 // @Tags         menu-items
 // @Accept       json
 // @Produce      json
 // @Param        id         path      int           true  "Menu Item ID"
 // @Param        menu_item  body      models.MenuItemUpdateInput  true  "Menu Item Input"
 // @Success      200  {object}  models.StandardResponse
-// @Failure      400  {object}  models.StandardResponse
-// @Failure      401  {object}  models.StandardResponse
-// @Failure      404  {object}  models.StandardResponse
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
 // @Router       /menu-items/{id} [put]
 // @Security     BearerAuth
-func UpdateMenuItem(c *gin.Context) {
-	const method = "UpdateMenuItem"
-	var input models.MenuItemUpdateInput
-	var status = http.StatusOK
-	var message = "Menu item updated successfully"
-	var data interface{}
-	var errResp *models.ErrorResponse
-
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+func (h *Handlers) UpdateMenuItem(c *gin.Context) {
+	id, err := paramID(c, "id")
 	if err != nil {
-		status = http.StatusBadRequest
-		message = "Invalid menu item ID"
-		errResp = &models.ErrorResponse{
-			Error:   "INVALID_ID",
-			Message: fmt.Sprintf("%s: %v", method, err.Error()),
-		}
-		utils.Respond(c, status, message, data, errResp)
+		_ = c.Error(err)
 		return
 	}
 
+	var input models.MenuItemUpdateInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		status = http.StatusBadRequest
-		message = "Invalid menu item data"
-		errResp = &models.ErrorResponse{
-			Error:   "INVALID_INPUT",
-			Message: fmt.Sprintf("%s: %v", method, err.Error()),
-		}
-		utils.Respond(c, status, message, data, errResp)
+		_ = c.Error(apperrors.FromBinding(err))
+		return
+	}
+	if ok, invalid := input.Validate(); !ok {
+		_ = c.Error(apperrors.InvalidFields(invalid))
 		return
 	}
 
-	updated, err := database.PartialUpdateMenuItem(uint(id), &input)
+	updated, err := h.MenuItems.Update(c.Request.Context(), id, &input)
 	if err != nil {
-		status = http.StatusInternalServerError
-		message = "Failed to update menu item"
-		errResp = &models.ErrorResponse{
-			Error:   "DATABASE_ERROR",
-			Message: fmt.Sprintf("%s: %v", method, err.Error()),
-		}
-	} else {
-		data = updated
+		_ = c.Error(err)
+		return
+	}
+	updated.SetVAT()
+	if err := h.setRating(c.Request.Context(), updated); err != nil {
+		_ = c.Error(err)
+		return
 	}
 
-	utils.Respond(c, status, message, data, errResp)
+	utils.Respond(c, http.StatusOK, "Menu item updated successfully", updated, nil)
 }
 
 // DeleteMenuItem godoc
@@ -123,36 +111,23 @@ func UpdateMenuItem(c *gin.Context) {
 // @Produce      json
 // @Param        id   path      int  true  "Menu Item ID"
 // @Success      200  {object}  models.StandardResponse
-// @Failure      400  {object}  models.StandardResponse
-// @Failure      401  {object}  models.StandardResponse
-// @Failure      404  {object}  models.StandardResponse
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
 // @Router       /menu-items/{id} [delete]
 // @Security     BearerAuth
-func DeleteMenuItem(c *gin.Context) {
-	const method = "DeleteMenuItem"
-	var status = http.StatusOK
-	var message = "Menu item deleted successfully"
-	var data interface{}
-	var errResp *models.ErrorResponse
-
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+func (h *Handlers) DeleteMenuItem(c *gin.Context) {
+	id, err := paramID(c, "id")
 	if err != nil {
-		status = http.StatusBadRequest
-		message = "Invalid menu item ID"
-		errResp = &models.ErrorResponse{
-			Error:   "INVALID_ID",
-			Message: fmt.Sprintf("%s: %v", method, err.Error()),
-		}
-	} else if err := database.DeleteMenuItem(uint(id)); err != nil {
-		status = http.StatusInternalServerError
-		message = "Failed to delete menu item"
-		errResp = &models.ErrorResponse{
-			Error:   "DATABASE_ERROR",
-			Message: fmt.Sprintf("%s: %v", method, err.Error()),
-		}
+		_ = c.Error(err)
+		return
+	}
+	if err := h.MenuItems.Delete(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
 	}
 
-	utils.Respond(c, status, message, data, errResp)
+	utils.Respond(c, http.StatusOK, "Menu item deleted successfully", nil, nil)
 }
 
 // GetMenuItems godoc
@@ -160,37 +135,38 @@ func DeleteMenuItem(c *gin.Context) {
 // @Description  Get a paginated list of menu items
 // @Tags         menu-items
 // @Produce      json
-// @Param        limit   query     int  false  "Limit"
-// @Param        offset  query     int  false  "Offset"
+// @Param        limit       query     int     false  "Limit"
+// @Param        offset      query     int     false  "Offset"
+// @Param        price_tier  query     string  false  "Show the prices of this variant, e.g. student"
 // @Success      200  {object}  models.StandardResponse
-// @Failure      500  {object}  models.StandardResponse
+// @Failure      400  {object}  models.Problem
+// @Failure      500  {object}  models.Problem
 // @Router       /menu-items [get]
-func GetMenuItems(c *gin.Context) {
-	const method = "GetMenuItems"
-	var status = http.StatusOK
-	var message = "Menu items fetched successfully"
-	var data interface{}
-	var errResp *models.ErrorResponse
-
+func (h *Handlers) GetMenuItems(c *gin.Context) {
+	tier, err := queryPriceTier(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 
-	menuItems, total, err := database.GetMenuItems(0, limit, offset)
+	menuItems, total, err := h.MenuItems.List(c.Request.Context(), 0, limit, offset)
 	if err != nil {
-		status = http.StatusInternalServerError
-		message = "Failed to retrieve menu items"
-		errResp = &models.ErrorResponse{
-			Error:   "DATABASE_ERROR",
-			Message: fmt.Sprintf("%s: %v", method, err.Error()),
-		}
-	} else {
-		data = models.MenuItemsResponse{
-			MenuItems: menuItems,
-			Total:     total,
-		}
+		_ = c.Error(err)
+		return
+	}
+	models.ApplyPriceTier(menuItems, tier)
+	models.SetVAT(menuItems)
+	if err := h.setRatings(c.Request.Context(), menuItems); err != nil {
+		_ = c.Error(err)
+		return
 	}
 
-	utils.Respond(c, status, message, data, errResp)
+	utils.Respond(c, http.StatusOK, "Menu items fetched successfully", models.MenuItemsResponse{
+		MenuItems: menuItems,
+		Total:     total,
+	}, nil)
 }
 
 // GetMenuItem godoc
@@ -198,41 +174,37 @@ func GetMenuItems(c *gin.Context) {
 // @Description  Get a menu item by ID
 // @Tags         menu-items
 // @Produce      json
-// @Param        id   path      int  true  "Menu Item ID"
+// @Param        id          path      int     true   "Menu Item ID"
+// @Param        price_tier  query     string  false  "Show the price of this variant, e.g. student"
 // @Success      200  {object}  models.StandardResponse
-// @Failure      400  {object}  models.StandardResponse
-// @Failure      404  {object}  models.StandardResponse
+// @Failure      400  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
 // @Router       /menu-items/{id} [get]
-func GetMenuItem(c *gin.Context) {
-	const method = "GetMenuItem"
-	var status = http.StatusOK
-	var message = "Menu item fetched successfully"
-	var data interface{}
-	var errResp *models.ErrorResponse
-
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+func (h *Handlers) GetMenuItem(c *gin.Context) {
+	id, err := paramID(c, "id")
 	if err != nil {
-		status = http.StatusBadRequest
-		message = "Invalid menu item ID"
-		errResp = &models.ErrorResponse{
-			Error:   "INVALID_ID",
-			Message: fmt.Sprintf("%s: %v", method, err.Error()),
-		}
-	} else {
-		menuItem, err := database.GetMenuItemByID(uint(id))
-		if err != nil {
-			status = http.StatusNotFound
-			message = "Menu item not found"
-			errResp = &models.ErrorResponse{
-				Error:   "NOT_FOUND",
-				Message: fmt.Sprintf("%s: %v", method, err.Error()),
-			}
-		} else {
-			data = menuItem
-		}
+		_ = c.Error(err)
+		return
 	}
+	tier, err := queryPriceTier(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	menuItem, err := h.MenuItems.GetByID(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	menuItem.ApplyPriceTier(tier)
+	menuItem.SetVAT()
+	if err := h.setRating(c.Request.Context(), menuItem); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	h.ViewRecorder.Record(models.ViewEvent{Kind: models.ViewMenuItem, RestaurantID: menuItem.RestaurantID, MenuItemID: &menuItem.ID})
 
-	utils.Respond(c, status, message, data, errResp)
+	utils.Respond(c, http.StatusOK, "Menu item fetched successfully", menuItem, nil)
 }
 
 // GetRestaurantMenu godoc
@@ -240,47 +212,48 @@ func GetMenuItem(c *gin.Context) {
 // @Description  Get a paginated list of menu items for a specific restaurant
 // @Tags         menu-items
 // @Produce      json
-// @Param        id      path      int  true  "Restaurant ID"
-// @Param        limit   query     int  false  "Limit"
-// @Param        offset  query     int  false  "Offset"
+// @Param        id          path      int     true   "Restaurant ID"
+// @Param        limit       query     int     false  "Limit"
+// @Param        offset      query     int     false  "Offset"
+// @Param        price_tier  query     string  false  "Show the prices of this variant, e.g. student"
 // @Success      200  {object}  models.StandardResponse
-// @Failure      400  {object}  models.StandardResponse
-// @Failure      404  {object}  models.StandardResponse
+// @Failure      400  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
 // @Router       /restaurants/{id}/menu [get]
-func GetRestaurantMenu(c *gin.Context) {
-	const method = "GetRestaurantMenu"
-	var status = http.StatusOK
-	var message = "Menu items fetched successfully"
-	var data interface{}
-	var errResp *models.ErrorResponse
-
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+func (h *Handlers) GetRestaurantMenu(c *gin.Context) {
+	id, err := paramID(c, "id")
 	if err != nil {
-		status = http.StatusBadRequest
-		message = "Invalid restaurant ID"
-		errResp = &models.ErrorResponse{
-			Error:   "INVALID_ID",
-			Message: fmt.Sprintf("%s: %v", method, err.Error()),
-		}
-	} else {
-		limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-		offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+		_ = c.Error(err)
+		return
+	}
+	tier, err := queryPriceTier(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if _, err := h.Restaurants.GetByID(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
 
-		menuItems, total, err := database.GetMenuItems(uint(id), limit, offset)
-		if err != nil {
-			status = http.StatusInternalServerError
-			message = "Failed to retrieve menu items"
-			errResp = &models.ErrorResponse{
-				Error:   "DATABASE_ERROR",
-				Message: fmt.Sprintf("%s: %v", method, err.Error()),
-			}
-		} else {
-			data = models.MenuItemsResponse{
-				MenuItems: menuItems,
-				Total:     total,
-			}
-		}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	menuItems, total, err := h.MenuItems.List(c.Request.Context(), id, limit, offset)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	models.ApplyPriceTier(menuItems, tier)
+	models.SetVAT(menuItems)
+	if err := h.setRatings(c.Request.Context(), menuItems); err != nil {
+		_ = c.Error(err)
+		return
 	}
+	h.ViewRecorder.Record(models.ViewEvent{Kind: models.ViewMenu, RestaurantID: id})
 
-	utils.Respond(c, status, message, data, errResp)
+	utils.Respond(c, http.StatusOK, "Menu items fetched successfully", models.MenuItemsResponse{
+		MenuItems: menuItems,
+		Total:     total,
+	}, nil)
 }
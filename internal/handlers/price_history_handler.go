@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/config"
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetMenuItemPrices godoc
+// @Summary      Get the price history of a menu item
+// @Description  Returns the prices a menu item has had, oldest first, the current one and the scheduled future changes
+// @Tags         menu-items
+// @Produce      json
+// @Param        id   path      int  true  "Menu Item ID"
+// @Success      200  {object}  models.StandardResponse{data=models.PriceHistoryResponse}
+// @Failure      400  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
+// @Router       /menu-items/{id}/prices [get]
+func (h *Handlers) GetMenuItemPrices(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if _, err := h.MenuItems.GetByID(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	changes, err := h.PriceHistory.History(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	utils.Respond(c, http.StatusOK, "Price history fetched successfully", models.NewPriceHistoryResponse(id, changes), nil)
+}
+
+// ScheduleMenuItemPrice godoc
+// @Summary      Schedule a price change
+// @Description  Admin only: schedule a new price of a menu item that takes effect at effective_from, which must be in the future. Use PUT /menu-items/{id} to change the price now.
+// @Tags         menu-items
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                      true  "Menu Item ID"
+// @Param        change  body      models.PriceChangeInput  true  "Price change"
+// @Success      201  {object}  models.StandardResponse{data=models.PriceChange}
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
+// @Router       /menu-items/{id}/prices [post]
+// @Security     BearerAuth
+func (h *Handlers) ScheduleMenuItemPrice(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var input models.PriceChangeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		_ = c.Error(apperrors.FromBinding(err))
+		return
+	}
+	if ok, invalid := input.Validate(time.Now()); !ok {
+		_ = c.Error(apperrors.InvalidFields(invalid))
+		return
+	}
+	menuItem, err := h.MenuItems.GetByID(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	currency := input.Currency
+	if currency == "" {
+		currency = menuItem.Currency
+	}
+
+	change, err := h.PriceHistory.Schedule(c.Request.Context(), &models.PriceChange{
+		MenuItemID:    id,
+		Price:         input.Price,
+		Currency:      currency,
+		EffectiveFrom: input.EffectiveFrom,
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	utils.Respond(c, http.StatusCreated, "Price change scheduled successfully", change, nil)
+}
+
+// CancelMenuItemPrice godoc
+// @Summary      Cancel a scheduled price change
+// @Description  Admin only: delete a price change that has not taken effect yet
+// @Tags         menu-items
+// @Produce      json
+// @Param        id         path      int  true  "Menu Item ID"
+// @Param        change_id  path      int  true  "Price change ID"
+// @Success      200  {object}  models.StandardResponse
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
+// @Failure      409  {object}  models.Problem
+// @Router       /menu-items/{id}/prices/{change_id} [delete]
+// @Security     BearerAuth
+func (h *Handlers) CancelMenuItemPrice(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	changeID, err := paramID(c, "change_id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := h.PriceHistory.CancelScheduled(c.Request.Context(), id, changeID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	utils.Respond(c, http.StatusOK, "Price change cancelled successfully", nil, nil)
+}
+
+// GetPriceTrends godoc
+// @Summary      Get price trends
+// @Description  Returns the average price of the menu items per restaurant, region or category at the end of each day, week, month or year, from the price history. Only prices in the configured currency are included.
+// @Tags         statistics
+// @Produce      json
+// @Param        group_by  query     string  false  "restaurant, region (default) or category"
+// @Param        interval  query     string  false  "day, week, month (default) or year"
+// @Param        from      query     string  false  "Start, RFC 3339 or YYYY-MM-DD (default: a year before to)"
+// @Param        to        query     string  false  "End, RFC 3339 or YYYY-MM-DD (default: now)"
+// @Success      200  {object}  models.StandardResponse{data=models.PriceTrends}
+// @Failure      400  {object}  models.Problem
+// @Router       /stats/price-trends [get]
+func (h *Handlers) GetPriceTrends(c *gin.Context) {
+	q := models.PriceTrendQuery{
+		GroupBy:  c.DefaultQuery("group_by", models.TrendByRegion),
+		Interval: c.DefaultQuery("interval", models.TrendMonthly),
+		Currency: config.AppConfig.Pricing.Currency,
+	}
+	var fields []models.FieldError
+	switch q.GroupBy {
+	case models.TrendByRestaurant, models.TrendByRegion, models.TrendByCategory:
+	default:
+		fields = append(fields, models.FieldError{Field: "group_by", Message: "must be restaurant, region or category"})
+	}
+	switch q.Interval {
+	case models.TrendDaily, models.TrendWeekly, models.TrendMonthly, models.TrendYearly:
+	default:
+		fields = append(fields, models.FieldError{Field: "interval", Message: "must be day, week, month or year"})
+	}
+	to, err := queryTime(c, "to", time.Now())
+	if err != nil {
+		fields = append(fields, models.FieldError{Field: "to", Message: err.Error()})
+	}
+	from, err := queryTime(c, "from", to.AddDate(-1, 0, 0))
+	if err != nil {
+		fields = append(fields, models.FieldError{Field: "from", Message: err.Error()})
+	}
+	q.From, q.To = from, to
+	if len(fields) == 0 && (from.After(to) || q.TrendPeriods() == nil) {
+		fields = append(fields, models.FieldError{
+			Field:   "from",
+			Message: fmt.Sprintf("must be before to, with at most %d periods in between", models.MaxTrendPeriods),
+		})
+	}
+	if len(fields) > 0 {
+		_ = c.Error(apperrors.Validation("Invalid price trend query", fields...))
+		return
+	}
+
+	changes, err := h.PriceHistory.Changes(c.Request.Context(), q.Start(), q.End())
+	if err != nil {
+		_ = c.Error(apperrors.Internal("Failed to retrieve price trends", err))
+		return
+	}
+
+	utils.Respond(c, http.StatusOK, "Price trends fetched successfully", models.BuildPriceTrends(q, changes), nil)
+}
+
+var errInvalidTime = errors.New("must be an RFC 3339 time or a YYYY-MM-DD date")
+
+// queryTime parses the optional query parameter name as an RFC 3339 time or a
+// date (midnight UTC), def if absent
+func queryTime(c *gin.Context, name string, def time.Time) (time.Time, error) {
+	value := c.Query(name)
+	if value == "" {
+		return def, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return def, errInvalidTime
+	}
+	return t, nil
+}
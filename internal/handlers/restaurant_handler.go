@@ -1,12 +1,10 @@
 package handlers
 
 import (
-	"fmt"
 	"net/http"
 	"strconv"
-	"strings"
 
-	"lunch_menu/internal/database"
+	"lunch_menu/internal/apperrors"
 	"lunch_menu/internal/models"
 	"lunch_menu/internal/utils"
 
@@ -21,57 +19,39 @@ import (
 // @Produce      json
 // @Param        restaurant  body      models.RestaurantInput  true  "Restaurant Input"
 // @Success      201  {object}  models.RestaurantResponse
-// @Failure      400  {object}  models.ErrorResponse
-// @Failure      401  {object}  models.ErrorResponse
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Failure      409  {object}  models.Problem
 // @Router       /restaurants [post]
 // @Security     BearerAuth
-func CreateRestaurant(c *gin.Context) {
-	const method = "CreateRestaurant"
+func (h *Handlers) CreateRestaurant(c *gin.Context) {
 	var input models.RestaurantInput
-	var status = http.StatusCreated
-	var message = "Restaurant created successfully"
-	var data interface{}
-	var errResp *models.ErrorResponse
-
 	if err := c.ShouldBindJSON(&input); err != nil {
-		status = http.StatusBadRequest
-		message = "Invalid input"
-		errResp = &models.ErrorResponse{
-			Error:   "INVALID_INPUT",
-			Message: fmt.Sprintf("%s: %v", method, err.Error()),
-		}
-	} else if ok, invalid := input.Validate(); !ok {
-		status = http.StatusBadRequest
-		message = "Invalid input fields"
-		errResp = &models.ErrorResponse{
-			Error:   "INVALID_FIELDS",
-			Message: fmt.Sprintf("%s: Invalid fields: %s", method, strings.Join(invalid, ", ")),
-		}
-	} else {
-		restaurant := models.Restaurant{
-			Name:        input.Name,
-			Description: input.Description,
-			Address:     input.Address,
-			Coordinate:  input.Coordinate,
-			Homepage:    input.Homepage,
-			Region:      input.Region,
-			Phone:       input.Phone,
-			Email:       input.Email,
-		}
-		created, err := database.CreateRestaurant(&restaurant)
-		if err != nil {
-			status = http.StatusInternalServerError
-			message = "Failed to create restaurant"
-			errResp = &models.ErrorResponse{
-				Error:   "DATABASE_ERROR",
-				Message: fmt.Sprintf("%s: %v", method, err.Error()),
-			}
-		} else {
-			data = models.RestaurantResponse{Restaurant: *created}
-		}
+		_ = c.Error(apperrors.FromBinding(err))
+		return
+	}
+	if ok, invalid := input.Validate(); !ok {
+		_ = c.Error(apperrors.InvalidFields(invalid))
+		return
+	}
+
+	restaurant := models.Restaurant{
+		Name:        input.Name,
+		Description: input.Description,
+		Address:     input.Address,
+		Coordinate:  input.Coordinate,
+		Homepage:    input.Homepage,
+		Region:      input.Region,
+		Phone:       input.Phone,
+		Email:       input.Email,
+	}
+	created, err := h.Restaurants.Create(c.Request.Context(), &restaurant)
+	if err != nil {
+		_ = c.Error(err)
+		return
 	}
 
-	utils.Respond(c, status, message, data, errResp)
+	utils.Respond(c, http.StatusCreated, "Restaurant created successfully", models.RestaurantResponse{Restaurant: *created}, nil)
 }
 
 // UpdateRestaurant godoc
@@ -83,56 +63,40 @@ func CreateRestaurant(c *gin.Context) {
 // @Param        id          path      int                   true  "Restaurant ID"
 // @Param        restaurant  body      models.RestaurantUpdateInput  true  "Restaurant Update Input"
 // @Success      200  {object}  models.RestaurantResponse
-// @Failure      400  {object}  models.ErrorResponse
-// @Failure      401  {object}  models.ErrorResponse
-// @Failure      404  {object}  models.ErrorResponse
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
 // @Router       /restaurants/{id} [put]
 // @Security     BearerAuth
-func UpdateRestaurant(c *gin.Context) {
-	const method = "UpdateRestaurant"
-	var input models.RestaurantUpdateInput
-	var status = http.StatusOK
-	var message = "Restaurant updated successfully"
-	var data interface{}
-	var errResp *models.ErrorResponse
-
-	idStr := c.Param("id")
-	id, err := strconv.ParseUint(idStr, 10, 32)
+func (h *Handlers) UpdateRestaurant(c *gin.Context) {
+	id, err := paramID(c, "id")
 	if err != nil {
-		status = http.StatusBadRequest
-		message = "Invalid restaurant ID"
-		errResp = &models.ErrorResponse{
-			Error:   "INVALID_ID",
-			Message: fmt.Sprintf("%s: %v", method, err.Error()),
-		}
-		utils.Respond(c, status, message, data, errResp)
+		_ = c.Error(err)
 		return
 	}
 
+	var input models.RestaurantUpdateInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		status = http.StatusBadRequest
-		message = "Invalid input"
-		errResp = &models.ErrorResponse{
-			Error:   "INVALID_INPUT",
-			Message: fmt.Sprintf("%s: %v", method, err.Error()),
-		}
-		utils.Respond(c, status, message, data, errResp)
+		_ = c.Error(apperrors.FromBinding(err))
+		return
+	}
+	if ok, invalid := input.Validate(); !ok {
+		_ = c.Error(apperrors.InvalidFields(invalid))
 		return
 	}
 
-	updated, err := database.PartialUpdateRestaurant(uint(id), &input)
+	updated, err := h.Restaurants.Update(c.Request.Context(), id, &input)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	rating, err := h.restaurantRating(c.Request.Context(), id)
 	if err != nil {
-		status = http.StatusInternalServerError
-		message = "Failed to update restaurant"
-		errResp = &models.ErrorResponse{
-			Error:   "DATABASE_ERROR",
-			Message: fmt.Sprintf("%s: %v", method, err.Error()),
-		}
-	} else {
-		data = models.RestaurantResponse{Restaurant: *updated}
+		_ = c.Error(err)
+		return
 	}
 
-	utils.Respond(c, status, message, data, errResp)
+	utils.Respond(c, http.StatusOK, "Restaurant updated successfully", models.RestaurantResponse{Restaurant: *updated, Rating: rating}, nil)
 }
 
 // DeleteRestaurant godoc
@@ -142,37 +106,23 @@ func UpdateRestaurant(c *gin.Context) {
 // @Produce      json
 // @Param        id   path      int  true  "Restaurant ID"
 // @Success      200  {object}  models.StandardResponse
-// @Failure      400  {object}  models.ErrorResponse
-// @Failure      401  {object}  models.ErrorResponse
-// @Failure      404  {object}  models.ErrorResponse
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
 // @Router       /restaurants/{id} [delete]
 // @Security     BearerAuth
-func DeleteRestaurant(c *gin.Context) {
-	const method = "DeleteRestaurant"
-	var status = http.StatusOK
-	var message = "Restaurant deleted successfully"
-	var data interface{}
-	var errResp *models.ErrorResponse
-
-	idStr := c.Param("id")
-	id, err := strconv.ParseUint(idStr, 10, 32)
+func (h *Handlers) DeleteRestaurant(c *gin.Context) {
+	id, err := paramID(c, "id")
 	if err != nil {
-		status = http.StatusBadRequest
-		message = "Invalid restaurant ID"
-		errResp = &models.ErrorResponse{
-			Error:   "INVALID_ID",
-			Message: fmt.Sprintf("%s: %v", method, err.Error()),
-		}
-	} else if err := database.DeleteRestaurant(uint(id)); err != nil {
-		status = http.StatusInternalServerError
-		message = "Failed to delete restaurant"
-		errResp = &models.ErrorResponse{
-			Error:   "DELETE_FAILED",
-			Message: fmt.Sprintf("%s: %v", method, err.Error()),
-		}
+		_ = c.Error(err)
+		return
+	}
+	if err := h.Restaurants.Delete(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
 	}
 
-	utils.Respond(c, status, message, data, errResp)
+	utils.Respond(c, http.StatusOK, "Restaurant deleted successfully", nil, nil)
 }
 
 // GetRestaurants godoc
@@ -183,34 +133,22 @@ func DeleteRestaurant(c *gin.Context) {
 // @Param        limit   query     int  false  "Limit"
 // @Param        offset  query     int  false  "Offset"
 // @Success      200  {object}  models.RestaurantsResponse
-// @Failure      500  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.Problem
 // @Router       /restaurants [get]
-func GetRestaurants(c *gin.Context) {
-	const method = "GetRestaurants"
-	var status = http.StatusOK
-	var message = "Restaurants fetched successfully"
-	var data interface{}
-	var errResp *models.ErrorResponse
-
+func (h *Handlers) GetRestaurants(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 
-	restaurants, total, err := database.GetRestaurants(limit, offset)
+	restaurants, total, err := h.Restaurants.List(c.Request.Context(), limit, offset)
 	if err != nil {
-		status = http.StatusInternalServerError
-		message = "Failed to retrieve restaurants"
-		errResp = &models.ErrorResponse{
-			Error:   "DATABASE_ERROR",
-			Message: fmt.Sprintf("%s: %v", method, err.Error()),
-		}
-	} else {
-		data = models.RestaurantsResponse{
-			Restaurants: restaurants,
-			Total:       total,
-		}
+		_ = c.Error(err)
+		return
 	}
 
-	utils.Respond(c, status, message, data, errResp)
+	utils.Respond(c, http.StatusOK, "Restaurants fetched successfully", models.RestaurantsResponse{
+		Restaurants: restaurants,
+		Total:       total,
+	}, nil)
 }
 
 // GetRestaurant godoc
@@ -220,39 +158,26 @@ func GetRestaurants(c *gin.Context) {
 // @Produce      json
 // @Param        id   path      int  true  "Restaurant ID"
 // @Success      200  {object}  models.RestaurantResponse
-// @Failure      400  {object}  models.ErrorResponse
-// @Failure      404  {object}  models.ErrorResponse
+// @Failure      400  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
 // @Router       /restaurants/{id} [get]
-func GetRestaurant(c *gin.Context) {
-	const method = "GetRestaurant"
-	var status = http.StatusOK
-	var message = "Restaurant fetched successfully"
-	var data interface{}
-	var errResp *models.ErrorResponse
-
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+func (h *Handlers) GetRestaurant(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	restaurant, err := h.Restaurants.GetByID(c.Request.Context(), id)
 	if err != nil {
-		status = http.StatusBadRequest
-		message = "Invalid restaurant ID"
-		errResp = &models.ErrorResponse{
-			Error:   "INVALID_ID",
-			Message: fmt.Sprintf("%s: %v", method, err.Error()),
-		}
-	} else {
-		restaurant, err := database.GetRestaurantByID(uint(id))
-		if err != nil {
-			status = http.StatusNotFound
-			message = "Restaurant not found"
-			errResp = &models.ErrorResponse{
-				Error:   "NOT_FOUND",
-				Message: fmt.Sprintf("%s: %v", method, err.Error()),
-			}
-		} else {
-			data = models.RestaurantResponse{
-				Restaurant: *restaurant,
-			}
-		}
+		_ = c.Error(err)
+		return
+	}
+	rating, err := h.restaurantRating(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
 	}
+	h.ViewRecorder.Record(models.ViewEvent{Kind: models.ViewRestaurant, RestaurantID: id})
 
-	utils.Respond(c, status, message, data, errResp)
+	utils.Respond(c, http.StatusOK, "Restaurant fetched successfully", models.RestaurantResponse{Restaurant: *restaurant, Rating: rating}, nil)
 }
@@ -0,0 +1,409 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetRestaurantReviews godoc
+// @Summary      List the reviews of a restaurant
+// @Description  Returns a page of the visible reviews of a restaurant, newest first, and its average rating
+// @Tags         reviews
+// @Produce      json
+// @Param        id      path      int  true   "Restaurant ID"
+// @Param        limit   query     int  false  "Limit"
+// @Param        offset  query     int  false  "Offset"
+// @Success      200  {object}  models.StandardResponse{data=models.ReviewsResponse}
+// @Failure      400  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
+// @Router       /restaurants/{id}/reviews [get]
+func (h *Handlers) GetRestaurantReviews(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if _, err := h.Restaurants.GetByID(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	h.listReviews(c, models.ReviewRestaurant, id)
+}
+
+// CreateRestaurantReview godoc
+// @Summary      Review a restaurant
+// @Description  Customers only: rate a restaurant from 1 to 5 with an optional comment, once per day
+// @Tags         reviews
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                 true  "Restaurant ID"
+// @Param        review  body      models.ReviewInput  true  "Review"
+// @Success      201  {object}  models.StandardResponse{data=models.Review}
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Failure      403  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
+// @Failure      409  {object}  models.Problem
+// @Router       /restaurants/{id}/reviews [post]
+// @Security     BearerAuth
+func (h *Handlers) CreateRestaurantReview(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if _, err := h.Restaurants.GetByID(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	h.createReview(c, models.ReviewRestaurant, id)
+}
+
+// GetMenuItemReviews godoc
+// @Summary      List the reviews of a menu item
+// @Description  Returns a page of the visible reviews of a menu item, newest first, and its average rating
+// @Tags         reviews
+// @Produce      json
+// @Param        id      path      int  true   "Menu Item ID"
+// @Param        limit   query     int  false  "Limit"
+// @Param        offset  query     int  false  "Offset"
+// @Success      200  {object}  models.StandardResponse{data=models.ReviewsResponse}
+// @Failure      400  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
+// @Router       /menu-items/{id}/reviews [get]
+func (h *Handlers) GetMenuItemReviews(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if _, err := h.MenuItems.GetByID(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	h.listReviews(c, models.ReviewMenuItem, id)
+}
+
+// CreateMenuItemReview godoc
+// @Summary      Review a menu item
+// @Description  Customers only: rate a menu item from 1 to 5 with an optional comment, once per day
+// @Tags         reviews
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                 true  "Menu Item ID"
+// @Param        review  body      models.ReviewInput  true  "Review"
+// @Success      201  {object}  models.StandardResponse{data=models.Review}
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Failure      403  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
+// @Failure      409  {object}  models.Problem
+// @Router       /menu-items/{id}/reviews [post]
+// @Security     BearerAuth
+func (h *Handlers) CreateMenuItemReview(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if _, err := h.MenuItems.GetByID(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	h.createReview(c, models.ReviewMenuItem, id)
+}
+
+// UpdateReview godoc
+// @Summary      Edit a review
+// @Description  Change the rating or comment of one of your own reviews
+// @Tags         reviews
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                       true  "Review ID"
+// @Param        review  body      models.ReviewUpdateInput  true  "Changes"
+// @Success      200  {object}  models.StandardResponse{data=models.Review}
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Failure      403  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
+// @Router       /reviews/{id} [put]
+// @Security     BearerAuth
+func (h *Handlers) UpdateReview(c *gin.Context) {
+	id, err := h.ownReview(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	var input models.ReviewUpdateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		_ = c.Error(apperrors.FromBinding(err))
+		return
+	}
+	if ok, invalid := input.Validate(); !ok {
+		_ = c.Error(apperrors.InvalidFields(invalid))
+		return
+	}
+
+	updated, err := h.Reviews.Update(c.Request.Context(), id, &input)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	utils.Respond(c, http.StatusOK, "Review updated successfully", updated, nil)
+}
+
+// DeleteReview godoc
+// @Summary      Delete a review
+// @Description  Delete one of your own reviews
+// @Tags         reviews
+// @Produce      json
+// @Param        id   path      int  true  "Review ID"
+// @Success      200  {object}  models.StandardResponse
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Failure      403  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
+// @Router       /reviews/{id} [delete]
+// @Security     BearerAuth
+func (h *Handlers) DeleteReview(c *gin.Context) {
+	id, err := h.ownReview(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := h.Reviews.Delete(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	utils.Respond(c, http.StatusOK, "Review deleted successfully", nil, nil)
+}
+
+// GetReviews godoc
+// @Summary      List reviews for moderation
+// @Description  Admin only: a page of all reviews, also hidden ones, newest first
+// @Tags         reviews
+// @Produce      json
+// @Param        flagged      query     bool    false  "Only flagged (true) or unflagged (false) reviews"
+// @Param        hidden       query     bool    false  "Only hidden (true) or visible (false) reviews"
+// @Param        target_type  query     string  false  "restaurant or menu_item"
+// @Param        limit        query     int     false  "Limit"
+// @Param        offset       query     int     false  "Offset"
+// @Success      200  {object}  models.StandardResponse{data=models.ModerationReviewsResponse}
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Failure      403  {object}  models.Problem
+// @Router       /reviews [get]
+// @Security     BearerAuth
+func (h *Handlers) GetReviews(c *gin.Context) {
+	filter := models.ReviewFilter{TargetType: c.Query("target_type")}
+	var fields []models.FieldError
+	switch filter.TargetType {
+	case "", models.ReviewRestaurant, models.ReviewMenuItem:
+	default:
+		fields = append(fields, models.FieldError{Field: "target_type", Message: "must be restaurant or menu_item"})
+	}
+	var err error
+	if filter.Flagged, err = queryBool(c, "flagged"); err != nil {
+		fields = append(fields, models.FieldError{Field: "flagged", Message: err.Error()})
+	}
+	if filter.Hidden, err = queryBool(c, "hidden"); err != nil {
+		fields = append(fields, models.FieldError{Field: "hidden", Message: err.Error()})
+	}
+	if len(fields) > 0 {
+		_ = c.Error(apperrors.Validation("Invalid review query", fields...))
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	reviews, total, err := h.Reviews.List(c.Request.Context(), filter, limit, offset)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	utils.Respond(c, http.StatusOK, "Reviews fetched successfully", models.ModerationReviewsResponse{
+		Reviews: reviews,
+		Total:   total,
+	}, nil)
+}
+
+// ModerateReview godoc
+// @Summary      Moderate a review
+// @Description  Admin only: hide a review from the listings and ratings, flag it for a closer look, or undo either
+// @Tags         reviews
+// @Accept       json
+// @Produce      json
+// @Param        id          path      int                           true  "Review ID"
+// @Param        moderation  body      models.ReviewModerationInput  true  "Moderation"
+// @Success      200  {object}  models.StandardResponse{data=models.Review}
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Failure      403  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
+// @Router       /reviews/{id}/moderation [put]
+// @Security     BearerAuth
+func (h *Handlers) ModerateReview(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	var input models.ReviewModerationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		_ = c.Error(apperrors.FromBinding(err))
+		return
+	}
+
+	moderated, err := h.Reviews.Moderate(c.Request.Context(), id, &input)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	utils.Respond(c, http.StatusOK, "Review moderated successfully", moderated, nil)
+}
+
+var errInvalidBool = errors.New("must be true or false")
+
+// queryBool parses the optional query parameter name, nil if absent
+func queryBool(c *gin.Context, name string) (*bool, error) {
+	value, ok := c.GetQuery(name)
+	if !ok {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, errInvalidBool
+	}
+	return &b, nil
+}
+
+// listReviews responds with a page of the visible reviews of a target
+func (h *Handlers) listReviews(c *gin.Context, targetType string, targetID uint) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	visible := false
+	reviews, total, err := h.Reviews.List(c.Request.Context(), models.ReviewFilter{TargetType: targetType, TargetID: targetID, Hidden: &visible}, limit, offset)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	ratings, err := h.Reviews.Ratings(c.Request.Context(), targetType, []uint{targetID})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	utils.Respond(c, http.StatusOK, "Reviews fetched successfully", models.ReviewsResponse{
+		Reviews: reviews,
+		Total:   total,
+		Rating:  ratings[targetID],
+	}, nil)
+}
+
+// createReview stores the review of a target by the authenticated customer
+func (h *Handlers) createReview(c *gin.Context, targetType string, targetID uint) {
+	userID, role, err := currentUser(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if role != models.RoleCustomer {
+		_ = c.Error(apperrors.Forbidden("Only customers can write reviews"))
+		return
+	}
+	var input models.ReviewInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		_ = c.Error(apperrors.FromBinding(err))
+		return
+	}
+	if ok, invalid := input.Validate(); !ok {
+		_ = c.Error(apperrors.InvalidFields(invalid))
+		return
+	}
+
+	created, err := h.Reviews.Create(c.Request.Context(), &models.Review{
+		UserID:     userID,
+		TargetType: targetType,
+		TargetID:   targetID,
+		ReviewedOn: models.ReviewDate(time.Now()),
+		Rating:     input.Rating,
+		Comment:    input.Comment,
+	})
+	if errors.Is(err, apperrors.ErrConflict) {
+		err = apperrors.Conflict("You have already reviewed this today, edit that review instead")
+	}
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	utils.Respond(c, http.StatusCreated, "Review created successfully", created, nil)
+}
+
+// ownReview returns the ID of the review in the path if it was written by the
+// authenticated user
+func (h *Handlers) ownReview(c *gin.Context) (uint, error) {
+	userID, _, err := currentUser(c)
+	if err != nil {
+		return 0, err
+	}
+	id, err := paramID(c, "id")
+	if err != nil {
+		return 0, err
+	}
+	review, err := h.Reviews.GetByID(c.Request.Context(), id)
+	if err != nil {
+		return 0, err
+	}
+	if review.UserID != userID {
+		return 0, apperrors.Forbidden("You can only change your own reviews")
+	}
+	return id, nil
+}
+
+// restaurantRating returns the rating summary of a restaurant
+func (h *Handlers) restaurantRating(ctx context.Context, id uint) (models.RatingSummary, error) {
+	ratings, err := h.Reviews.Ratings(ctx, models.ReviewRestaurant, []uint{id})
+	return ratings[id], err
+}
+
+// setRating sets the rating summary of a menu item
+func (h *Handlers) setRating(ctx context.Context, item *models.MenuItem) error {
+	ratings, err := h.Reviews.Ratings(ctx, models.ReviewMenuItem, []uint{item.ID})
+	if err != nil {
+		return err
+	}
+	rating := ratings[item.ID]
+	item.Rating = &rating
+	return nil
+}
+
+// setRatings sets the rating summaries of menu items
+func (h *Handlers) setRatings(ctx context.Context, items []models.MenuItem) error {
+	ids := make([]uint, len(items))
+	for i := range items {
+		ids[i] = items[i].ID
+	}
+	ratings, err := h.Reviews.Ratings(ctx, models.ReviewMenuItem, ids)
+	if err != nil {
+		return err
+	}
+	for i := range items {
+		rating := ratings[items[i].ID]
+		items[i].Rating = &rating
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/events"
+	"lunch_menu/internal/models"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+// StreamEvents godoc
+// @Summary      Stream live menu updates
+// @Description  Server-Sent Events stream of restaurant and menu item change events.
+// @Description  Each event has the event type as name, the event ID as id and a JSON body.
+// @Description  Reconnecting clients send Last-Event-ID (or ?last_event_id=) to receive the events they missed, as far as they are still in the recent history.
+// @Tags         stream
+// @Produce      text/event-stream
+// @Param        region         query     string  false  "Only events of restaurants in this region"
+// @Param        restaurant_id  query     int     false  "Only events of this restaurant"
+// @Param        last_event_id  query     int     false  "Resume after this event ID (alternative to the Last-Event-ID header)"
+// @Success      200  {string}  string  "event stream"
+// @Failure      400  {object}  models.Problem
+// @Failure      503  {object}  models.Problem
+// @Router       /stream [get]
+func StreamEvents(c *gin.Context) {
+	broker := events.Default
+	if broker == nil {
+		_ = c.Error(apperrors.Unavailable("Event stream unavailable: event broker is not running"))
+		return
+	}
+
+	restaurantID, err := queryID(c, "restaurant_id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	lastEventIDStr := c.GetHeader("Last-Event-ID")
+	if lastEventIDStr == "" {
+		lastEventIDStr = c.DefaultQuery("last_event_id", "0")
+	}
+	lastEventID, err := strconv.ParseUint(lastEventIDStr, 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.Validation("Invalid Last-Event-ID", models.FieldError{Field: "last_event_id", Message: "must be a positive integer"}))
+		return
+	}
+
+	filter := events.Filter{Region: c.Query("region"), RestaurantID: restaurantID}
+	sub, replay := broker.Subscribe(filter, uint(lastEventID))
+	defer broker.Unsubscribe(sub)
+
+	disableWriteDeadline(c)
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // disable response buffering in nginx
+	c.Status(http.StatusOK)
+
+	for i := range replay {
+		writeStreamEvent(c.Writer, &replay[i])
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(broker.Heartbeat())
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case e, ok := <-sub.C:
+			if !ok {
+				return false
+			}
+			writeStreamEvent(w, &e)
+		case <-heartbeat.C:
+			_, _ = io.WriteString(w, ": heartbeat\n\n")
+		}
+		return true
+	})
+}
+
+func writeStreamEvent(w io.Writer, e *events.Event) {
+	_ = sse.Encode(w, sse.Event{
+		Id:    strconv.FormatUint(uint64(e.ID), 10),
+		Event: e.Type,
+		Data:  e,
+	})
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetTrending godoc
+// @Summary      Get trending restaurants and dishes
+// @Description  Returns the most viewed active restaurants and available menu items of the last day or week, from the anonymous views of GET /restaurants/{id}, /restaurants/{id}/menu and /menu-items/{id}. The views of a restaurant include those of its menu and menu items. Views are written in batches, so the latest ones may be missing for a few seconds.
+// @Tags         statistics
+// @Produce      json
+// @Param        period  query     string  false  "day (default, the last 24 hours) or week (the last 7 days)"
+// @Param        limit   query     int     false  "Restaurants and menu items to return (default 10, at most 50)"
+// @Success      200  {object}  models.StandardResponse{data=models.Trending}
+// @Failure      400  {object}  models.Problem
+// @Failure      500  {object}  models.Problem
+// @Router       /stats/trending [get]
+func (h *Handlers) GetTrending(c *gin.Context) {
+	period := c.DefaultQuery("period", models.TrendingDay)
+	var fields []models.FieldError
+	since, ok := models.TrendingSince(period, time.Now())
+	if !ok {
+		fields = append(fields, models.FieldError{Field: "period", Message: "must be day or week"})
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(models.DefaultTrendingLimit)))
+	if err != nil || limit < 1 || limit > models.MaxTrendingLimit {
+		fields = append(fields, models.FieldError{
+			Field:   "limit",
+			Message: fmt.Sprintf("must be between 1 and %d", models.MaxTrendingLimit),
+		})
+	}
+	if len(fields) > 0 {
+		_ = c.Error(apperrors.Validation("Invalid trending query", fields...))
+		return
+	}
+
+	restaurants, items, err := h.Views.Trending(c.Request.Context(), since, limit)
+	if err != nil {
+		_ = c.Error(apperrors.Internal("Failed to retrieve trending restaurants and dishes", err))
+		return
+	}
+
+	utils.Respond(c, http.StatusOK, "Trending restaurants and dishes fetched successfully", models.Trending{
+		Period:      period,
+		Since:       since,
+		Restaurants: restaurants,
+		MenuItems:   items,
+	}, nil)
+}
@@ -5,8 +5,10 @@ import (
 	"strings"
 	"time"
 
-	"lunch_menu/internal/database"
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/metrics"
 	"lunch_menu/internal/models"
+	"lunch_menu/internal/tracing"
 	"lunch_menu/internal/utils"
 
 	"github.com/gin-gonic/gin"
@@ -20,156 +22,132 @@ func setCSRFToken(c *gin.Context) string {
 }
 
 // UserRegister godoc
-// @Summary      Register a new admin user
-// @Description  Register a new admin user (admin only)
+// @Summary      Register a new user
+// @Description  Register a new customer; registering an admin requires the Bearer token of an admin
 // @Tags         users
 // @Accept       json
 // @Produce      json
 // @Param        user  body      models.UserInput  true  "User Input"
 // @Success      201  {object}  models.SafeUser
-// @Failure      400  {object}  models.ErrorResponse
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Failure      403  {object}  models.Problem
+// @Failure      409  {object}  models.Problem
+// @Failure      429  {object}  models.Problem
 // @Router       /user/register [post]
-func UserRegister(c *gin.Context) {
-	const method = "AdminRegister"
+func (h *Handlers) UserRegister(c *gin.Context) {
 	var input models.UserInput
-	var status = http.StatusCreated
-	var message = "Admin registered successfully"
-	var data interface{}
-	var errResp *models.ErrorResponse
-
 	if err := c.ShouldBindJSON(&input); err != nil {
-		status = http.StatusBadRequest
-		message = "Invalid User Input data"
-		errResp = &models.ErrorResponse{
-			Error:   "INVALID_INPUT",
-			Message: method + ": " + err.Error(),
-		}
-	} else if ok, invalid := input.Validate(); !ok {
-		status = http.StatusBadRequest
-		message = "Invalid input data"
-		errResp = &models.ErrorResponse{
-			Error:   "VALIDATION_FAILED",
-			Message: method + ": " + strings.Join(invalid, ", "),
-		}
-	} else {
-		// Always set role to admin for this endpoint
-		user := &models.User{
-			Username:     input.Username,
-			PasswordHash: input.PasswordHash,
-			Email:        input.Email,
-			Role:         input.Role,
-			IsActive:     true,
-		}
-		// Hash password before saving
-		hashed, err := utils.HashPassword(user.PasswordHash)
+		_ = c.Error(apperrors.FromBinding(err))
+		return
+	}
+	if ok, invalid := input.Validate(); !ok {
+		_ = c.Error(apperrors.InvalidFields(invalid))
+		return
+	}
+
+	// Anyone can register as a customer, only admins create admins
+	role := models.RoleCustomer
+	if input.Role == models.RoleAdmin {
+		_, callerRole, err := currentUser(c)
 		if err != nil {
-			status = http.StatusInternalServerError
-			message = "Failed to hash password"
-			errResp = &models.ErrorResponse{
-				Error:   "HASH_ERROR",
-				Message: method + ": " + err.Error(),
-			}
-		} else {
-			user.PasswordHash = hashed
-			user, err := database.CreateUser(user)
-			if err != nil {
-				status = http.StatusBadRequest
-				message = "Registration failed"
-				errResp = &models.ErrorResponse{
-					Error:   "REGISTRATION_FAILED",
-					Message: method + ": " + err.Error(),
-				}
-			} else {
-				csrfToken := setCSRFToken(c)
-				data = gin.H{
-					"user":       user.ToSafeUser(),
-					"csrf_token": csrfToken,
-				}
-			}
+			_ = c.Error(err)
+			return
+		}
+		if callerRole != models.RoleAdmin {
+			_ = c.Error(apperrors.Forbidden("Only admins can register admins"))
+			return
 		}
+		role = models.RoleAdmin
+	}
+	user := &models.User{
+		Username:     input.Username,
+		PasswordHash: input.PasswordHash,
+		Email:        input.Email,
+		Role:         role,
+		IsActive:     true,
+	}
+	// Hash password before saving
+	_, span := tracing.Start(c.Request.Context(), "auth.hash_password")
+	hashed, err := utils.HashPassword(user.PasswordHash)
+	tracing.End(span, err)
+	if err != nil {
+		_ = c.Error(apperrors.Internal("Failed to hash password", err))
+		return
+	}
+	user.PasswordHash = hashed
+	user, err = h.Users.Create(c.Request.Context(), user)
+	if err != nil {
+		_ = c.Error(err)
+		return
 	}
 
-	utils.Respond(c, status, message, data, errResp)
+	csrfToken := setCSRFToken(c)
+	utils.Respond(c, http.StatusCreated, "User registered successfully", gin.H{
+		"user":       user.ToSafeUser(),
+		"csrf_token": csrfToken,
+	}, nil)
 }
 
 // UserLogin godoc
-// @Summary      Login as admin
-// @Description  Login as admin user and receive JWT
+// @Summary      Login
+// @Description  Login as an admin or customer user and receive JWT
 // @Tags         users
 // @Accept       json
 // @Produce      json
 // @Param        user  body      models.UserLoginInput  true  "Login Input"
 // @Success      200  {object}  models.SafeUser
-// @Failure      400  {object}  models.ErrorResponse
-// @Failure      401  {object}  models.ErrorResponse
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Failure      429  {object}  models.Problem
 // @Router       /user/login [post]
-func UserLogin(c *gin.Context) {
-	const method = "UserLogin"
+func (h *Handlers) UserLogin(c *gin.Context) {
 	var input models.UserLoginInput
-	var status = http.StatusOK
-	var message = "Login successful"
-	var data interface{}
-	var errResp *models.ErrorResponse
-
 	if err := c.ShouldBindJSON(&input); err != nil {
-		status = http.StatusBadRequest
-		message = "Invalid login data"
-		errResp = &models.ErrorResponse{
-			Error:   "INVALID_INPUT",
-			Message: method + ": " + err.Error(),
-		}
-	} else {
-		user, err := database.GetUserByUsername(input.Username)
-		if err != nil || !user.IsActive || user.Role != "admin" {
-			status = http.StatusUnauthorized
-			message = "Admin not found or not active or not authorized"
-			errResp = &models.ErrorResponse{
-				Error:   "AUTH_FAILED",
-				Message: method + ": Admin not found or not active or not authorized",
-			}
-		} else if err := utils.CheckPasswordHash(input.PasswordHash, user.PasswordHash); err != nil {
-			status = http.StatusUnauthorized
-			message = "Invalid credentials"
-			errResp = &models.ErrorResponse{
-				Error:   "AUTH_FAILED",
-				Message: method + ": Invalid credentials",
-			}
-		} else {
-			// Generate access and refresh tokens
-			accessToken, err := utils.GenerateJWT(user)
-			if err != nil {
-				status = http.StatusInternalServerError
-				message = "Failed to generate access token"
-				errResp = &models.ErrorResponse{
-					Error:   "TOKEN_ERROR",
-					Message: method + ": " + err.Error(),
-				}
-			} else {
-				refreshToken := utils.GenerateRandomToken()
-				refreshTokenAge := 7 * 24 * 3600 // 7 days
-				expiresAt := time.Now().Add(time.Duration(refreshTokenAge) * time.Second)
-				err = database.SaveRefreshToken(user.ID, refreshToken, c.Request.UserAgent(), c.ClientIP(), expiresAt)
-				if err != nil {
-					status = http.StatusInternalServerError
-					message = "Failed to save refresh token"
-					errResp = &models.ErrorResponse{
-						Error:   "DB_ERROR",
-						Message: method + ": " + err.Error(),
-					}
-				} else {
-					csrfToken := setCSRFToken(c)
-					utils.SetAuthCookies(c, accessToken, refreshToken, csrfToken)
-					safeUser := user.ToSafeUser()
-					data = gin.H{
-						"user":  safeUser,
-						"token": accessToken,
-					}
-				}
-			}
+		_ = c.Error(apperrors.FromBinding(err))
+		return
+	}
+
+	user, err := h.Users.GetByUsername(c.Request.Context(), input.Username)
+	if err != nil || !user.IsActive || (user.Role != models.RoleAdmin && user.Role != models.RoleCustomer) {
+		if err != nil && apperrors.KindOf(err) != apperrors.KindNotFound {
+			_ = c.Error(err)
+			return
 		}
+		metrics.Logins.WithLabelValues(metrics.ResultFailure).Inc()
+		_ = c.Error(apperrors.Unauthorized("AUTH_FAILED", "User not found or not active or not authorized"))
+		return
+	}
+	_, span := tracing.Start(c.Request.Context(), "auth.check_password")
+	err = utils.CheckPasswordHash(input.PasswordHash, user.PasswordHash)
+	span.End() // a mismatch is an expected outcome, not a span error
+	if err != nil {
+		metrics.Logins.WithLabelValues(metrics.ResultFailure).Inc()
+		_ = c.Error(apperrors.Unauthorized("AUTH_FAILED", "Invalid credentials"))
+		return
+	}
+
+	// Generate access and refresh tokens
+	accessToken, err := utils.GenerateJWT(user)
+	if err != nil {
+		_ = c.Error(apperrors.Internal("Failed to generate access token", err))
+		return
+	}
+	refreshToken := utils.GenerateRandomToken()
+	refreshTokenAge := 7 * 24 * 3600 // 7 days
+	expiresAt := time.Now().Add(time.Duration(refreshTokenAge) * time.Second)
+	if err := h.Tokens.SaveRefreshToken(c.Request.Context(), user.ID, refreshToken, c.Request.UserAgent(), c.ClientIP(), expiresAt); err != nil {
+		_ = c.Error(apperrors.Internal("Failed to save refresh token", err))
+		return
 	}
 
-	utils.Respond(c, status, message, data, errResp)
+	metrics.Logins.WithLabelValues(metrics.ResultSuccess).Inc()
+	csrfToken := setCSRFToken(c)
+	utils.SetAuthCookies(c, accessToken, refreshToken, csrfToken)
+	utils.Respond(c, http.StatusOK, "Login successful", gin.H{
+		"user":  user.ToSafeUser(),
+		"token": accessToken,
+	}, nil)
 }
 
 // UserLogout godoc
@@ -179,7 +157,7 @@ func UserLogin(c *gin.Context) {
 // @Produce      json
 // @Success      200  {object}  models.StandardResponse
 // @Router       /user/logout [post]
-func UserLogout(c *gin.Context) {
+func (h *Handlers) UserLogout(c *gin.Context) {
 	// Get the access token from the Authorization header
 	authHeader := c.GetHeader("Authorization")
 	if strings.HasPrefix(authHeader, "Bearer ") {
@@ -188,13 +166,13 @@ func UserLogout(c *gin.Context) {
 		if err == nil && claims["exp"] != nil {
 			exp := int64(claims["exp"].(float64))
 			expiresAt := time.Unix(exp, 0)
-			_ = database.BlacklistToken(token, expiresAt)
+			_ = h.Tokens.BlacklistToken(c.Request.Context(), token, expiresAt)
 		}
 		// Also revoke refresh token
 		refreshToken, err := c.Cookie("refresh_token")
 		if err == nil && claims["user_id"] != nil {
 			userID := uint(claims["user_id"].(float64))
-			_ = database.DeleteRefreshToken(userID, refreshToken)
+			_ = h.Tokens.DeleteRefreshToken(c.Request.Context(), userID, refreshToken)
 		}
 	}
 	utils.ExpireAuthCookies(c)
@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateWebhookSubscription godoc
+// @Summary      Create a webhook subscription
+// @Description  Admin only: subscribe a URL to restaurant and menu item change events ("*" for all).
+// @Description  The secret is used to sign deliveries (X-Webhook-Signature) and is only returned on creation; one is generated if omitted.
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        subscription  body      models.WebhookSubscriptionInput  true  "Webhook Subscription Input"
+// @Success      201  {object}  models.StandardResponse{data=models.WebhookSubscription}
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Router       /webhooks [post]
+// @Security     BearerAuth
+func (h *Handlers) CreateWebhookSubscription(c *gin.Context) {
+	var input models.WebhookSubscriptionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		_ = c.Error(apperrors.FromBinding(err))
+		return
+	}
+	if ok, invalid := input.Validate(); !ok {
+		_ = c.Error(apperrors.InvalidFields(invalid))
+		return
+	}
+
+	sub := models.WebhookSubscription{
+		URL:        input.URL,
+		EventTypes: strings.Join(input.EventTypes, ","),
+		Secret:     input.Secret,
+		IsActive:   input.IsActive == nil || *input.IsActive,
+	}
+	if sub.Secret == "" {
+		sub.Secret = utils.GenerateRandomToken()
+	}
+	created, err := h.Webhooks.Create(c.Request.Context(), &sub)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	utils.Respond(c, http.StatusCreated, "Webhook subscription created successfully", created, nil)
+}
+
+// GetWebhookSubscriptions godoc
+// @Summary      List webhook subscriptions
+// @Description  Admin only: get a paginated list of webhook subscriptions
+// @Tags         webhooks
+// @Produce      json
+// @Param        limit   query     int  false  "Limit"
+// @Param        offset  query     int  false  "Offset"
+// @Success      200  {object}  models.StandardResponse{data=models.WebhookSubscriptionsResponse}
+// @Failure      401  {object}  models.Problem
+// @Failure      500  {object}  models.Problem
+// @Router       /webhooks [get]
+// @Security     BearerAuth
+func (h *Handlers) GetWebhookSubscriptions(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	subs, total, err := h.Webhooks.List(c.Request.Context(), limit, offset)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	for i := range subs {
+		subs[i].Secret = ""
+	}
+
+	utils.Respond(c, http.StatusOK, "Webhook subscriptions fetched successfully", models.WebhookSubscriptionsResponse{
+		Subscriptions: subs,
+		Total:         total,
+	}, nil)
+}
+
+// GetWebhookSubscription godoc
+// @Summary      Get a webhook subscription
+// @Description  Admin only: get a webhook subscription by ID
+// @Tags         webhooks
+// @Produce      json
+// @Param        id   path      int  true  "Subscription ID"
+// @Success      200  {object}  models.StandardResponse{data=models.WebhookSubscription}
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
+// @Router       /webhooks/{id} [get]
+// @Security     BearerAuth
+func (h *Handlers) GetWebhookSubscription(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	sub, err := h.Webhooks.GetByID(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	sub.Secret = ""
+
+	utils.Respond(c, http.StatusOK, "Webhook subscription fetched successfully", sub, nil)
+}
+
+// UpdateWebhookSubscription godoc
+// @Summary      Update a webhook subscription
+// @Description  Admin only: update the URL, event types, secret or active flag of a webhook subscription
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        id            path      int                                    true  "Subscription ID"
+// @Param        subscription  body      models.WebhookSubscriptionUpdateInput  true  "Webhook Subscription Update Input"
+// @Success      200  {object}  models.StandardResponse{data=models.WebhookSubscription}
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
+// @Router       /webhooks/{id} [put]
+// @Security     BearerAuth
+func (h *Handlers) UpdateWebhookSubscription(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var input models.WebhookSubscriptionUpdateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		_ = c.Error(apperrors.FromBinding(err))
+		return
+	}
+	if ok, invalid := input.Validate(); !ok {
+		_ = c.Error(apperrors.InvalidFields(invalid))
+		return
+	}
+
+	updated, err := h.Webhooks.Update(c.Request.Context(), id, &input)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	updated.Secret = ""
+
+	utils.Respond(c, http.StatusOK, "Webhook subscription updated successfully", updated, nil)
+}
+
+// DeleteWebhookSubscription godoc
+// @Summary      Delete a webhook subscription
+// @Description  Admin only: delete a webhook subscription; its delivery log is kept
+// @Tags         webhooks
+// @Produce      json
+// @Param        id   path      int  true  "Subscription ID"
+// @Success      200  {object}  models.StandardResponse
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
+// @Router       /webhooks/{id} [delete]
+// @Security     BearerAuth
+func (h *Handlers) DeleteWebhookSubscription(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := h.Webhooks.Delete(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	utils.Respond(c, http.StatusOK, "Webhook subscription deleted successfully", nil, nil)
+}
+
+// GetWebhookDeliveries godoc
+// @Summary      List webhook deliveries
+// @Description  Admin only: get the delivery log of a webhook subscription, newest first
+// @Tags         webhooks
+// @Produce      json
+// @Param        id      path      int     true   "Subscription ID"
+// @Param        status  query     string  false  "pending, succeeded, dead or skipped"
+// @Param        limit   query     int     false  "Limit"
+// @Param        offset  query     int     false  "Offset"
+// @Success      200  {object}  models.StandardResponse{data=models.WebhookDeliveriesResponse}
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Failure      500  {object}  models.Problem
+// @Router       /webhooks/{id}/deliveries [get]
+// @Security     BearerAuth
+func (h *Handlers) GetWebhookDeliveries(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	deliveries, total, err := h.Webhooks.Deliveries(c.Request.Context(), id, c.Query("status"), limit, offset)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	utils.Respond(c, http.StatusOK, "Webhook deliveries fetched successfully", models.WebhookDeliveriesResponse{
+		Deliveries: deliveries,
+		Total:      total,
+	}, nil)
+}
+
+// RetryWebhookDelivery godoc
+// @Summary      Retry a webhook delivery
+// @Description  Admin only: reset a (typically dead or skipped) delivery to pending so that it is attempted again
+// @Tags         webhooks
+// @Produce      json
+// @Param        id           path      int  true  "Subscription ID"
+// @Param        delivery_id  path      int  true  "Delivery ID"
+// @Success      200  {object}  models.StandardResponse{data=models.WebhookDelivery}
+// @Failure      400  {object}  models.Problem
+// @Failure      401  {object}  models.Problem
+// @Failure      404  {object}  models.Problem
+// @Router       /webhooks/{id}/deliveries/{delivery_id}/retry [post]
+// @Security     BearerAuth
+func (h *Handlers) RetryWebhookDelivery(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	deliveryID, err := paramID(c, "delivery_id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	delivery, err := h.Webhooks.RetryDelivery(c.Request.Context(), id, deliveryID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	utils.Respond(c, http.StatusOK, "Webhook delivery scheduled for retry", delivery, nil)
+}
@@ -0,0 +1,174 @@
+// Package importer parses CSV and JSON files for the bulk catalogue import
+package importer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/money"
+)
+
+// Supported file formats
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+// Kinds of rows that can be imported
+const (
+	KindRestaurants = "restaurants"
+	KindMenuItems   = "menu_items"
+)
+
+var (
+	restaurantColumns = []string{"name", "description", "address", "latitude", "longitude", "homepage", "region", "phone", "email"}
+	menuItemColumns   = []string{"restaurant_name", "name", "description", "price", "currency", "category", "is_available"}
+)
+
+// DetectFormat returns the file format from an explicit format value or,
+// if that is empty, from the file name extension.
+func DetectFormat(format, filename string) (string, error) {
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), ".")
+	}
+	switch strings.ToLower(format) {
+	case FormatCSV:
+		return FormatCSV, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	}
+	return "", fmt.Errorf("unsupported import format %q (use csv or json)", format)
+}
+
+// Parse reads rows of the given kind and format from r and appends them to data.
+// Cells that cannot be parsed are left empty so that row validation reports them.
+func Parse(kind, format string, r io.Reader, data *models.CatalogueImport) error {
+	switch {
+	case kind == KindRestaurants && format == FormatCSV:
+		rows, err := parseRestaurantsCSV(r)
+		if err != nil {
+			return err
+		}
+		data.Restaurants = append(data.Restaurants, rows...)
+	case kind == KindRestaurants && format == FormatJSON:
+		var rows []models.RestaurantInput
+		if err := json.NewDecoder(r).Decode(&rows); err != nil {
+			return fmt.Errorf("restaurants: invalid JSON: %w", err)
+		}
+		data.Restaurants = append(data.Restaurants, rows...)
+	case kind == KindMenuItems && format == FormatCSV:
+		rows, err := parseMenuItemsCSV(r)
+		if err != nil {
+			return err
+		}
+		data.MenuItems = append(data.MenuItems, rows...)
+	case kind == KindMenuItems && format == FormatJSON:
+		var rows []models.MenuItemImportRow
+		if err := json.NewDecoder(r).Decode(&rows); err != nil {
+			return fmt.Errorf("menu_items: invalid JSON: %w", err)
+		}
+		data.MenuItems = append(data.MenuItems, rows...)
+	default:
+		return fmt.Errorf("unsupported import kind %q or format %q", kind, format)
+	}
+	return nil
+}
+
+func parseRestaurantsCSV(r io.Reader) ([]models.RestaurantInput, error) {
+	records, index, err := readCSV(r, KindRestaurants, restaurantColumns)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]models.RestaurantInput, 0, len(records))
+	for _, rec := range records {
+		row := models.RestaurantInput{
+			Name:        cell(rec, index, "name"),
+			Description: cell(rec, index, "description"),
+			Address:     cell(rec, index, "address"),
+			Homepage:    cell(rec, index, "homepage"),
+			Region:      cell(rec, index, "region"),
+			Phone:       cell(rec, index, "phone"),
+			Email:       cell(rec, index, "email"),
+		}
+		lat, latErr := strconv.ParseFloat(cell(rec, index, "latitude"), 64)
+		lng, lngErr := strconv.ParseFloat(cell(rec, index, "longitude"), 64)
+		if latErr == nil && lngErr == nil {
+			row.Coordinate = []float64{lat, lng}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func parseMenuItemsCSV(r io.Reader) ([]models.MenuItemImportRow, error) {
+	records, index, err := readCSV(r, KindMenuItems, menuItemColumns)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]models.MenuItemImportRow, 0, len(records))
+	for _, rec := range records {
+		row := models.MenuItemImportRow{
+			RestaurantName: cell(rec, index, "restaurant_name"),
+			Name:           cell(rec, index, "name"),
+			Description:    cell(rec, index, "description"),
+			Currency:       cell(rec, index, "currency"),
+			Category:       cell(rec, index, "category"),
+		}
+		if price, err := money.Parse(cell(rec, index, "price")); err == nil {
+			row.Price = price
+		}
+		if v := cell(rec, index, "is_available"); v != "" {
+			if available, err := strconv.ParseBool(v); err == nil {
+				row.IsAvailable = &available
+			} else {
+				row.Unparsable = append(row.Unparsable, "is_available")
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// readCSV reads all records and maps the header names to column positions.
+// Only the first column of columns is required to be present in the header.
+func readCSV(r io.Reader, kind string, columns []string) ([][]string, map[string]int, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, fmt.Errorf("%s: empty CSV file", kind)
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("%s: invalid CSV header: %w", kind, err)
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	if _, ok := index[columns[0]]; !ok {
+		return nil, nil, fmt.Errorf("%s: CSV header must contain %q (known columns: %s)", kind, columns[0], strings.Join(columns, ", "))
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: invalid CSV: %w", kind, err)
+	}
+	return records, index, nil
+}
+
+func cell(record []string, index map[string]int, column string) string {
+	i, ok := index[column]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
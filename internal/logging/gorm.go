@@ -0,0 +1,104 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// GormLogger adapts GORM's logger to slog. Queries are logged with the
+// logger of their statement context, set with DB.WithContext; the
+// repositories run them with the request context, so they carry its request
+// ID. SQL is logged with placeholders only: bound values such as password
+// hashes are never logged.
+type GormLogger struct {
+	Level         gormlogger.LogLevel
+	SlowThreshold time.Duration
+}
+
+// NewGormLogger returns a GORM logger for the level name silent, error, warn or info
+func NewGormLogger(level string, slowThreshold time.Duration) *GormLogger {
+	return &GormLogger{Level: ParseGormLevel(level), SlowThreshold: slowThreshold}
+}
+
+// ParseGormLevel parses a GORM log level name, defaulting to warn
+func ParseGormLevel(name string) gormlogger.LogLevel {
+	switch strings.ToLower(name) {
+	case "silent":
+		return gormlogger.Silent
+	case "error":
+		return gormlogger.Error
+	case "info", "debug":
+		return gormlogger.Info
+	default:
+		return gormlogger.Warn
+	}
+}
+
+// LogMode returns a copy of the logger with the given level
+func (l *GormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	copied := *l
+	copied.Level = level
+	return &copied
+}
+
+func (l *GormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.Level >= gormlogger.Info {
+		FromContext(ctx).InfoContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l *GormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.Level >= gormlogger.Warn {
+		FromContext(ctx).WarnContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l *GormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.Level >= gormlogger.Error {
+		FromContext(ctx).ErrorContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+// Trace logs failed queries at error, slow queries at warn and all queries at info level
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.Level <= gormlogger.Silent {
+		return
+	}
+	elapsed := time.Since(begin)
+	logger := FromContext(ctx)
+	switch {
+	case err != nil && l.Level >= gormlogger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		sql, rows := fc()
+		logger.ErrorContext(ctx, "query failed", queryAttrs(sql, rows, elapsed, err)...)
+	case l.SlowThreshold > 0 && elapsed > l.SlowThreshold && l.Level >= gormlogger.Warn:
+		sql, rows := fc()
+		logger.WarnContext(ctx, "slow query", queryAttrs(sql, rows, elapsed, nil)...)
+	case l.Level >= gormlogger.Info:
+		sql, rows := fc()
+		logger.InfoContext(ctx, "query", queryAttrs(sql, rows, elapsed, nil)...)
+	}
+}
+
+// ParamsFilter drops the bound values, so that SQL is logged with placeholders
+func (l *GormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
+
+func queryAttrs(sql string, rows int64, elapsed time.Duration, err error) []any {
+	attrs := []any{
+		slog.String("sql", sql),
+		slog.Int64("rows", rows),
+		slog.Float64("duration_ms", float64(elapsed.Microseconds())/1000),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.Any("error", err))
+	}
+	return attrs
+}
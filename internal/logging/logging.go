@@ -0,0 +1,79 @@
+// Package logging configures structured JSON logging with log/slog. Every
+// request gets its own logger, stored in the request context, that carries
+// the request ID, route and (once authenticated) the user ID, so all lines
+// of a request can be correlated. Credentials are redacted before output.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Options configures the default logger
+type Options struct {
+	Level  string // debug, info, warn or error
+	Format string // json or text
+}
+
+// Setup installs a redacting slog logger as the default logger. Output of
+// the standard log package is routed through it as well.
+func Setup(opts Options) *slog.Logger {
+	logger := New(os.Stdout, opts)
+	slog.SetDefault(logger)
+	return logger
+}
+
+// New returns a redacting logger writing to w
+func New(w io.Writer, opts Options) *slog.Logger {
+	handlerOpts := &slog.HandlerOptions{
+		Level:       ParseLevel(opts.Level),
+		ReplaceAttr: redactAttr,
+	}
+	var handler slog.Handler
+	if strings.EqualFold(opts.Format, "text") {
+		handler = slog.NewTextHandler(w, handlerOpts)
+	} else {
+		handler = slog.NewJSONHandler(w, handlerOpts)
+	}
+	return slog.New(handler)
+}
+
+// ParseLevel parses a level name, defaulting to info
+func ParseLevel(name string) slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return slog.LevelInfo
+	}
+	return level
+}
+
+// Fatal logs msg at error level and exits, the slog counterpart of log.Fatal
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
+
+type loggerKey struct{}
+
+// WithLogger returns a copy of ctx carrying logger
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the request logger of ctx, or the default logger
+func FromContext(ctx context.Context) *slog.Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+			return logger
+		}
+	}
+	return slog.Default()
+}
+
+// With returns a copy of ctx whose logger has the additional attributes
+func With(ctx context.Context, args ...any) context.Context {
+	return WithLogger(ctx, FromContext(ctx).With(args...))
+}
@@ -0,0 +1,56 @@
+package logging
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// Redacted replaces the values of sensitive attributes
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys are attribute and header names whose values are never logged
+var sensitiveKeys = map[string]bool{
+	"authorization":       true,
+	"proxy-authorization": true,
+	"cookie":              true,
+	"set-cookie":          true,
+	"x-csrf-token":        true,
+	"password":            true,
+	"password_hash":       true,
+	"secret":              true,
+	"token":               true,
+	"access_token":        true,
+	"refresh_token":       true,
+}
+
+// IsSensitive reports whether values of the attribute or header key must be redacted
+func IsSensitive(key string) bool {
+	return sensitiveKeys[strings.ToLower(key)]
+}
+
+func redactAttr(groups []string, a slog.Attr) slog.Attr {
+	if IsSensitive(a.Key) {
+		return slog.String(a.Key, Redacted)
+	}
+	return a
+}
+
+// Headers returns a log value of h with sensitive headers redacted
+func Headers(h http.Header) slog.LogValuer {
+	return headers(h)
+}
+
+type headers http.Header
+
+func (h headers) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, len(h))
+	for name, values := range h {
+		value := strings.Join(values, ", ")
+		if IsSensitive(name) {
+			value = Redacted
+		}
+		attrs = append(attrs, slog.String(name, value))
+	}
+	return slog.GroupValue(attrs...)
+}
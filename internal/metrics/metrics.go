@@ -0,0 +1,92 @@
+// Package metrics defines the Prometheus metrics of the API and the handler
+// that exposes them. Metrics are registered on Registry rather than the
+// global default registry, so that only the collectors below are exported.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "lunch_menu"
+
+// Registry holds all metrics of the API
+var Registry = prometheus.NewRegistry()
+
+var (
+	// HTTPRequests counts handled requests by method, route template and status
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	// HTTPRequestDuration observes request latencies by method, route template and status
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests by method, route template and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// RateLimitRejections counts requests rejected by the rate limiter by tier and route template
+	RateLimitRejections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limit_rejections_total",
+		Help:      "Number of requests rejected by the rate limiter by tier and route template.",
+	}, []string{"tier", "route"})
+
+	// Logins counts login attempts by result (success or failure)
+	Logins = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "auth_logins_total",
+		Help:      "Number of login attempts by result.",
+	}, []string{"result"})
+
+	// TokenRenewals counts access token renewals with a refresh token by result (success or failure)
+	TokenRenewals = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "auth_token_renewals_total",
+		Help:      "Number of access token renewals by result.",
+	}, []string{"result"})
+
+	// ViewsDropped counts view events dropped because the queue of unwritten events was full
+	ViewsDropped = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "views_dropped_total",
+		Help:      "Number of view events dropped because too many were waiting to be written.",
+	})
+)
+
+// Result label values
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPRequestDuration,
+		RateLimitRejections,
+		Logins,
+		TokenRenewals,
+		ViewsDropped,
+	)
+}
+
+// RegisterDB exports the connection pool statistics of db
+func RegisterDB(db *sql.DB, name string) error {
+	return Registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// Handler serves the metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
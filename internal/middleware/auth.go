@@ -1,95 +1,109 @@
 package middleware
 
 import (
-	"net/http"
 	"strings"
 
-	"lunch_menu/internal/database"
-	"lunch_menu/internal/models"
+	"lunch_menu/internal/app"
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/metrics"
+	"lunch_menu/internal/tracing"
 	"lunch_menu/internal/utils"
 
 	"github.com/gin-gonic/gin"
-	"github.com/ulule/limiter/v3"
-	ginlimiter "github.com/ulule/limiter/v3/drivers/middleware/gin"
-	memory "github.com/ulule/limiter/v3/drivers/store/memory"
 )
 
 // AuthMiddleware checks for a valid JWT token in the Authorization header.
-func AuthMiddleware(c *gin.Context) {
-	authHeader := c.GetHeader("Authorization")
-	if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Missing or invalid Authorization header"})
-		return
-	}
-	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+// Revoked tokens are looked up in a.Tokens; expired ones are renewed with the
+// refresh token cookie.
+func AuthMiddleware(a *app.App) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
+			abortWithError(c, apperrors.Unauthorized("UNAUTHORIZED", "Missing or invalid Authorization header"))
+			return
+		}
+		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 
-	// Check if token is blacklisted
-	if blacklisted, err := database.IsTokenBlacklisted(tokenString); err == nil && blacklisted {
-		utils.Respond(c, http.StatusUnauthorized, "Unauthorized", nil, &models.ErrorResponse{
-			Error:   "TOKEN_REVOKED",
-			Message: "Token has been revoked",
-		})
-		c.Abort()
-		return
-	}
+		// Check if token is blacklisted
+		if blacklisted, err := a.Tokens.IsTokenBlacklisted(c.Request.Context(), tokenString); err == nil && blacklisted {
+			abortWithError(c, apperrors.Unauthorized("TOKEN_REVOKED", "Token has been revoked"))
+			return
+		}
 
-	claims, err := utils.ParseJWT(tokenString)
-	if err != nil {
-		if err.Error() == "token is expired" || strings.Contains(err.Error(), "expired") {
-			// Try to renew using refresh token from cookie
-			refreshToken, cookieErr := c.Cookie("refresh_token")
-			if cookieErr == nil && refreshToken != "" {
-				newAccessToken, renewErr := utils.RenewAccessToken(refreshToken, tokenString)
-				if renewErr == nil && newAccessToken != "" {
-					// Optionally set new access token as cookie or header
-					c.Header("X-New-Access-Token", newAccessToken)
-					// Optionally parse new token and set claims
-					newClaims, parseErr := utils.ParseJWT(newAccessToken)
-					if parseErr == nil {
-						c.Set("userClaims", newClaims)
-						c.Next()
-						return
+		claims, err := utils.ParseJWT(tokenString)
+		if err != nil {
+			if err.Error() == "token is expired" || strings.Contains(err.Error(), "expired") {
+				// Try to renew using refresh token from cookie
+				refreshToken, cookieErr := c.Cookie("refresh_token")
+				if cookieErr == nil && refreshToken != "" {
+					ctx, span := tracing.Start(c.Request.Context(), "auth.renew_access_token")
+					newAccessToken, renewErr := utils.RenewAccessToken(ctx, a.Tokens, a.Users, refreshToken, tokenString)
+					tracing.End(span, renewErr)
+					if renewErr == nil && newAccessToken != "" {
+						// Optionally set new access token as cookie or header
+						c.Header("X-New-Access-Token", newAccessToken)
+						// Optionally parse new token and set claims
+						newClaims, parseErr := utils.ParseJWT(newAccessToken)
+						if parseErr == nil {
+							metrics.TokenRenewals.WithLabelValues(metrics.ResultSuccess).Inc()
+							c.Set("userClaims", newClaims)
+							withUserLogger(c, newClaims)
+							c.Next()
+							return
+						}
 					}
+					metrics.TokenRenewals.WithLabelValues(metrics.ResultFailure).Inc()
 				}
 			}
+			abortWithError(c, apperrors.Unauthorized("INVALID_TOKEN", "Invalid or expired token"))
+			return
 		}
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired refresh token"})
-		return
+		// Access claims:
+		// userID := claims["user_id"]
+		// username := claims["username"]
+		// role := claims["role"]
+		// Store claims in context for use in handlers
+		c.Set("userClaims", claims)
+		withUserLogger(c, claims)
+		// refreshToken, err := c.Cookie("refresh_token")
+		// fmt.Printf("AuthMiddleware: refresh_token cookie=%q, err=%v\n", refreshToken, err)
+		c.Next()
+	}
+}
+
+// OptionalAuthMiddleware authenticates the request like AuthMiddleware when it
+// carries an Authorization header and lets anonymous requests through, for
+// endpoints such as /graphql that serve public reads and authenticated writes.
+func OptionalAuthMiddleware(a *app.App) gin.HandlerFunc {
+	auth := AuthMiddleware(a)
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+		auth(c)
 	}
-	// Access claims:
-	// userID := claims["user_id"]
-	// username := claims["username"]
-	// role := claims["role"]
-	// Store claims in context for use in handlers
-	c.Set("userClaims", claims)
-	// refreshToken, err := c.Cookie("refresh_token")
-	// fmt.Printf("AuthMiddleware: refresh_token cookie=%q, err=%v\n", refreshToken, err)
-	c.Next()
 }
 
-// AdminMiddleware checks if the user has admin role or will be used as role based authentication.
-// we are not using it currently but can be used in future if needed.
+// AdminMiddleware checks if the user has admin role, used for role based authentication.
+// It must run after AuthMiddleware, which stores the user claims in the context.
 func AdminMiddleware(c *gin.Context) {
 	claims, exists := c.Get("userClaims")
 	if !exists {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "No user claims found"})
+		abortWithError(c, apperrors.Forbidden("No user claims found"))
 		return
 	}
 	userClaims, ok := claims.(map[string]interface{})
 	if !ok || userClaims["role"] != "admin" {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+		abortWithError(c, apperrors.Forbidden("Admin access required"))
 		return
 	}
 	c.Next()
 }
 
-// RateLimitMiddleware returns a Gin middleware that limits requests per IP.
-// rateFormat example: "10-S" (10 requests per second), "100-M" (100 per minute)
-func RateLimitMiddleware(rateFormat string) gin.HandlerFunc {
-	rate, err := limiter.NewRateFromFormatted(rateFormat)
-	if err != nil {
-		panic("invalid rate format for rate limiter")
-	}
-	store := memory.NewStore()
-	return ginlimiter.NewMiddleware(limiter.New(store, rate))
+// abortWithError records err and renders it right away, so that the
+// middleware also works on routers without ErrorHandler
+func abortWithError(c *gin.Context, err *apperrors.Error) {
+	_ = c.Error(err)
+	WriteProblem(c, err)
 }
@@ -1,25 +1,75 @@
 package middleware
 
 import (
+	"strings"
+
+	"lunch_menu/internal/config"
+
+	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
-// CORSMiddleware sets CORS headers for API security and cross-origin support
-// it is only browser issue, such issues never happen in postman or curl as these are server to server requests
-// if credentials are involved, do not use "*" in Access-Control-Allow-Origin, instead specify the exact domain
-// for production, replace "*" with your frontend domain
-func CORSMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		c.Writer.Header().Set("Access-Control-Allow-Origin", "*") // Change "*" to your frontend domain in production
-		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS") // preflighted requests
+// CORS allows browsers on the configured origins to call the API. Origins are
+// matched exactly or, for patterns like "https://*.example.com", on any
+// subdomain; other cross-origin requests are rejected with 403. It only
+// affects browsers: curl, Postman and server-to-server requests send no
+// Origin header.
+func CORS(cfg config.CORSConfig) gin.HandlerFunc {
+	corsConfig := cors.Config{
+		AllowMethods:     cfg.AllowedMethods,
+		AllowHeaders:     cfg.AllowedHeaders,
+		ExposeHeaders:    cfg.ExposedHeaders,
+		AllowCredentials: cfg.AllowCredentials,
+		MaxAge:           cfg.MaxAge,
+	}
+	if len(cfg.AllowedOrigins) == 1 && cfg.AllowedOrigins[0] == "*" {
+		// Only valid without credentials, see config.Validate
+		corsConfig.AllowAllOrigins = true
+	} else {
+		corsConfig.AllowOriginFunc = OriginMatcher(cfg.AllowedOrigins)
+	}
+	return cors.New(corsConfig)
+}
 
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
-			return
+// OriginMatcher reports whether an origin matches one of the allowed origins
+// or wildcard subdomain patterns. Origins are compared case-insensitively.
+func OriginMatcher(allowed []string) func(origin string) bool {
+	exact := make(map[string]bool)
+	var wildcards []originPattern
+	for _, a := range allowed {
+		a = strings.ToLower(strings.TrimSuffix(a, "/"))
+		scheme, host, ok := strings.Cut(a, "://*.")
+		if !ok {
+			exact[a] = true
+			continue
+		}
+		wildcards = append(wildcards, originPattern{prefix: scheme + "://", suffix: "." + host})
+	}
+	return func(origin string) bool {
+		origin = strings.ToLower(origin)
+		if exact[origin] {
+			return true
+		}
+		for _, w := range wildcards {
+			if w.matches(origin) {
+				return true
+			}
 		}
+		return false
+	}
+}
+
+// originPattern matches "https://*.example.com": the scheme, at least one
+// subdomain label and the domain, including its port if any
+type originPattern struct {
+	prefix string // "https://"
+	suffix string // ".example.com"
+}
 
-		c.Next()
+func (p originPattern) matches(origin string) bool {
+	if !strings.HasPrefix(origin, p.prefix) || !strings.HasSuffix(origin, p.suffix) {
+		return false
 	}
+	sub := strings.TrimSuffix(strings.TrimPrefix(origin, p.prefix), p.suffix)
+	return sub != "" && !strings.ContainsAny(sub, "/:@") && !strings.HasPrefix(sub, ".") && !strings.HasSuffix(sub, ".")
 }
@@ -3,6 +3,8 @@ package middleware
 import (
 	"net/http"
 
+	"lunch_menu/internal/apperrors"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -20,7 +22,7 @@ func CSRFProtection() gin.HandlerFunc {
 			csrfToken := c.GetHeader("X-CSRF-Token")
 			sessionToken, err := c.Cookie("csrf_token")
 			if err != nil || csrfToken == "" || csrfToken != sessionToken {
-				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Invalid or missing CSRF token"})
+				abortWithError(c, apperrors.Forbidden("Invalid or missing CSRF token"))
 				return
 			}
 		}
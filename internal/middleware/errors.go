@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/logging"
+	"lunch_menu/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem details
+const ProblemContentType = "application/problem+json"
+
+// ErrorHandler renders the last error added with c.Error by a handler or
+// middleware as RFC 7807 problem details. Application errors keep their
+// status, code, message and field details; any other error becomes a 500
+// whose details are only logged. It must be registered before the routes.
+func ErrorHandler(c *gin.Context) {
+	c.Next()
+
+	if len(c.Errors) == 0 || c.Writer.Written() {
+		return
+	}
+	appErr := apperrors.As(c.Errors.Last().Err)
+	if appErr.Kind == apperrors.KindInternal {
+		logging.FromContext(c.Request.Context()).Error("internal error", slog.Any("error", appErr))
+	}
+	WriteProblem(c, appErr)
+}
+
+// WriteProblem writes err as problem details and aborts the request
+func WriteProblem(c *gin.Context, err *apperrors.Error) {
+	status := err.Kind.Status()
+	problem := models.Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   err.Message,
+		Instance: c.Request.URL.Path,
+		Code:     err.Code,
+		Errors:   err.Fields,
+	}
+	c.Header("Content-Type", ProblemContentType)
+	c.AbortWithStatusJSON(status, problem)
+}
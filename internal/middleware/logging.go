@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"log/slog"
+	"regexp"
+	"runtime/debug"
+	"time"
+
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/logging"
+	"lunch_menu/internal/tracing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader carries the correlation ID of a request
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID limits accepted client IDs to a safe length and character set
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID accepts the client's X-Request-ID or generates one, echoes it in
+// the response and stores a logger carrying it and the route in the request context.
+func RequestID(c *gin.Context) {
+	id := c.GetHeader(RequestIDHeader)
+	if !validRequestID.MatchString(id) {
+		id = uuid.NewString()
+	}
+	c.Set("requestID", id)
+	c.Header(RequestIDHeader, id)
+
+	route := c.FullPath()
+	if route == "" {
+		route = "unmatched"
+	}
+	logger := logging.FromContext(c.Request.Context()).With(
+		slog.String("request_id", id),
+		slog.String("method", c.Request.Method),
+		slog.String("route", route),
+	)
+	if traceID := tracing.TraceID(c.Request.Context()); traceID != "" {
+		logger = logger.With(slog.String("trace_id", traceID))
+	}
+	c.Request = c.Request.WithContext(logging.WithLogger(c.Request.Context(), logger))
+	c.Next()
+}
+
+// RequestLogger logs one line per request with the request logger, replacing
+// gin's text logger. It must run after RequestID.
+func RequestLogger(c *gin.Context) {
+	start := time.Now()
+	c.Next()
+
+	status := c.Writer.Status()
+	level := slog.LevelInfo
+	switch {
+	case status >= 500:
+		level = slog.LevelError
+	case status >= 400:
+		level = slog.LevelWarn
+	}
+	attrs := []slog.Attr{
+		slog.String("path", c.Request.URL.Path),
+		slog.Int("status", status),
+		slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+		slog.Int("bytes", c.Writer.Size()),
+		slog.String("client_ip", c.ClientIP()),
+		slog.String("user_agent", c.Request.UserAgent()),
+	}
+	if len(c.Errors) > 0 {
+		attrs = append(attrs, slog.String("error", c.Errors.Last().Error()))
+	}
+	logger := logging.FromContext(c.Request.Context())
+	if logger.Enabled(c.Request.Context(), slog.LevelDebug) {
+		attrs = append(attrs, slog.Any("headers", logging.Headers(c.Request.Header)))
+	}
+	logger.LogAttrs(c.Request.Context(), level, "request", attrs...)
+}
+
+// Recovery turns a panic into a 500 problem response and logs it with the request logger
+func Recovery(c *gin.Context, recovered any) {
+	logging.FromContext(c.Request.Context()).Error("panic recovered",
+		slog.Any("panic", recovered),
+		slog.String("stack", string(debug.Stack())),
+	)
+	WriteProblem(c, apperrors.Internal("An unexpected error occurred", nil))
+}
+
+// withUserLogger adds the authenticated user to the request logger
+func withUserLogger(c *gin.Context, claims map[string]interface{}) {
+	ctx := logging.With(c.Request.Context(), slog.Any("user_id", claims["user_id"]))
+	c.Request = c.Request.WithContext(ctx)
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"strconv"
+	"time"
+
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics records the count and latency of every request, labelled by route
+// template (not the raw path, to keep label cardinality bounded) and status.
+func Metrics(c *gin.Context) {
+	start := time.Now()
+	c.Next()
+
+	route := c.FullPath()
+	if route == "" {
+		route = "unmatched"
+	}
+	status := strconv.Itoa(c.Writer.Status())
+	metrics.HTTPRequests.WithLabelValues(c.Request.Method, route, status).Inc()
+	metrics.HTTPRequestDuration.WithLabelValues(c.Request.Method, route, status).Observe(time.Since(start).Seconds())
+}
+
+// MetricsAuth protects the metrics endpoint with a static bearer token; an
+// empty token leaves it open, e.g. when it is served on an internal admin port.
+func MetricsAuth(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			c.Next()
+			return
+		}
+		got := c.GetHeader("Authorization")
+		if subtle.ConstantTimeCompare([]byte(got), []byte("Bearer "+token)) != 1 {
+			abortWithError(c, apperrors.Unauthorized("UNAUTHORIZED", "Missing or invalid metrics token"))
+			return
+		}
+		c.Next()
+	}
+}
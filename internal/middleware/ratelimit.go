@@ -0,0 +1,185 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/config"
+	"lunch_menu/internal/logging"
+	"lunch_menu/internal/metrics"
+	"lunch_menu/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/ulule/limiter/v3"
+	memory "github.com/ulule/limiter/v3/drivers/store/memory"
+	sredis "github.com/ulule/limiter/v3/drivers/store/redis"
+)
+
+// Rate limit tiers, applied with RateLimiter.Limit
+const (
+	// TierAPI limits all requests: anonymous clients by IP (rate_limit.rate),
+	// authenticated users by user ID (rate_limit.user)
+	TierAPI = "api"
+	// TierAuth limits login and registration attempts by IP (rate_limit.auth)
+	TierAuth = "auth"
+	// TierWrite limits admin writes by user ID (rate_limit.write); it must run
+	// after AuthMiddleware
+	TierWrite = "write"
+
+	// tierUser is the TierAPI budget of authenticated users
+	tierUser = "user"
+)
+
+// Response headers of the IETF RateLimit header fields draft. Requests
+// limited by several tiers report the tier with the fewest remaining requests.
+const (
+	RateLimitLimitHeader     = "RateLimit-Limit"
+	RateLimitRemainingHeader = "RateLimit-Remaining"
+	RateLimitResetHeader     = "RateLimit-Reset" // seconds until the window resets
+	RateLimitPolicyHeader    = "RateLimit-Policy"
+)
+
+// rateLimitKeyPrefix namespaces the counters in a shared Redis
+const rateLimitKeyPrefix = "lunch_menu:ratelimit"
+
+// RateLimiter enforces the request budgets of config.RateLimitConfig. With
+// the redis store the budgets are shared by all replicas, with the memory
+// store each replica counts on its own.
+type RateLimiter struct {
+	limiters map[string]*limiter.Limiter
+	user     *limiter.Limiter // TierAPI limiter for authenticated users
+	client   *redis.Client
+}
+
+// NewRateLimiter creates the limiters of every tier on the configured store
+func NewRateLimiter(cfg config.RateLimitConfig) (*RateLimiter, error) {
+	rl := &RateLimiter{limiters: make(map[string]*limiter.Limiter)}
+
+	var store limiter.Store
+	switch cfg.Store {
+	case "redis":
+		opts, err := redis.ParseURL(cfg.RedisURL)
+		if err != nil {
+			return nil, fmt.Errorf("rate limiter: invalid redis URL: %w", err)
+		}
+		rl.client = redis.NewClient(opts)
+		store, err = sredis.NewStoreWithOptions(rl.client, limiter.StoreOptions{
+			Prefix:   rateLimitKeyPrefix,
+			MaxRetry: limiter.DefaultMaxRetry,
+		})
+		if err != nil {
+			_ = rl.client.Close()
+			return nil, fmt.Errorf("rate limiter: redis: %w", err)
+		}
+	case "memory", "":
+		store = memory.NewStoreWithOptions(limiter.StoreOptions{
+			Prefix:          rateLimitKeyPrefix,
+			CleanUpInterval: limiter.DefaultCleanUpInterval,
+		})
+	default:
+		return nil, fmt.Errorf("rate limiter: unknown store %q", cfg.Store)
+	}
+
+	for tier, format := range map[string]string{
+		TierAPI:   cfg.Rate,
+		TierAuth:  cfg.Auth,
+		TierWrite: cfg.Write,
+		tierUser:  cfg.User,
+	} {
+		rate, err := limiter.NewRateFromFormatted(format)
+		if err != nil {
+			rl.Close()
+			return nil, fmt.Errorf("rate limiter: invalid %s rate %q: %w", tier, format, err)
+		}
+		rl.limiters[tier] = limiter.New(store, rate)
+	}
+	rl.user = rl.limiters[tierUser]
+	delete(rl.limiters, tierUser)
+	return rl, nil
+}
+
+// Close releases the Redis connection, if any
+func (rl *RateLimiter) Close() {
+	if rl.client != nil {
+		_ = rl.client.Close()
+	}
+}
+
+// Limit returns a middleware counting requests against the budget of tier.
+// Requests over the budget are rejected with 429 and Retry-After. If the
+// store fails, e.g. Redis is down, requests are let through and the error is
+// logged, so the limiter never takes the API down.
+func (rl *RateLimiter) Limit(tier string) gin.HandlerFunc {
+	lim, ok := rl.limiters[tier]
+	if !ok {
+		panic("rate limiter: unknown tier " + tier)
+	}
+	return func(c *gin.Context) {
+		l, key := lim, tier+":ip:"+c.ClientIP()
+		if userID, ok := rateLimitUser(c, tier); ok {
+			key = tier + ":user:" + userID
+			if tier == TierAPI {
+				l = rl.user
+			}
+		}
+
+		ctx, err := l.Get(c.Request.Context(), key)
+		if err != nil {
+			logging.FromContext(c.Request.Context()).Warn("Rate limiter unavailable, request not limited",
+				slog.String("tier", tier), slog.Any("error", err))
+			c.Next()
+			return
+		}
+		setRateLimitHeaders(c, l.Rate, ctx)
+
+		if ctx.Reached {
+			route := c.FullPath()
+			if route == "" {
+				route = "unmatched"
+			}
+			metrics.RateLimitRejections.WithLabelValues(tier, route).Inc()
+			c.Header("Retry-After", c.Writer.Header().Get(RateLimitResetHeader))
+			abortWithError(c, apperrors.TooManyRequests("Rate limit exceeded, retry later"))
+			return
+		}
+		c.Next()
+	}
+}
+
+// rateLimitUser returns the ID of the authenticated user: from the claims
+// set by AuthMiddleware or, for TierAPI which runs before it, from a valid
+// bearer token. Invalid tokens count against the client's IP.
+func rateLimitUser(c *gin.Context, tier string) (string, bool) {
+	var claims map[string]interface{}
+	if v, ok := c.Get("userClaims"); ok {
+		claims, _ = v.(map[string]interface{})
+	} else if tier == TierAPI {
+		if token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok {
+			claims, _ = utils.ParseJWT(token)
+		}
+	}
+	userID, ok := claims["user_id"].(float64)
+	if !ok {
+		return "", false
+	}
+	return strconv.FormatInt(int64(userID), 10), true
+}
+
+// setRateLimitHeaders reports the budget of the tier, unless an earlier tier
+// of the request has fewer requests remaining
+func setRateLimitHeaders(c *gin.Context, rate limiter.Rate, ctx limiter.Context) {
+	h := c.Writer.Header()
+	if prev, err := strconv.ParseInt(h.Get(RateLimitRemainingHeader), 10, 64); err == nil && prev < ctx.Remaining {
+		return
+	}
+	reset := time.Until(time.Unix(ctx.Reset, 0))
+	h.Set(RateLimitLimitHeader, strconv.FormatInt(ctx.Limit, 10))
+	h.Set(RateLimitRemainingHeader, strconv.FormatInt(ctx.Remaining, 10))
+	h.Set(RateLimitResetHeader, strconv.Itoa(max(int((reset+time.Second-1)/time.Second), 0)))
+	h.Set(RateLimitPolicyHeader, fmt.Sprintf("%d;w=%d", rate.Limit, int(rate.Period/time.Second)))
+}
@@ -0,0 +1,200 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Favorite targets
+const (
+	FavoriteRestaurant = "restaurant"
+	FavoriteMenuItem   = "menu_item"
+)
+
+// Preference limits
+const (
+	MaxDietaryPreferences   = 20
+	MaxPreferenceNameLength = 100 // characters of a home region or a dietary preference
+	MaxFeedLimit            = 100 // feed items per page
+)
+
+// Favorite is a restaurant or menu item starred by a user
+type Favorite struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	UserID     uint      `gorm:"uniqueIndex:idx_favorites_user_target,priority:1;not null" json:"user_id"`
+	TargetType string    `gorm:"size:16;uniqueIndex:idx_favorites_user_target,priority:2;not null" json:"target_type" example:"restaurant"`
+	TargetID   uint      `gorm:"uniqueIndex:idx_favorites_user_target,priority:3;not null" json:"target_id"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName overrides the table name used by GORM
+func (Favorite) TableName() string {
+	return "favorites"
+}
+
+// Favorites are the favorite restaurants and menu items of a user that are
+// still active and available
+type Favorites struct {
+	Restaurants []Restaurant `json:"restaurants"`
+	MenuItems   []MenuItem   `json:"menu_items"`
+}
+
+// UserPreferences are the home region and dietary preferences of a user. The
+// dietary preferences are menu item categories, e.g. "Vegetarian"; both are
+// compared case-insensitively.
+type UserPreferences struct {
+	HomeRegion string   `json:"home_region" example:"Solna"`
+	Dietary    []string `json:"dietary" example:"Vegetarian"`
+}
+
+// Preferences returns the preferences stored on the user
+func (u *User) Preferences() UserPreferences {
+	prefs := UserPreferences{HomeRegion: u.HomeRegion, Dietary: []string{}}
+	if u.DietaryPreferences != "" {
+		prefs.Dietary = strings.Split(u.DietaryPreferences, ",")
+	}
+	return prefs
+}
+
+// UserPreferencesInput changes the home region or the dietary preferences of
+// a user; an empty home region or list clears them
+type UserPreferencesInput struct {
+	HomeRegion *string   `json:"home_region,omitempty" example:"Solna"`
+	Dietary    *[]string `json:"dietary,omitempty"`
+}
+
+// Validate checks the fields that are set on the UserPreferencesInput
+func (input *UserPreferencesInput) Validate() (bool, []string) {
+	var invalidFields []string
+	if input.HomeRegion != nil && utf8.RuneCountInString(strings.TrimSpace(*input.HomeRegion)) > MaxPreferenceNameLength {
+		invalidFields = append(invalidFields, "home_region")
+	}
+	if input.Dietary != nil && !validDietary(*input.Dietary) {
+		invalidFields = append(invalidFields, "dietary")
+	}
+	return len(invalidFields) == 0, invalidFields
+}
+
+// validDietary reports whether dietary preferences can be stored: not too
+// many, none blank, too long or containing the comma they are joined with
+func validDietary(dietary []string) bool {
+	if len(dietary) > MaxDietaryPreferences {
+		return false
+	}
+	for _, d := range dietary {
+		d = strings.TrimSpace(d)
+		if d == "" || strings.Contains(d, ",") || utf8.RuneCountInString(d) > MaxPreferenceNameLength {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply copies the fields that are set on the UserPreferencesInput to user,
+// trimmed and without duplicate dietary preferences
+func (input *UserPreferencesInput) Apply(user *User) {
+	if input.HomeRegion != nil {
+		user.HomeRegion = strings.TrimSpace(*input.HomeRegion)
+	}
+	if input.Dietary != nil {
+		var dietary []string
+		for _, d := range *input.Dietary {
+			d = strings.TrimSpace(d)
+			if !slices.ContainsFunc(dietary, func(other string) bool { return strings.EqualFold(other, d) }) {
+				dietary = append(dietary, d)
+			}
+		}
+		user.DietaryPreferences = strings.Join(dietary, ",")
+	}
+}
+
+// Reasons a feed item is ranked before the others, from the strongest
+const (
+	FeedFavoriteMenuItem   = "favorite_menu_item"
+	FeedFavoriteRestaurant = "favorite_restaurant"
+	FeedDietary            = "dietary"
+	FeedHomeRegion         = "home_region"
+)
+
+// FeedWeights weighs the reasons of a feed item so that a stronger reason
+// outranks any combination of weaker ones
+var FeedWeights = map[string]int{
+	FeedFavoriteMenuItem:   8,
+	FeedFavoriteRestaurant: 4,
+	FeedDietary:            2,
+	FeedHomeRegion:         1,
+}
+
+// FeedItem is an available menu item in a user's feed
+type FeedItem struct {
+	MenuItem
+	RestaurantName string   `json:"restaurant_name"`
+	Region         string   `json:"region"`
+	Reasons        []string `gorm:"-" json:"reasons"` // why the item is ranked first, empty for the others
+}
+
+// FeedResponse is a page of a user's feed
+type FeedResponse struct {
+	Date  string     `json:"date" example:"2026-10-19"`
+	Items []FeedItem `json:"items"`
+	Total int64      `json:"total"`
+}
+
+// FeedQuery is what a user's feed ranks first: the favorite menu items, then
+// the items of favorite restaurants, those in a dietary preference and those
+// in the home region. Items matching several of these come before those
+// matching only the strongest of them.
+type FeedQuery struct {
+	FavoriteMenuItems   []uint
+	FavoriteRestaurants []uint
+	Dietary             []string // lower case
+	HomeRegion          string   // lower case, "" for none
+}
+
+// NewFeedQuery returns the feed query of a user with preferences prefs and
+// favorites
+func NewFeedQuery(prefs UserPreferences, favorites []Favorite) FeedQuery {
+	q := FeedQuery{HomeRegion: strings.ToLower(prefs.HomeRegion)}
+	for _, f := range favorites {
+		switch f.TargetType {
+		case FavoriteMenuItem:
+			q.FavoriteMenuItems = append(q.FavoriteMenuItems, f.TargetID)
+		case FavoriteRestaurant:
+			q.FavoriteRestaurants = append(q.FavoriteRestaurants, f.TargetID)
+		}
+	}
+	for _, d := range prefs.Dietary {
+		q.Dietary = append(q.Dietary, strings.ToLower(d))
+	}
+	return q
+}
+
+// Reasons returns why the feed ranks an item of a restaurant in region first,
+// strongest first
+func (q FeedQuery) Reasons(item *MenuItem, region string) []string {
+	reasons := []string{}
+	if slices.Contains(q.FavoriteMenuItems, item.ID) {
+		reasons = append(reasons, FeedFavoriteMenuItem)
+	}
+	if slices.Contains(q.FavoriteRestaurants, item.RestaurantID) {
+		reasons = append(reasons, FeedFavoriteRestaurant)
+	}
+	if slices.Contains(q.Dietary, strings.ToLower(item.Category)) {
+		reasons = append(reasons, FeedDietary)
+	}
+	if q.HomeRegion != "" && q.HomeRegion == strings.ToLower(region) {
+		reasons = append(reasons, FeedHomeRegion)
+	}
+	return reasons
+}
+
+// FeedScore returns the rank of a feed item with reasons, higher first
+func FeedScore(reasons []string) int {
+	score := 0
+	for _, r := range reasons {
+		score += FeedWeights[r]
+	}
+	return score
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"slices"
+
+	"lunch_menu/internal/money"
+)
+
+// MenuItemImportRow represents a menu item row in a bulk import file.
+// The owning restaurant is referenced by name so that restaurants and
+// their menus can be imported together before any IDs exist.
+type MenuItemImportRow struct {
+	RestaurantName string       `json:"restaurant_name"`
+	Name           string       `json:"name"`
+	Description    string       `json:"description"`
+	Price          money.Amount `json:"price" swaggertype:"number"`
+	Currency       string       `json:"currency"` // defaults to pricing.currency
+	Category       string       `json:"category"`
+	IsAvailable    *bool        `json:"is_available"` // pointer to allow "not set"
+	Unparsable     []string     `json:"-"`            // CSV columns whose values could not be parsed
+}
+
+// Validate checks the MenuItemImportRow for required fields and correct values.
+func (input *MenuItemImportRow) Validate() (bool, []string) {
+	invalidFields := slices.Clone(input.Unparsable)
+	if input.RestaurantName == "" {
+		invalidFields = append(invalidFields, "restaurant_name")
+	}
+	if input.Name == "" {
+		invalidFields = append(invalidFields, "name")
+	}
+	if input.Price <= 0 || input.Price > MaxPrice {
+		invalidFields = append(invalidFields, "price")
+	}
+	if input.Currency != "" && !money.IsCurrency(input.Currency) {
+		invalidFields = append(invalidFields, "currency")
+	}
+	return len(invalidFields) == 0, invalidFields
+}
+
+// CatalogueImport is the JSON document accepted by the bulk import
+type CatalogueImport struct {
+	Restaurants []RestaurantInput   `json:"restaurants"`
+	MenuItems   []MenuItemImportRow `json:"menu_items"`
+}
+
+// ImportRowError describes why a single row of an import was rejected.
+// Row is 1-based and counts data rows only (CSV header excluded).
+type ImportRowError struct {
+	Kind    string   `json:"kind"` // "restaurants" or "menu_items"
+	Row     int      `json:"row"`
+	Fields  []string `json:"fields,omitempty"`
+	Message string   `json:"message"`
+}
+
+// ImportCounts holds the number of created and updated rows of one kind
+type ImportCounts struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+}
+
+// ImportReport is the result of a bulk import or dry run
+type ImportReport struct {
+	DryRun      bool             `json:"dry_run"`
+	Applied     bool             `json:"applied"`
+	Restaurants ImportCounts     `json:"restaurants"`
+	MenuItems   ImportCounts     `json:"menu_items"`
+	Errors      []ImportRowError `json:"errors"`
+}
@@ -1,18 +1,125 @@
 package models
 
-import "time"
+import (
+	"regexp"
+	"time"
+
+	"lunch_menu/internal/config"
+	"lunch_menu/internal/money"
+)
+
+// MaxPrice is the largest price the DECIMAL(10,2) price column can hold
+const MaxPrice money.Amount = 99_999_999_99
 
 // MenuItem represents a menu item
 type MenuItem struct {
-	ID           uint      `gorm:"primaryKey" json:"id"`
-	RestaurantID uint      `gorm:"index;not null" json:"restaurant_id"`
-	Name         string    `gorm:"not null" json:"name"`
-	Description  string    `json:"description"`
-	Price        float64   `gorm:"not null" json:"price"`
-	Category     string    `json:"category"`
-	IsAvailable  bool      `gorm:"default:true" json:"is_available"`
-	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ID           uint         `gorm:"primaryKey" json:"id"`
+	RestaurantID uint         `gorm:"index;not null" json:"restaurant_id"`
+	Name         string       `gorm:"not null" json:"name"`
+	Description  string       `json:"description"`
+	Price        money.Amount `gorm:"type:decimal(10,2);not null" json:"price" swaggertype:"number" example:"129.00"` // including VAT
+	Currency     string       `gorm:"size:3;not null;default:SEK" json:"currency" example:"SEK"`
+	Category     string       `json:"category"`
+	IsAvailable  bool         `gorm:"default:true" json:"is_available"`
+	CreatedAt    time.Time    `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time    `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Prices are the named price variants of the item, e.g. the student
+	// price; the default variant's price is also Price
+	Prices []MenuItemPrice `gorm:"foreignKey:MenuItemID;constraint:OnDelete:CASCADE" json:"prices,omitempty"`
+	// PriceTier is the variant whose price is in Price, set by ApplyPriceTier
+	// when a client asks for a price tier
+	PriceTier string `gorm:"-" json:"price_tier,omitempty" example:"student"`
+	// VAT is the VAT breakdown of the price, set by SetVAT for API responses
+	VAT *money.VAT `gorm:"-" json:"vat,omitempty"`
+	// Rating summarizes the visible reviews of the item, set for API responses
+	Rating *RatingSummary `gorm:"-" json:"rating,omitempty"`
+}
+
+// MenuItemPrice is a named price variant of a menu item, such as the student,
+// employee or takeaway price
+type MenuItemPrice struct {
+	ID         uint         `gorm:"primaryKey" json:"-"`
+	MenuItemID uint         `gorm:"uniqueIndex:idx_menu_item_prices_tier;not null" json:"-"`
+	Tier       string       `gorm:"uniqueIndex:idx_menu_item_prices_tier;size:32;not null" json:"tier" example:"student"`
+	Price      money.Amount `gorm:"type:decimal(10,2);not null" json:"price" swaggertype:"number" example:"99.00"` // including VAT
+	IsDefault  bool         `gorm:"not null;default:false" json:"is_default"`
+}
+
+// TableName overrides the table name used by GORM
+func (MenuItemPrice) TableName() string {
+	return "menu_item_prices"
+}
+
+// priceTierFormat is a price tier name, e.g. "student" or "takeaway"
+var priceTierFormat = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+
+// IsPriceTier reports whether tier is a well-formed price tier name
+func IsPriceTier(tier string) bool {
+	return priceTierFormat.MatchString(tier)
+}
+
+// DefaultPrice returns the price of the default variant of prices, 0 if there
+// is none
+func DefaultPrice(prices []MenuItemPrice) money.Amount {
+	for _, p := range prices {
+		if p.IsDefault {
+			return p.Price
+		}
+	}
+	return 0
+}
+
+// validPrices reports whether prices are valid variants: well-formed, unique
+// tiers, prices in range and exactly one default
+func validPrices(prices []MenuItemPrice) bool {
+	tiers := make(map[string]bool, len(prices))
+	defaults := 0
+	for _, p := range prices {
+		if !IsPriceTier(p.Tier) || tiers[p.Tier] || p.Price <= 0 || p.Price > MaxPrice {
+			return false
+		}
+		tiers[p.Tier] = true
+		if p.IsDefault {
+			defaults++
+		}
+	}
+	return defaults == 1
+}
+
+// ApplyPriceTier sets Price to the price of the variant tier, if the item has
+// one; items without it keep their default price. An empty tier is a no-op.
+func (m *MenuItem) ApplyPriceTier(tier string) {
+	if tier == "" {
+		return
+	}
+	for _, p := range m.Prices {
+		if p.Tier == tier {
+			m.Price, m.PriceTier = p.Price, tier
+			return
+		}
+	}
+}
+
+// ApplyPriceTier applies tier to each of items
+func ApplyPriceTier(items []MenuItem, tier string) {
+	for i := range items {
+		items[i].ApplyPriceTier(tier)
+	}
+}
+
+// SetVAT sets the VAT breakdown of the price with the configured VAT rate of
+// the item's category
+func (m *MenuItem) SetVAT() {
+	vat := m.Price.SplitVAT(config.AppConfig.Pricing.VATRateFor(m.Category))
+	m.VAT = &vat
+}
+
+// SetVAT sets the VAT breakdown of each of items
+func SetVAT(items []MenuItem) {
+	for i := range items {
+		items[i].SetVAT()
+	}
 }
 
 // TableName overrides the table name used by GORM (optional)
@@ -20,21 +127,138 @@ func (MenuItem) TableName() string {
 	return "menu_items"
 }
 
+// MenuItemWithRestaurant is a menu item joined with the name of its restaurant
+type MenuItemWithRestaurant struct {
+	MenuItem
+	RestaurantName string `json:"restaurant_name"`
+}
+
 // MenuItemInput represents the input for creating or updating a menu item
 type MenuItemInput struct {
-	RestaurantID uint    `json:"restaurant_id" binding:"required"`
-	Name         string  `json:"name" binding:"required"`
-	Description  string  `json:"description"`
-	Price        float64 `json:"price" binding:"required,gt=0"`
-	Category     string  `json:"category"`
-	IsAvailable  *bool   `json:"is_available"` // pointer to allow "not set"
+	RestaurantID uint         `json:"restaurant_id" binding:"required"`
+	Name         string       `json:"name" binding:"required"`
+	Description  string       `json:"description"`
+	Price        money.Amount `json:"price" binding:"omitempty,gt=0" swaggertype:"number" example:"129.00"` // at most 2 decimals; defaults to the default variant's price
+	Currency     string       `json:"currency" example:"SEK"`                                               // defaults to pricing.currency
+	Category     string       `json:"category"`
+	IsAvailable  *bool        `json:"is_available"` // pointer to allow "not set"
+	// Prices are optional price variants, one of them the default
+	Prices []MenuItemPrice `json:"prices"`
 }
 
 type MenuItemUpdateInput struct {
-	RestaurantID *uint    `json:"restaurant_id,omitempty"`
-	Name         *string  `json:"name,omitempty"`
-	Description  *string  `json:"description,omitempty"`
-	Price        *float64 `json:"price,omitempty"`
-	Category     *string  `json:"category,omitempty"`
-	IsAvailable  *bool    `json:"is_available,omitempty"`
+	RestaurantID *uint         `json:"restaurant_id,omitempty"`
+	Name         *string       `json:"name,omitempty"`
+	Description  *string       `json:"description,omitempty"`
+	Price        *money.Amount `json:"price,omitempty" swaggertype:"number"`
+	Currency     *string       `json:"currency,omitempty"`
+	Category     *string       `json:"category,omitempty"`
+	IsAvailable  *bool         `json:"is_available,omitempty"`
+	// Prices replace all price variants of the item; an empty list removes them
+	Prices *[]MenuItemPrice `json:"prices,omitempty"`
+}
+
+// PriceCurrency returns the currency of the input's price, the configured
+// default if none is given
+func (input *MenuItemInput) PriceCurrency() string {
+	if input.Currency == "" {
+		return config.AppConfig.Pricing.Currency
+	}
+	return input.Currency
+}
+
+// DefaultPrice returns the price of the input, that of the default variant if
+// only variants are given
+func (input *MenuItemInput) DefaultPrice() money.Amount {
+	if input.Price == 0 {
+		return DefaultPrice(input.Prices)
+	}
+	return input.Price
+}
+
+// Validate checks the MenuItemInput for required fields and correct values.
+func (input *MenuItemInput) Validate() (bool, []string) {
+	var invalidFields []string
+	if input.RestaurantID == 0 {
+		invalidFields = append(invalidFields, "restaurant_id")
+	}
+	if input.Name == "" {
+		invalidFields = append(invalidFields, "name")
+	}
+	if len(input.Prices) > 0 && !validPrices(input.Prices) {
+		invalidFields = append(invalidFields, "prices")
+	} else if price := input.DefaultPrice(); price <= 0 || price > MaxPrice ||
+		len(input.Prices) > 0 && price != DefaultPrice(input.Prices) {
+		invalidFields = append(invalidFields, "price")
+	}
+	if input.Currency != "" && !money.IsCurrency(input.Currency) {
+		invalidFields = append(invalidFields, "currency")
+	}
+	return len(invalidFields) == 0, invalidFields
+}
+
+// Validate checks the fields that are set on the MenuItemUpdateInput.
+func (input *MenuItemUpdateInput) Validate() (bool, []string) {
+	var invalidFields []string
+	if input.RestaurantID != nil && *input.RestaurantID == 0 {
+		invalidFields = append(invalidFields, "restaurant_id")
+	}
+	if input.Name != nil && *input.Name == "" {
+		invalidFields = append(invalidFields, "name")
+	}
+	if input.Price != nil && (*input.Price <= 0 || *input.Price > MaxPrice) {
+		invalidFields = append(invalidFields, "price")
+	}
+	if input.Currency != nil && !money.IsCurrency(*input.Currency) {
+		invalidFields = append(invalidFields, "currency")
+	}
+	if input.Prices != nil && len(*input.Prices) > 0 {
+		if !validPrices(*input.Prices) {
+			invalidFields = append(invalidFields, "prices")
+		} else if input.Price != nil && *input.Price != DefaultPrice(*input.Prices) {
+			invalidFields = append(invalidFields, "price")
+		}
+	}
+	return len(invalidFields) == 0, invalidFields
+}
+
+// Apply copies the fields that are set on the MenuItemUpdateInput to item.
+// The caller checks that a new RestaurantID exists. A new price of an item
+// with variants is the price of its default variant.
+func (input *MenuItemUpdateInput) Apply(item *MenuItem) {
+	if input.RestaurantID != nil {
+		item.RestaurantID = *input.RestaurantID
+	}
+	if input.Name != nil {
+		item.Name = *input.Name
+	}
+	if input.Description != nil {
+		item.Description = *input.Description
+	}
+	if input.Prices != nil {
+		item.Prices = make([]MenuItemPrice, len(*input.Prices))
+		for i, p := range *input.Prices {
+			item.Prices[i] = MenuItemPrice{MenuItemID: item.ID, Tier: p.Tier, Price: p.Price, IsDefault: p.IsDefault}
+		}
+		if len(item.Prices) > 0 {
+			item.Price = DefaultPrice(item.Prices)
+		}
+	}
+	if input.Price != nil {
+		item.Price = *input.Price
+		for i := range item.Prices {
+			if item.Prices[i].IsDefault {
+				item.Prices[i].Price = *input.Price
+			}
+		}
+	}
+	if input.Currency != nil {
+		item.Currency = *input.Currency
+	}
+	if input.Category != nil {
+		item.Category = *input.Category
+	}
+	if input.IsAvailable != nil {
+		item.IsAvailable = *input.IsAvailable
+	}
 }
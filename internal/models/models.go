@@ -1,6 +1,8 @@
 package models
 
-import "time"
+import (
+	"time"
+)
 
 // Response Models
 
@@ -12,7 +14,8 @@ type RestaurantsResponse struct {
 
 // RestaurantResponse represents the response for a single restaurant
 type RestaurantResponse struct {
-	Restaurant Restaurant `json:"restaurant"`
+	Restaurant Restaurant    `json:"restaurant"`
+	Rating     RatingSummary `json:"rating"` // of the visible reviews of the restaurant
 }
 
 // MenuItemsResponse represents the response for restaurant menu items
@@ -33,24 +36,34 @@ type ErrorResponse struct {
 	Message string `json:"message"`
 }
 
-// BusinessStatistics represents business analytics data
-type BusinessStatistics struct {
-	TotalRestaurants    int64                    `json:"total_restaurants"`
-	ActiveRestaurants   int64                    `json:"active_restaurants"`
-	InactiveRestaurants int64                    `json:"inactive_restaurants"`
-	TotalMenuItems      int64                    `json:"total_menu_items"`
-	AveragePrice        float64                  `json:"average_price"`
-	RevenueByCateory    map[string]float64       `json:"revenue_by_category"`
-	RestaurantDetails   []RestaurantBusinessData `json:"restaurant_details"`
+// Problem is an RFC 7807 problem details error response (application/problem+json)
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Code     string       `json:"code"`             // machine-readable error code, e.g. "NOT_FOUND"
+	Errors   []FieldError `json:"errors,omitempty"` // per-field validation details
 }
 
-// RestaurantBusinessData represents per-restaurant business data
-type RestaurantBusinessData struct {
-	RestaurantID   uint    `json:"restaurant_id"`
-	RestaurantName string  `json:"restaurant_name"`
-	MenuItemCount  int64   `json:"menu_item_count"`
-	AveragePrice   float64 `json:"average_price"`
-	TotalRevenue   float64 `json:"total_revenue"`
+// FieldError describes why a single input field is invalid
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// HealthResponse is returned by the liveness and readiness probes
+type HealthResponse struct {
+	Status string                 `json:"status" example:"ok"` // ok or unavailable
+	Checks map[string]HealthCheck `json:"checks,omitempty"`
+}
+
+// HealthCheck is the result of a single readiness check
+type HealthCheck struct {
+	Status     string  `json:"status" example:"ok"` // ok or failed
+	Error      string  `json:"error,omitempty"`
+	DurationMs float64 `json:"duration_ms"`
 }
 
 // SafeUser is used for API responses to hide sensitive fields
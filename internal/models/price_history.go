@@ -0,0 +1,282 @@
+package models
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"lunch_menu/internal/money"
+)
+
+// PriceChange is an entry of the price history of a menu item: the price it
+// had from EffectiveFrom until the next change. Changes made through the API
+// take effect immediately; scheduled changes have a future EffectiveFrom and
+// no AppliedAt until they are applied to the menu item.
+type PriceChange struct {
+	ID            uint         `gorm:"primaryKey" json:"id"`
+	MenuItemID    uint         `gorm:"index:idx_price_changes_item_effective,priority:1;not null" json:"menu_item_id"`
+	Price         money.Amount `gorm:"type:decimal(10,2);not null" json:"price" swaggertype:"number" example:"129.00"` // including VAT
+	Currency      string       `gorm:"size:3;not null" json:"currency" example:"SEK"`
+	EffectiveFrom time.Time    `gorm:"index:idx_price_changes_item_effective,priority:2;not null" json:"effective_from"`
+	AppliedAt     *time.Time   `gorm:"index" json:"applied_at"` // null while scheduled
+	CreatedAt     time.Time    `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName overrides the table name used by GORM
+func (PriceChange) TableName() string {
+	return "price_changes"
+}
+
+// Scheduled reports whether the change has not been applied yet
+func (c *PriceChange) Scheduled() bool {
+	return c.AppliedAt == nil
+}
+
+// PriceChangeInput schedules a price change of a menu item
+type PriceChangeInput struct {
+	Price         money.Amount `json:"price" binding:"required,gt=0" swaggertype:"number" example:"135.00"` // including VAT, at most 2 decimals
+	Currency      string       `json:"currency" example:"SEK"`                                              // defaults to the item's currency
+	EffectiveFrom time.Time    `json:"effective_from" binding:"required" example:"2026-01-01T00:00:00Z"`
+}
+
+// Validate checks the PriceChangeInput; the change must take effect after now
+func (input *PriceChangeInput) Validate(now time.Time) (bool, []string) {
+	var invalidFields []string
+	if input.Price <= 0 || input.Price > MaxPrice {
+		invalidFields = append(invalidFields, "price")
+	}
+	if input.Currency != "" && !money.IsCurrency(input.Currency) {
+		invalidFields = append(invalidFields, "currency")
+	}
+	if !input.EffectiveFrom.After(now) {
+		invalidFields = append(invalidFields, "effective_from")
+	}
+	return len(invalidFields) == 0, invalidFields
+}
+
+// PriceHistoryResponse is the price history of a menu item
+type PriceHistoryResponse struct {
+	MenuItemID uint          `json:"menu_item_id"`
+	Current    *PriceChange  `json:"current"`   // the change in effect, null for items without history
+	History    []PriceChange `json:"history"`   // applied changes, oldest first
+	Scheduled  []PriceChange `json:"scheduled"` // changes yet to take effect, soonest first
+}
+
+// NewPriceHistoryResponse splits the changes of a menu item, ordered by
+// EffectiveFrom, into the applied history and the scheduled changes
+func NewPriceHistoryResponse(menuItemID uint, changes []PriceChange) PriceHistoryResponse {
+	resp := PriceHistoryResponse{MenuItemID: menuItemID, History: []PriceChange{}, Scheduled: []PriceChange{}}
+	for _, c := range changes {
+		if c.Scheduled() {
+			resp.Scheduled = append(resp.Scheduled, c)
+		} else {
+			resp.History = append(resp.History, c)
+		}
+	}
+	if n := len(resp.History); n > 0 {
+		resp.Current = &resp.History[n-1]
+	}
+	return resp
+}
+
+// PriceChangeWithItem is an applied price change with the attributes of its
+// menu item that price trends are grouped by
+type PriceChangeWithItem struct {
+	PriceChange
+	RestaurantID   uint
+	RestaurantName string
+	Region         string
+	Category       string
+}
+
+// Price trend groupings and intervals
+const (
+	TrendByRestaurant = "restaurant"
+	TrendByRegion     = "region"
+	TrendByCategory   = "category"
+
+	TrendDaily   = "day"
+	TrendWeekly  = "week"
+	TrendMonthly = "month"
+	TrendYearly  = "year"
+)
+
+// MaxTrendPeriods limits the number of periods of a price trend report
+const MaxTrendPeriods = 400
+
+// PriceTrendQuery selects a price trend report
+type PriceTrendQuery struct {
+	GroupBy  string    // TrendByRestaurant, TrendByRegion or TrendByCategory
+	Interval string    // TrendDaily, TrendWeekly, TrendMonthly or TrendYearly
+	From     time.Time // start of the first period
+	To       time.Time // the last period contains To
+	Currency string    // only prices in this currency are averaged
+}
+
+// PriceTrends is the average price of the menu items of each group at the end
+// of each period, the "lunch inflation" report
+type PriceTrends struct {
+	GroupBy  string             `json:"group_by" example:"region"`
+	Interval string             `json:"interval" example:"month"`
+	Currency string             `json:"currency" example:"SEK"`
+	From     time.Time          `json:"from"`
+	To       time.Time          `json:"to"`
+	Series   []PriceTrendSeries `json:"series"`
+}
+
+// PriceTrendSeries is the price trend of a restaurant, region or category
+type PriceTrendSeries struct {
+	Key          string            `json:"key" example:"Solna"` // restaurant name, region or category
+	RestaurantID uint              `json:"restaurant_id,omitempty"`
+	Points       []PriceTrendPoint `json:"points"`
+}
+
+// PriceTrendPoint is the average price of the items that had a price at the
+// end of a period. ChangePercent is the change since the first point of the
+// series.
+type PriceTrendPoint struct {
+	Period        time.Time    `json:"period"` // start of the period
+	AveragePrice  money.Amount `json:"average_price" swaggertype:"number" example:"119.50"`
+	MenuItems     int64        `json:"menu_items"`
+	ChangePercent float64      `json:"change_percent" example:"4.2"`
+}
+
+// PeriodStart returns the start of the period of interval that contains t, in UTC
+func PeriodStart(interval string, t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch interval {
+	case TrendWeekly:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7) // weeks start on Monday
+	case TrendMonthly:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case TrendYearly:
+		return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return day
+}
+
+// nextPeriod returns the start of the period after the one starting at start
+func nextPeriod(interval string, start time.Time) time.Time {
+	switch interval {
+	case TrendWeekly:
+		return start.AddDate(0, 0, 7)
+	case TrendMonthly:
+		return start.AddDate(0, 1, 0)
+	case TrendYearly:
+		return start.AddDate(1, 0, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// TrendPeriods returns the starts of the periods of q, nil if there are more
+// than MaxTrendPeriods
+func (q PriceTrendQuery) TrendPeriods() []time.Time {
+	var periods []time.Time
+	for start := PeriodStart(q.Interval, q.From); !start.After(q.To); start = nextPeriod(q.Interval, start) {
+		if len(periods) == MaxTrendPeriods {
+			return nil
+		}
+		periods = append(periods, start)
+	}
+	return periods
+}
+
+// Start returns the start of the first period of q
+func (q PriceTrendQuery) Start() time.Time {
+	return PeriodStart(q.Interval, q.From)
+}
+
+// End returns the end of the last period of q
+func (q PriceTrendQuery) End() time.Time {
+	return nextPeriod(q.Interval, PeriodStart(q.Interval, q.To))
+}
+
+// BuildPriceTrends computes the price trends of q from the applied price
+// changes of the menu items, ordered by EffectiveFrom. An item's price at the
+// end of a period is that of its last change before the period ended; items
+// whose price is in another currency then are left out.
+func BuildPriceTrends(q PriceTrendQuery, changes []PriceChangeWithItem) PriceTrends {
+	trends := PriceTrends{GroupBy: q.GroupBy, Interval: q.Interval, Currency: q.Currency, From: q.From, To: q.To, Series: []PriceTrendSeries{}}
+	periods := q.TrendPeriods()
+
+	type group struct {
+		series PriceTrendSeries
+		items  map[uint]PriceChangeWithItem // latest change per menu item
+	}
+	groups := make(map[string]*group)
+	var keys []string
+	for _, c := range changes {
+		key := q.groupKey(&c)
+		if _, ok := groups[key]; !ok {
+			groups[key] = &group{series: PriceTrendSeries{Key: q.groupLabel(&c), Points: []PriceTrendPoint{}}, items: make(map[uint]PriceChangeWithItem)}
+			if q.GroupBy == TrendByRestaurant {
+				groups[key].series.RestaurantID = c.RestaurantID
+			}
+			keys = append(keys, key)
+		}
+	}
+	// Series are ordered by label; restaurants of the same name by ID
+	slices.SortFunc(keys, func(a, b string) int {
+		ga, gb := groups[a].series, groups[b].series
+		return cmp.Or(cmp.Compare(ga.Key, gb.Key), cmp.Compare(ga.RestaurantID, gb.RestaurantID))
+	})
+
+	next := 0
+	for _, start := range periods {
+		end := nextPeriod(q.Interval, start)
+		for ; next < len(changes) && changes[next].EffectiveFrom.Before(end); next++ {
+			c := changes[next]
+			groups[q.groupKey(&c)].items[c.MenuItemID] = c
+		}
+		for _, key := range keys {
+			g := groups[key]
+			var sum money.Amount
+			var n int64
+			for _, c := range g.items {
+				if c.Currency == q.Currency {
+					sum += c.Price
+					n++
+				}
+			}
+			if n == 0 {
+				continue
+			}
+			point := PriceTrendPoint{Period: start, AveragePrice: money.Average(sum, n), MenuItems: n}
+			if len(g.series.Points) > 0 {
+				first := g.series.Points[0].AveragePrice
+				point.ChangePercent = math.Round(float64(point.AveragePrice-first)/float64(first)*10000) / 100
+			}
+			g.series.Points = append(g.series.Points, point)
+		}
+	}
+	for _, key := range keys {
+		if series := groups[key].series; len(series.Points) > 0 {
+			trends.Series = append(trends.Series, series)
+		}
+	}
+	return trends
+}
+
+// groupKey returns the restaurant ID, region or category of a change, which
+// identifies its series; restaurants are told apart by ID, not by name
+func (q PriceTrendQuery) groupKey(c *PriceChangeWithItem) string {
+	if q.GroupBy == TrendByRestaurant {
+		return strconv.FormatUint(uint64(c.RestaurantID), 10)
+	}
+	return q.groupLabel(c)
+}
+
+// groupLabel returns the restaurant name, region or category of a change,
+// the Key of its series
+func (q PriceTrendQuery) groupLabel(c *PriceChangeWithItem) string {
+	switch q.GroupBy {
+	case TrendByRestaurant:
+		return c.RestaurantName
+	case TrendByCategory:
+		return c.Category
+	}
+	return c.Region
+}
@@ -1,14 +1,17 @@
 package models
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // RefreshToken represents a refresh token for a user session
 type RefreshToken struct {
-	ID        uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
 	UserID    uint       `gorm:"index;not null" json:"user_id"`
 	TokenHash string     `gorm:"not null" json:"token_hash"`
 	UserAgent string     `gorm:"not null" json:"user_agent"`
@@ -18,7 +21,23 @@ type RefreshToken struct {
 	RevokedAt *time.Time `json:"revoked_at"` // pointer for nullable
 }
 
+// BeforeCreate generates the ID in Go rather than with a Postgres default, so
+// that the table works on every database
+func (rt *RefreshToken) BeforeCreate(tx *gorm.DB) error {
+	if rt.ID == uuid.Nil {
+		rt.ID = uuid.New()
+	}
+	return nil
+}
+
 // TableName overrides the table name used by GORM (optional)
 func (RefreshToken) TableName() string {
 	return "refresh_tokens"
 }
+
+// HashRefreshToken returns the hash under which a refresh token is stored, so
+// that leaked rows cannot be used as tokens
+func HashRefreshToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -6,6 +6,9 @@ import (
 	"errors"
 	"net/mail"
 	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 )
 
 // FloatArray represents a PostgreSQL array of floats for coordinates
@@ -16,6 +19,15 @@ func (fa FloatArray) Value() (driver.Value, error) {
 	return json.Marshal(fa)
 }
 
+// GormDBDataType stores coordinates as JSONB in Postgres and as JSON text in
+// other databases
+func (FloatArray) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "jsonb"
+	}
+	return "text"
+}
+
 // Scan implements the sql.Scanner interface
 func (fa *FloatArray) Scan(value interface{}) error {
 	if value == nil {
@@ -38,7 +50,7 @@ type Restaurant struct {
 	Name        string     `gorm:"not null" json:"name"`
 	Description string     `json:"description"`
 	Address     string     `json:"address"`
-	Coordinate  FloatArray `gorm:"type:jsonb" json:"coordinate"` // Store as JSONB in Postgres
+	Coordinate  FloatArray `json:"coordinate"` // Store as JSONB in Postgres, see FloatArray.GormDBDataType
 	Homepage    string     `json:"homepage"`
 	Region      string     `json:"region"`
 	Phone       string     `json:"phone"`
@@ -101,3 +113,52 @@ type RestaurantUpdateInput struct {
 	Email       *string    `json:"email,omitempty"`
 	IsActive    *bool      `json:"is_active,omitempty"`
 }
+
+// Validate checks the fields that are set on the RestaurantUpdateInput.
+func (input *RestaurantUpdateInput) Validate() (bool, []string) {
+	var invalidFields []string
+	if input.Name != nil && *input.Name == "" {
+		invalidFields = append(invalidFields, "name")
+	}
+	if input.Address != nil && *input.Address == "" {
+		invalidFields = append(invalidFields, "address")
+	}
+	if input.Email != nil && !IsValidEmail(*input.Email) {
+		invalidFields = append(invalidFields, "email")
+	}
+	if input.Coordinate != nil && len(*input.Coordinate) != 2 {
+		invalidFields = append(invalidFields, "coordinate")
+	}
+	return len(invalidFields) == 0, invalidFields
+}
+
+// Apply copies the fields that are set on the RestaurantUpdateInput to r.
+func (input *RestaurantUpdateInput) Apply(r *Restaurant) {
+	if input.Name != nil {
+		r.Name = *input.Name
+	}
+	if input.Description != nil {
+		r.Description = *input.Description
+	}
+	if input.Address != nil {
+		r.Address = *input.Address
+	}
+	if input.Coordinate != nil {
+		r.Coordinate = *input.Coordinate
+	}
+	if input.Homepage != nil {
+		r.Homepage = *input.Homepage
+	}
+	if input.Region != nil {
+		r.Region = *input.Region
+	}
+	if input.Phone != nil {
+		r.Phone = *input.Phone
+	}
+	if input.Email != nil {
+		r.Email = *input.Email
+	}
+	if input.IsActive != nil {
+		r.IsActive = *input.IsActive
+	}
+}
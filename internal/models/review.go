@@ -0,0 +1,178 @@
+package models
+
+import (
+	"math"
+	"time"
+	"unicode/utf8"
+)
+
+// Review targets
+const (
+	ReviewRestaurant = "restaurant"
+	ReviewMenuItem   = "menu_item"
+)
+
+// Review limits
+const (
+	MinRating              = 1
+	MaxRating              = 5
+	MaxReviewCommentLength = 2000 // characters
+)
+
+// Review is a customer's rating and comment of a restaurant or a menu item.
+// A user reviews a target at most once per day (UTC), see ReviewedOn. Admins
+// moderate reviews: hidden ones are left out of the public listings and the
+// ratings, flagged ones are marked for a closer look and stay visible.
+type Review struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	UserID     uint      `gorm:"uniqueIndex:idx_reviews_user_target_day,priority:1;not null" json:"user_id"`
+	TargetType string    `gorm:"size:16;uniqueIndex:idx_reviews_user_target_day,priority:2;index:idx_reviews_target,priority:1;not null" json:"target_type" example:"restaurant"`
+	TargetID   uint      `gorm:"uniqueIndex:idx_reviews_user_target_day,priority:3;index:idx_reviews_target,priority:2;not null" json:"target_id"`
+	ReviewedOn string    `gorm:"size:10;uniqueIndex:idx_reviews_user_target_day,priority:4;not null" json:"reviewed_on" example:"2026-10-19"` // UTC date of creation
+	Rating     int       `gorm:"not null" json:"rating" example:"4"`
+	Comment    string    `gorm:"size:2000" json:"comment" example:"Generous portions"`
+	Hidden     bool      `gorm:"not null;default:false" json:"hidden"`
+	Flagged    bool      `gorm:"not null;default:false;index" json:"flagged"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName overrides the table name used by GORM
+func (Review) TableName() string {
+	return "reviews"
+}
+
+// ReviewDate returns the date a review created at t is counted on
+func ReviewDate(t time.Time) string {
+	return t.UTC().Format(time.DateOnly)
+}
+
+// ReviewInput is a new review of a restaurant or menu item
+type ReviewInput struct {
+	Rating  int    `json:"rating" binding:"required" example:"4"` // 1 to 5
+	Comment string `json:"comment" example:"Generous portions"`
+}
+
+// Validate checks the rating and the length of the comment
+func (input *ReviewInput) Validate() (bool, []string) {
+	return validReview(&input.Rating, &input.Comment)
+}
+
+// ReviewUpdateInput changes the rating or comment of a review
+type ReviewUpdateInput struct {
+	Rating  *int    `json:"rating,omitempty" example:"5"`
+	Comment *string `json:"comment,omitempty"`
+}
+
+// Validate checks the fields that are set on the ReviewUpdateInput
+func (input *ReviewUpdateInput) Validate() (bool, []string) {
+	return validReview(input.Rating, input.Comment)
+}
+
+// Apply copies the fields that are set on the ReviewUpdateInput to review
+func (input *ReviewUpdateInput) Apply(review *Review) {
+	if input.Rating != nil {
+		review.Rating = *input.Rating
+	}
+	if input.Comment != nil {
+		review.Comment = *input.Comment
+	}
+}
+
+// Columns returns the columns set by the ReviewUpdateInput and their values,
+// so that an edit does not overwrite a concurrent moderation
+func (input *ReviewUpdateInput) Columns() map[string]interface{} {
+	columns := make(map[string]interface{})
+	if input.Rating != nil {
+		columns["rating"] = *input.Rating
+	}
+	if input.Comment != nil {
+		columns["comment"] = *input.Comment
+	}
+	return columns
+}
+
+// validReview checks a rating and a comment, each unless nil
+func validReview(rating *int, comment *string) (bool, []string) {
+	var invalidFields []string
+	if rating != nil && (*rating < MinRating || *rating > MaxRating) {
+		invalidFields = append(invalidFields, "rating")
+	}
+	if comment != nil && utf8.RuneCountInString(*comment) > MaxReviewCommentLength {
+		invalidFields = append(invalidFields, "comment")
+	}
+	return len(invalidFields) == 0, invalidFields
+}
+
+// ReviewModerationInput hides, flags or restores a review
+type ReviewModerationInput struct {
+	Hidden  *bool `json:"hidden,omitempty"`
+	Flagged *bool `json:"flagged,omitempty"`
+}
+
+// Apply copies the fields that are set on the ReviewModerationInput to review
+func (input *ReviewModerationInput) Apply(review *Review) {
+	if input.Hidden != nil {
+		review.Hidden = *input.Hidden
+	}
+	if input.Flagged != nil {
+		review.Flagged = *input.Flagged
+	}
+}
+
+// Columns returns the columns set by the ReviewModerationInput and their
+// values, so that moderation does not overwrite a concurrent edit
+func (input *ReviewModerationInput) Columns() map[string]interface{} {
+	columns := make(map[string]interface{})
+	if input.Hidden != nil {
+		columns["hidden"] = *input.Hidden
+	}
+	if input.Flagged != nil {
+		columns["flagged"] = *input.Flagged
+	}
+	return columns
+}
+
+// ReviewFilter selects reviews; zero fields match all
+type ReviewFilter struct {
+	TargetType string
+	TargetID   uint
+	Hidden     *bool
+	Flagged    *bool
+}
+
+// Matches reports whether the filter selects review
+func (f ReviewFilter) Matches(review *Review) bool {
+	return (f.TargetType == "" || review.TargetType == f.TargetType) &&
+		(f.TargetID == 0 || review.TargetID == f.TargetID) &&
+		(f.Hidden == nil || review.Hidden == *f.Hidden) &&
+		(f.Flagged == nil || review.Flagged == *f.Flagged)
+}
+
+// RatingSummary is the average rating and the number of visible reviews of a
+// restaurant or menu item
+type RatingSummary struct {
+	Average float64 `json:"average" example:"4.25"` // rounded to 2 decimals, 0 without reviews
+	Count   int64   `json:"count" example:"12"`
+}
+
+// NewRatingSummary returns the summary of count ratings adding up to sum
+func NewRatingSummary(sum, count int64) RatingSummary {
+	if count == 0 {
+		return RatingSummary{}
+	}
+	return RatingSummary{Average: math.Round(float64(sum)/float64(count)*100) / 100, Count: count}
+}
+
+// ReviewsResponse is a page of the reviews of a restaurant or menu item
+type ReviewsResponse struct {
+	Reviews []Review      `json:"reviews"`
+	Total   int64         `json:"total"`
+	Rating  RatingSummary `json:"rating"`
+}
+
+// ModerationReviewsResponse is a page of reviews for admins
+type ModerationReviewsResponse struct {
+	Reviews []Review `json:"reviews"`
+	Total   int64    `json:"total"`
+}
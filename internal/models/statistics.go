@@ -0,0 +1,364 @@
+package models
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"lunch_menu/internal/money"
+)
+
+// StaleMenuAge is how long an active restaurant's menu can go without an
+// update before the statistics count it as stale
+const StaleMenuAge = 7 * 24 * time.Hour
+
+// Price histogram buckets
+const (
+	DefaultBucketWidth  money.Amount = 25_00
+	MaxHistogramBuckets              = 50
+)
+
+// StatisticsFilter selects the data that business statistics are computed on
+type StatisticsFilter struct {
+	// PriceTier aggregates the prices of this variant, the default price of
+	// items without it; empty for the default prices
+	PriceTier string
+	// Region restricts the statistics to the restaurants of a region; empty
+	// for all regions
+	Region string
+	// From and To restrict the menu items to those created at or after From
+	// and before To; zero for no bound
+	From time.Time
+	To   time.Time
+	// BucketWidth is the width of the price histogram buckets,
+	// DefaultBucketWidth if zero
+	BucketWidth money.Amount
+}
+
+// Key identifies the statistics selected by the filter, e.g. in a cache
+func (f StatisticsFilter) Key() string {
+	width := f.BucketWidth
+	if width == 0 {
+		width = DefaultBucketWidth
+	}
+	bound := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339Nano)
+	}
+	return strings.Join([]string{f.PriceTier, f.Region, bound(f.From), bound(f.To), width.String()}, "|")
+}
+
+// BusinessStatistics represents business analytics data. Menu item figures
+// cover the available items of the active restaurants selected by the filter;
+// prices are aggregated exactly, over the items priced in Currency.
+type BusinessStatistics struct {
+	TotalRestaurants    int64      `json:"total_restaurants"`
+	ActiveRestaurants   int64      `json:"active_restaurants"`
+	InactiveRestaurants int64      `json:"inactive_restaurants"`
+	StaleRestaurants    int64      `json:"stale_restaurants"` // active restaurants whose menu was not updated within StaleMenuAge
+	TotalMenuItems      int64      `json:"total_menu_items"`
+	TotalViews          int64      `json:"total_views"` // of the active restaurants, see RestaurantBusinessData.Views
+	Currency            string     `json:"currency" example:"SEK"`
+	PriceTier           string     `json:"price_tier,omitempty" example:"student"`
+	Region              string     `json:"region,omitempty" example:"Solna"`
+	From                *time.Time `json:"from,omitempty"`
+	To                  *time.Time `json:"to,omitempty"`
+	// AveragePrice is Prices.Average
+	AveragePrice      money.Amount             `json:"average_price" swaggertype:"number"`
+	Prices            PriceSummary             `json:"prices"`
+	PriceHistogram    []HistogramBucket        `json:"price_histogram"`
+	Categories        []CategoryStatistics     `json:"categories"`
+	Regions           []RegionStatistics       `json:"regions"`
+	LastMenuUpdate    *time.Time               `json:"last_menu_update"` // latest menu change of the restaurants, null without menu items
+	RestaurantDetails []RestaurantBusinessData `json:"restaurant_details"`
+	GeneratedAt       time.Time                `json:"generated_at"` // when the statistics were computed; they may be served from a cache
+}
+
+// NewBusinessStatistics returns the statistics selected by filter, in
+// currency and generated now, before their figures are computed
+func NewBusinessStatistics(filter StatisticsFilter, currency string, now time.Time) *BusinessStatistics {
+	stats := &BusinessStatistics{
+		Currency:    currency,
+		PriceTier:   filter.PriceTier,
+		Region:      filter.Region,
+		GeneratedAt: now,
+	}
+	if !filter.From.IsZero() {
+		stats.From = &filter.From
+	}
+	if !filter.To.IsZero() {
+		stats.To = &filter.To
+	}
+	return stats
+}
+
+// StatisticsSnapshot is precomputed business statistics, shared by the
+// replicas through the database
+type StatisticsSnapshot struct {
+	Key         string    `gorm:"primaryKey;size:255"` // StatisticsFilter.Key of the statistics
+	Data        string    `gorm:"type:text;not null"`  // the BusinessStatistics as JSON
+	GeneratedAt time.Time `gorm:"not null"`
+}
+
+// TableName overrides the table name used by GORM
+func (StatisticsSnapshot) TableName() string {
+	return "statistics_snapshots"
+}
+
+// PriceSummary describes the distribution of a set of prices. Percentiles are
+// interpolated between the closest prices.
+type PriceSummary struct {
+	PricedItems int64        `json:"priced_items"` // items priced in the statistics' currency
+	Average     money.Amount `json:"average" swaggertype:"number" example:"118.40"`
+	Min         money.Amount `json:"min" swaggertype:"number" example:"85.00"`
+	P25         money.Amount `json:"p25" swaggertype:"number" example:"105.00"`
+	Median      money.Amount `json:"median" swaggertype:"number" example:"115.00"`
+	P75         money.Amount `json:"p75" swaggertype:"number" example:"129.00"`
+	P90         money.Amount `json:"p90" swaggertype:"number" example:"145.00"`
+	Max         money.Amount `json:"max" swaggertype:"number" example:"189.00"`
+}
+
+// HistogramBucket counts the prices from From up to but not including To
+type HistogramBucket struct {
+	From  money.Amount `json:"from" swaggertype:"number" example:"100.00"`
+	To    money.Amount `json:"to" swaggertype:"number" example:"125.00"`
+	Count int64        `json:"count"`
+}
+
+// CategoryStatistics represents the menu items of a category
+type CategoryStatistics struct {
+	Category  string       `json:"category" example:"Main"`
+	MenuItems int64        `json:"menu_items"`
+	Prices    PriceSummary `json:"prices"`
+}
+
+// RegionStatistics represents the active restaurants of a region and their
+// menu items
+type RegionStatistics struct {
+	Region        string          `json:"region" example:"Solna"`
+	Restaurants   int64           `json:"restaurants"`
+	MenuItems     int64           `json:"menu_items"`
+	Prices        PriceSummary    `json:"prices"`
+	Cheapest      *PricedMenuItem `json:"cheapest"`       // null without priced items
+	MostExpensive *PricedMenuItem `json:"most_expensive"` // null without priced items
+}
+
+// PricedMenuItem identifies a menu item and its restaurant by price
+type PricedMenuItem struct {
+	MenuItemID     uint         `json:"menu_item_id"`
+	Name           string       `json:"name"`
+	RestaurantID   uint         `json:"restaurant_id"`
+	RestaurantName string       `json:"restaurant_name"`
+	Price          money.Amount `json:"price" swaggertype:"number"`
+}
+
+// RestaurantBusinessData represents per-restaurant business data
+type RestaurantBusinessData struct {
+	RestaurantID   uint         `json:"restaurant_id"`
+	RestaurantName string       `json:"restaurant_name"`
+	Region         string       `json:"region"`
+	MenuItemCount  int64        `json:"menu_item_count"`
+	AveragePrice   money.Amount `json:"average_price" swaggertype:"number"`
+	MedianPrice    money.Amount `json:"median_price" swaggertype:"number"`
+	MinPrice       money.Amount `json:"min_price" swaggertype:"number"`
+	MaxPrice       money.Amount `json:"max_price" swaggertype:"number"`
+	LastMenuUpdate *time.Time   `json:"last_menu_update"` // latest change of any of its menu items, null without items
+	Stale          bool         `json:"stale"`
+	// Views counts the views of the restaurant, its menu and its menu items,
+	// those within the date range of the filter if it has one
+	Views int64 `json:"views"`
+}
+
+// StatisticsRestaurant is an active restaurant the statistics are computed on
+type StatisticsRestaurant struct {
+	ID             uint
+	Name           string
+	Region         string
+	LastMenuUpdate *time.Time
+	Views          int64
+}
+
+// StatisticsMenuItem is an available menu item the statistics are computed
+// on, with the price of the requested tier
+type StatisticsMenuItem struct {
+	ID           uint
+	Name         string
+	RestaurantID uint
+	Category     string
+	Price        money.Amount
+	Currency     string
+}
+
+// Summarize computes the menu item figures of the statistics from the
+// restaurants, ordered by name, and their menu items, ordered by ID, as of now
+func (s *BusinessStatistics) Summarize(restaurants []StatisticsRestaurant, items []StatisticsMenuItem, bucketWidth money.Amount, now time.Time) {
+	all := &priceGroup{}
+	byRestaurant := make(map[uint]*priceGroup, len(restaurants))
+	byRegion := make(map[string]*priceGroup)
+	byCategory := make(map[string]*priceGroup)
+	regionRestaurants := make(map[string]int64)
+	restaurantsByID := make(map[uint]*StatisticsRestaurant, len(restaurants))
+	for i := range restaurants {
+		r := &restaurants[i]
+		restaurantsByID[r.ID] = r
+		byRestaurant[r.ID] = &priceGroup{}
+		if byRegion[r.Region] == nil {
+			byRegion[r.Region] = &priceGroup{}
+		}
+		regionRestaurants[r.Region]++
+	}
+	for i := range items {
+		item := &items[i]
+		r, ok := restaurantsByID[item.RestaurantID]
+		if !ok {
+			continue
+		}
+		if byCategory[item.Category] == nil {
+			byCategory[item.Category] = &priceGroup{}
+		}
+		for _, g := range []*priceGroup{all, byRestaurant[r.ID], byRegion[r.Region], byCategory[item.Category]} {
+			g.add(item, s.Currency)
+		}
+	}
+
+	s.TotalMenuItems = all.items
+	s.Prices = all.summary()
+	s.AveragePrice = s.Prices.Average
+	s.PriceHistogram = priceHistogram(all.prices, bucketWidth)
+
+	s.Categories = []CategoryStatistics{}
+	for _, category := range slices.Sorted(maps.Keys(byCategory)) {
+		g := byCategory[category]
+		s.Categories = append(s.Categories, CategoryStatistics{Category: category, MenuItems: g.items, Prices: g.summary()})
+	}
+
+	s.Regions = []RegionStatistics{}
+	for _, region := range slices.Sorted(maps.Keys(byRegion)) {
+		g := byRegion[region]
+		s.Regions = append(s.Regions, RegionStatistics{
+			Region:        region,
+			Restaurants:   regionRestaurants[region],
+			MenuItems:     g.items,
+			Prices:        g.summary(),
+			Cheapest:      g.pricedItem(g.cheapest, restaurantsByID),
+			MostExpensive: g.pricedItem(g.mostExpensive, restaurantsByID),
+		})
+	}
+
+	s.StaleRestaurants = 0
+	s.TotalViews = 0
+	s.LastMenuUpdate = nil
+	s.RestaurantDetails = []RestaurantBusinessData{}
+	for _, r := range restaurants {
+		prices := byRestaurant[r.ID].summary()
+		detail := RestaurantBusinessData{
+			RestaurantID:   r.ID,
+			RestaurantName: r.Name,
+			Region:         r.Region,
+			MenuItemCount:  byRestaurant[r.ID].items,
+			AveragePrice:   prices.Average,
+			MedianPrice:    prices.Median,
+			MinPrice:       prices.Min,
+			MaxPrice:       prices.Max,
+			LastMenuUpdate: r.LastMenuUpdate,
+			Stale:          r.LastMenuUpdate == nil || now.Sub(*r.LastMenuUpdate) > StaleMenuAge,
+			Views:          r.Views,
+		}
+		s.TotalViews += r.Views
+		if detail.Stale {
+			s.StaleRestaurants++
+		}
+		if r.LastMenuUpdate != nil && (s.LastMenuUpdate == nil || r.LastMenuUpdate.After(*s.LastMenuUpdate)) {
+			s.LastMenuUpdate = r.LastMenuUpdate
+		}
+		s.RestaurantDetails = append(s.RestaurantDetails, detail)
+	}
+}
+
+// priceGroup collects the menu items of a restaurant, region or category
+type priceGroup struct {
+	items         int64
+	prices        []money.Amount // in the statistics' currency
+	cheapest      *StatisticsMenuItem
+	mostExpensive *StatisticsMenuItem
+}
+
+// add counts item, and its price if it is in currency
+func (g *priceGroup) add(item *StatisticsMenuItem, currency string) {
+	g.items++
+	if item.Currency != currency {
+		return
+	}
+	g.prices = append(g.prices, item.Price)
+	if g.cheapest == nil || item.Price < g.cheapest.Price {
+		g.cheapest = item
+	}
+	if g.mostExpensive == nil || item.Price > g.mostExpensive.Price {
+		g.mostExpensive = item
+	}
+}
+
+// summary sorts the prices of the group and describes them
+func (g *priceGroup) summary() PriceSummary {
+	slices.Sort(g.prices)
+	n := len(g.prices)
+	if n == 0 {
+		return PriceSummary{}
+	}
+	var sum money.Amount
+	for _, p := range g.prices {
+		sum += p
+	}
+	return PriceSummary{
+		PricedItems: int64(n),
+		Average:     money.Average(sum, int64(n)),
+		Min:         g.prices[0],
+		P25:         money.Percentile(g.prices, 25),
+		Median:      money.Percentile(g.prices, 50),
+		P75:         money.Percentile(g.prices, 75),
+		P90:         money.Percentile(g.prices, 90),
+		Max:         g.prices[n-1],
+	}
+}
+
+// pricedItem describes item of the group, nil if there is none
+func (g *priceGroup) pricedItem(item *StatisticsMenuItem, restaurants map[uint]*StatisticsRestaurant) *PricedMenuItem {
+	if item == nil {
+		return nil
+	}
+	return &PricedMenuItem{
+		MenuItemID:     item.ID,
+		Name:           item.Name,
+		RestaurantID:   item.RestaurantID,
+		RestaurantName: restaurants[item.RestaurantID].Name,
+		Price:          item.Price,
+	}
+}
+
+// priceHistogram counts the ascending prices in buckets of width, starting at
+// the multiple of width below the cheapest price. The width is doubled until
+// there are at most MaxHistogramBuckets buckets.
+func priceHistogram(sorted []money.Amount, width money.Amount) []HistogramBucket {
+	buckets := []HistogramBucket{}
+	if len(sorted) == 0 {
+		return buckets
+	}
+	if width <= 0 {
+		width = DefaultBucketWidth
+	}
+	low, high := sorted[0], sorted[len(sorted)-1]
+	for (high-low/width*width)/width >= MaxHistogramBuckets {
+		width *= 2
+	}
+	low = low / width * width
+	for from := low; from <= high; from += width {
+		buckets = append(buckets, HistogramBucket{From: from, To: from + width})
+	}
+	for _, p := range sorted {
+		buckets[(p-low)/width].Count++
+	}
+	return buckets
+}
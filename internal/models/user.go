@@ -5,23 +5,33 @@ import (
 	"time"
 )
 
+// User roles: admins manage the catalogue, customers review it
+const (
+	RoleAdmin    = "admin"
+	RoleCustomer = "customer"
+)
+
 // User represents an application user (admin or customer)
 type User struct {
-	ID           uint      `gorm:"primaryKey" json:"id"`
-	Username     string    `gorm:"unique;not null" json:"username"`
-	PasswordHash string    `gorm:"not null" json:"password_hash"`
-	Email        string    `gorm:"unique;not null" json:"email"`
-	Role         string    `json:"role"`
-	IsActive     bool      `json:"is_active"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID           uint   `gorm:"primaryKey" json:"id"`
+	Username     string `gorm:"unique;not null" json:"username"`
+	PasswordHash string `gorm:"not null" json:"password_hash"`
+	Email        string `gorm:"unique;not null" json:"email"`
+	Role         string `json:"role"`
+	IsActive     bool   `json:"is_active"`
+	// HomeRegion and DietaryPreferences are the user's preferences for the
+	// feed, see Preferences; DietaryPreferences is comma-separated
+	HomeRegion         string    `gorm:"size:100" json:"home_region"`
+	DietaryPreferences string    `json:"dietary_preferences"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
 }
 
 type UserInput struct {
 	Username     string `json:"username" binding:"required"`
 	PasswordHash string `json:"password_hash" binding:"required"`
 	Email        string `json:"email" binding:"required"`
-	Role         string `json:"role" binding:"required"`
+	Role         string `json:"role" example:"customer"` // customer (default) or admin; only admins register admins
 }
 
 func (input *UserInput) Validate() (bool, []string) {
@@ -35,7 +45,7 @@ func (input *UserInput) Validate() (bool, []string) {
 	if input.Email == "" || !IsValid_Email(input.Email) {
 		invalidFields = append(invalidFields, "email")
 	}
-	if input.Role == "" {
+	if input.Role != "" && input.Role != RoleCustomer && input.Role != RoleAdmin {
 		invalidFields = append(invalidFields, "role")
 	}
 	return len(invalidFields) == 0, invalidFields
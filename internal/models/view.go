@@ -0,0 +1,77 @@
+package models
+
+import "time"
+
+// Kinds of view events
+const (
+	ViewRestaurant = "restaurant" // GET /api/restaurants/:id
+	ViewMenu       = "menu"       // GET /api/restaurants/:id/menu
+	ViewMenuItem   = "menu_item"  // GET /api/menu-items/:id
+)
+
+// ViewEvent records that a restaurant, its menu or a menu item was viewed.
+// Events are anonymous: they hold neither the user nor the client address,
+// and the time is truncated to the minute.
+type ViewEvent struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	Kind         string    `gorm:"size:16;not null" json:"kind" example:"menu_item"`
+	RestaurantID uint      `gorm:"index:idx_view_events_restaurant_viewed,priority:1;not null" json:"restaurant_id"`
+	MenuItemID   *uint     `gorm:"index" json:"menu_item_id"` // only set for menu item views
+	ViewedAt     time.Time `gorm:"index:idx_view_events_restaurant_viewed,priority:2;index;not null" json:"viewed_at"`
+}
+
+// TableName overrides the table name used by GORM
+func (ViewEvent) TableName() string {
+	return "view_events"
+}
+
+// Trending periods
+const (
+	TrendingDay  = "day"  // the last 24 hours
+	TrendingWeek = "week" // the last 7 days
+)
+
+// Trending list sizes
+const (
+	DefaultTrendingLimit = 10
+	MaxTrendingLimit     = 50
+)
+
+// TrendingSince returns the start of a trending period ending at now, false
+// for an unknown period
+func TrendingSince(period string, now time.Time) (time.Time, bool) {
+	switch period {
+	case TrendingDay:
+		return now.Add(-24 * time.Hour), true
+	case TrendingWeek:
+		return now.AddDate(0, 0, -7), true
+	}
+	return time.Time{}, false
+}
+
+// Trending lists the most viewed active restaurants and available menu items
+// of a period, most viewed first. The views of a restaurant include those of
+// its menu and its menu items.
+type Trending struct {
+	Period      string               `json:"period" example:"day"`
+	Since       time.Time            `json:"since"`
+	Restaurants []TrendingRestaurant `json:"restaurants"`
+	MenuItems   []TrendingMenuItem   `json:"menu_items"`
+}
+
+// TrendingRestaurant is a restaurant and its number of views in a period
+type TrendingRestaurant struct {
+	RestaurantID   uint   `json:"restaurant_id"`
+	RestaurantName string `json:"restaurant_name"`
+	Region         string `json:"region"`
+	Views          int64  `json:"views"`
+}
+
+// TrendingMenuItem is a menu item and its number of views in a period
+type TrendingMenuItem struct {
+	MenuItemID     uint   `json:"menu_item_id"`
+	Name           string `json:"name"`
+	RestaurantID   uint   `json:"restaurant_id"`
+	RestaurantName string `json:"restaurant_name"`
+	Views          int64  `json:"views"`
+}
@@ -0,0 +1,222 @@
+package models
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Event types emitted on restaurant and menu item changes
+const (
+	EventRestaurantCreated = "restaurant.created"
+	EventRestaurantUpdated = "restaurant.updated"
+	EventRestaurantDeleted = "restaurant.deleted"
+	EventMenuItemCreated   = "menu_item.created"
+	EventMenuItemUpdated   = "menu_item.updated"
+	EventMenuItemDeleted   = "menu_item.deleted"
+
+	// EventAll subscribes to every event type
+	EventAll = "*"
+)
+
+// EventTypes lists all event types that can be subscribed to
+var EventTypes = []string{
+	EventRestaurantCreated, EventRestaurantUpdated, EventRestaurantDeleted,
+	EventMenuItemCreated, EventMenuItemUpdated, EventMenuItemDeleted,
+}
+
+// Webhook delivery states
+const (
+	DeliveryPending   = "pending"   // waiting for its first or next attempt
+	DeliverySucceeded = "succeeded" // receiver answered with 2xx
+	DeliveryDead      = "dead"      // gave up after the maximum number of attempts
+	DeliverySkipped   = "skipped"   // not attempted because the subscription was deleted or deactivated
+)
+
+// OutboxEvent is a change event written in the same transaction as the change
+// itself (transactional outbox), so that no event is lost if the process
+// crashes before it has been delivered.
+type OutboxEvent struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	EventType    string     `gorm:"not null;index" json:"event_type"`
+	RestaurantID uint       `gorm:"index" json:"restaurant_id"`
+	Region       string     `json:"region"`                            // region of the restaurant at the time of the event
+	Payload      string     `gorm:"type:text;not null" json:"payload"` // JSON string
+	CreatedAt    time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	DispatchedAt *time.Time `gorm:"index" json:"dispatched_at"` // set once deliveries are created
+}
+
+// TableName overrides the table name used by GORM (optional)
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
+
+// WebhookSubscription is an admin-managed receiver of change events
+type WebhookSubscription struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	URL        string    `gorm:"not null" json:"url"`
+	EventTypes string    `gorm:"not null" json:"event_types"` // comma-separated, "*" for all
+	Secret     string    `gorm:"not null" json:"secret,omitempty"`
+	IsActive   bool      `gorm:"default:true" json:"is_active"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName overrides the table name used by GORM (optional)
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// Matches reports whether the subscription wants events of the given type
+func (s *WebhookSubscription) Matches(eventType string) bool {
+	for _, t := range strings.Split(s.EventTypes, ",") {
+		t = strings.TrimSpace(t)
+		if t == EventAll || t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery tracks the delivery of one event to one subscription
+type WebhookDelivery struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	SubscriptionID uint       `gorm:"index;not null" json:"subscription_id"`
+	EventID        uint       `gorm:"index;not null" json:"event_id"`
+	EventType      string     `gorm:"not null" json:"event_type"`
+	Status         string     `gorm:"index;not null" json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  time.Time  `gorm:"index" json:"next_attempt_at"`
+	ResponseStatus int        `json:"response_status"`
+	LastError      string     `json:"last_error"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName overrides the table name used by GORM (optional)
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// NewWebhookDeliveries returns the pending deliveries of an event to the
+// subscriptions that want its type, due at now
+func NewWebhookDeliveries(event *OutboxEvent, subscriptions []WebhookSubscription, now time.Time) []WebhookDelivery {
+	var deliveries []WebhookDelivery
+	for _, sub := range subscriptions {
+		if !sub.IsActive || !sub.Matches(event.EventType) {
+			continue
+		}
+		deliveries = append(deliveries, WebhookDelivery{
+			SubscriptionID: sub.ID,
+			EventID:        event.ID,
+			EventType:      event.EventType,
+			Status:         DeliveryPending,
+			NextAttemptAt:  now,
+		})
+	}
+	return deliveries
+}
+
+// Retry puts the delivery back into the pending state, due at now
+func (d *WebhookDelivery) Retry(now time.Time) {
+	d.Status = DeliveryPending
+	d.Attempts = 0
+	d.NextAttemptAt = now
+}
+
+// WebhookSubscriptionInput represents the input for creating a webhook subscription
+type WebhookSubscriptionInput struct {
+	URL        string   `json:"url" binding:"required"`
+	EventTypes []string `json:"event_types" binding:"required"`
+	Secret     string   `json:"secret"` // generated if empty
+	IsActive   *bool    `json:"is_active"`
+}
+
+// Validate checks the WebhookSubscriptionInput for required fields and correct formats.
+func (input *WebhookSubscriptionInput) Validate() (bool, []string) {
+	var invalidFields []string
+	if !IsValidWebhookURL(input.URL) {
+		invalidFields = append(invalidFields, "url")
+	}
+	if !AreValidEventTypes(input.EventTypes) {
+		invalidFields = append(invalidFields, "event_types")
+	}
+	return len(invalidFields) == 0, invalidFields
+}
+
+// WebhookSubscriptionUpdateInput represents the input for updating a webhook subscription
+type WebhookSubscriptionUpdateInput struct {
+	URL        *string   `json:"url,omitempty"`
+	EventTypes *[]string `json:"event_types,omitempty"`
+	Secret     *string   `json:"secret,omitempty"`
+	IsActive   *bool     `json:"is_active,omitempty"`
+}
+
+// Validate checks the fields that are set on the WebhookSubscriptionUpdateInput.
+func (input *WebhookSubscriptionUpdateInput) Validate() (bool, []string) {
+	var invalidFields []string
+	if input.URL != nil && !IsValidWebhookURL(*input.URL) {
+		invalidFields = append(invalidFields, "url")
+	}
+	if input.EventTypes != nil && !AreValidEventTypes(*input.EventTypes) {
+		invalidFields = append(invalidFields, "event_types")
+	}
+	if input.Secret != nil && *input.Secret == "" {
+		invalidFields = append(invalidFields, "secret")
+	}
+	return len(invalidFields) == 0, invalidFields
+}
+
+// Apply copies the fields that are set on the WebhookSubscriptionUpdateInput to sub
+func (input *WebhookSubscriptionUpdateInput) Apply(sub *WebhookSubscription) {
+	if input.URL != nil {
+		sub.URL = *input.URL
+	}
+	if input.EventTypes != nil {
+		sub.EventTypes = strings.Join(*input.EventTypes, ",")
+	}
+	if input.Secret != nil {
+		sub.Secret = *input.Secret
+	}
+	if input.IsActive != nil {
+		sub.IsActive = *input.IsActive
+	}
+}
+
+// IsValidWebhookURL checks that the URL is an absolute http(s) URL
+func IsValidWebhookURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// AreValidEventTypes checks that types is non-empty and only contains known event types or "*"
+func AreValidEventTypes(types []string) bool {
+	if len(types) == 0 {
+		return false
+	}
+	for _, t := range types {
+		known := t == EventAll
+		for _, et := range EventTypes {
+			if t == et {
+				known = true
+			}
+		}
+		if !known {
+			return false
+		}
+	}
+	return true
+}
+
+// WebhookSubscriptionsResponse represents the response for listing webhook subscriptions
+type WebhookSubscriptionsResponse struct {
+	Subscriptions []WebhookSubscription `json:"subscriptions"`
+	Total         int64                 `json:"total"`
+}
+
+// WebhookDeliveriesResponse represents the response for listing webhook deliveries
+type WebhookDeliveriesResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+	Total      int64             `json:"total"`
+}
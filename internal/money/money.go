@@ -0,0 +1,254 @@
+// Package money represents prices exactly. Amounts are integer minor units
+// (öre for SEK), so sums, averages and VAT are computed without the rounding
+// errors of float64. In the database amounts are stored as DECIMAL(10,2), in
+// JSON as numbers with two decimals.
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Scale is the number of decimals of an amount; all supported currencies
+// have 100 minor units
+const Scale = 2
+
+const unit = 100 // minor units per currency unit
+
+// Amount is an amount of money in minor units
+type Amount int64
+
+var (
+	// ErrScale is returned for amounts with more than Scale decimals
+	ErrScale = errors.New("money: at most 2 decimals allowed")
+	// ErrSyntax is returned for text that is not a decimal amount
+	ErrSyntax = errors.New("money: invalid amount")
+)
+
+// Parse parses a decimal amount in currency units, e.g. "129", "129.5" or
+// "129.50". Amounts with more than two decimals are rejected with ErrScale.
+func Parse(s string) (Amount, error) {
+	minor, err := parseDecimal(s, Scale)
+	if err != nil {
+		return 0, err
+	}
+	return Amount(minor), nil
+}
+
+// FromFloat converts a float amount in currency units, as sent by GraphQL and
+// gRPC clients, rejecting values that have more than two decimals
+func FromFloat(f float64) (Amount, error) {
+	scaled := f * unit
+	rounded := math.Round(scaled)
+	if math.IsNaN(f) || math.IsInf(f, 0) || math.Abs(rounded) > math.MaxInt64/2 {
+		return 0, fmt.Errorf("%w %v", ErrSyntax, f)
+	}
+	if math.Abs(scaled-rounded) > 1e-6 {
+		return 0, ErrScale
+	}
+	return Amount(rounded), nil
+}
+
+// Float64 returns the amount in currency units, for clients that expect floats
+func (a Amount) Float64() float64 {
+	return float64(a) / unit
+}
+
+// String formats the amount in currency units with two decimals, e.g. "129.50"
+func (a Amount) String() string {
+	sign := ""
+	minor := int64(a)
+	if minor < 0 {
+		sign, minor = "-", -minor
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, minor/unit, minor%unit)
+}
+
+// Mul returns the amount multiplied by n
+func (a Amount) Mul(n int64) Amount {
+	return a * Amount(n)
+}
+
+// Average returns sum divided by n, rounded half away from zero to the minor
+// unit; 0 if n is 0
+func Average(sum Amount, n int64) Amount {
+	if n == 0 {
+		return 0
+	}
+	return Amount(divRound(int64(sum), n))
+}
+
+// Percentile returns the p-th percentile (0-100) of the ascending amounts,
+// interpolated linearly between the closest ranks like PostgreSQL's
+// percentile_cont and rounded to the minor unit; 0 if there are none
+func Percentile(sorted []Amount, p int) Amount {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int64(p) * int64(len(sorted)-1)
+	i, rem := rank/100, rank%100
+	if rem == 0 {
+		return sorted[i]
+	}
+	return sorted[i] + Amount(divRound(int64(sorted[i+1]-sorted[i])*rem, 100))
+}
+
+// MarshalJSON writes the amount as a JSON number with two decimals
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON reads a JSON number or a string with a decimal amount
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	parsed, err := Parse(strings.Trim(s, `"`))
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// Value stores the amount as a decimal string, exact for DECIMAL columns
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+// Scan reads a decimal column or aggregate. Values with more decimals, such
+// as the AVG of Postgres, are rounded half away from zero.
+func (a *Amount) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*a = 0
+		return nil
+	case int64:
+		*a = Amount(v * unit)
+		return nil
+	case float64:
+		// SQLite stores decimals as REAL
+		*a = Amount(math.Round(v * unit))
+		return nil
+	case []byte:
+		value = string(v)
+	}
+	s, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("money: cannot scan %T into Amount", value)
+	}
+	minor, err := parseDecimalRounded(s, Scale)
+	if err != nil {
+		return err
+	}
+	*a = Amount(minor)
+	return nil
+}
+
+// currencyFormat is an ISO 4217 currency code
+var currencyFormat = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// IsCurrency reports whether code is a well-formed ISO 4217 currency code,
+// e.g. "SEK"
+func IsCurrency(code string) bool {
+	return currencyFormat.MatchString(code)
+}
+
+// parseDecimal parses a decimal number with at most scale decimals into an
+// integer scaled by 10^scale
+func parseDecimal(s string, scale int) (int64, error) {
+	intPart, fracPart, neg, err := splitDecimal(s)
+	if err != nil {
+		return 0, err
+	}
+	if fracPart = strings.TrimRight(fracPart, "0"); len(fracPart) > scale {
+		return 0, ErrScale
+	}
+	return scaleDecimal(intPart, fracPart, neg, scale, s)
+}
+
+// parseDecimalRounded is parseDecimal rounding extra decimals half away from
+// zero instead of rejecting them
+func parseDecimalRounded(s string, scale int) (int64, error) {
+	intPart, fracPart, neg, err := splitDecimal(s)
+	if err != nil {
+		return 0, err
+	}
+	roundUp := false
+	if len(fracPart) > scale {
+		roundUp = fracPart[scale] >= '5'
+		fracPart = fracPart[:scale]
+	}
+	n, err := scaleDecimal(intPart, fracPart, neg, scale, s)
+	if err != nil || !roundUp {
+		return n, err
+	}
+	if neg {
+		return n - 1, nil
+	}
+	return n + 1, nil
+}
+
+func splitDecimal(s string) (intPart, fracPart string, neg bool, err error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "-") {
+		neg, s = true, s[1:]
+	} else {
+		s = strings.TrimPrefix(s, "+")
+	}
+	intPart, fracPart, _ = strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return "", "", false, fmt.Errorf("%w %q", ErrSyntax, s)
+	}
+	return intPart, fracPart, neg, nil
+}
+
+func scaleDecimal(intPart, fracPart string, neg bool, scale int, s string) (int64, error) {
+	fracPart += strings.Repeat("0", scale-len(fracPart))
+	n, err := strconv.ParseInt(intPart+fracPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w %q", ErrSyntax, s)
+	}
+	if neg {
+		n = -n
+	}
+	return n, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// divRound divides a by b, rounding half away from zero
+func divRound(a, b int64) int64 {
+	if b < 0 {
+		a, b = -a, -b
+	}
+	q, r := a/b, a%b
+	if 2*abs(r) >= b {
+		if a < 0 {
+			q--
+		} else {
+			q++
+		}
+	}
+	return q
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,69 @@
+package money
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Rate is a VAT rate in basis points (hundredths of a percent), e.g. 1200
+// for the 12% Swedish VAT on restaurant and takeaway food
+type Rate int64
+
+// ParseRate parses a percentage with at most two decimals, e.g. "12", "6" or
+// "25%"
+func ParseRate(s string) (Rate, error) {
+	bp, err := parseDecimal(strings.TrimSuffix(strings.TrimSpace(s), "%"), 2)
+	if err != nil {
+		return 0, err
+	}
+	if bp < 0 || bp > 100*100 {
+		return 0, errRateRange
+	}
+	return Rate(bp), nil
+}
+
+var errRateRange = errors.New("money: VAT rate must be between 0 and 100 percent")
+
+// Percent returns the rate in percent
+func (r Rate) Percent() float64 {
+	return float64(r) / 100
+}
+
+// String formats the rate in percent without trailing zeros, e.g. "12" or "6.5"
+func (r Rate) String() string {
+	return strconv.FormatFloat(r.Percent(), 'f', -1, 64)
+}
+
+// MarshalJSON writes the rate as a JSON number in percent
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalJSON reads a rate in percent
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	parsed, err := ParseRate(strings.Trim(string(data), `"`))
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// VAT is the breakdown of a price including VAT
+type VAT struct {
+	Rate         Rate   `json:"rate" swaggertype:"number" example:"12"` // percent
+	PriceExclVAT Amount `json:"price_excl_vat" swaggertype:"number" example:"115.18"`
+	Amount       Amount `json:"amount" swaggertype:"number" example:"13.82"`
+}
+
+// SplitVAT splits a price including VAT into the price excluding VAT, rounded
+// half away from zero to the minor unit, and the VAT amount. The two parts
+// always add up to the price.
+func (a Amount) SplitVAT(rate Rate) VAT {
+	excl := Amount(divRound(int64(a)*10000, 10000+int64(rate)))
+	return VAT{Rate: rate, PriceExclVAT: excl, Amount: a - excl}
+}
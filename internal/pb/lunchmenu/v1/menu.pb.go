@@ -0,0 +1,1212 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: lunchmenu/v1/menu.proto
+
+package lunchmenuv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type MenuItem struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	RestaurantId uint32                 `protobuf:"varint,2,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	Name         string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description  string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// Price including VAT, with at most two decimals.
+	Price       float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	Category    string                 `protobuf:"bytes,6,opt,name=category,proto3" json:"category,omitempty"`
+	IsAvailable bool                   `protobuf:"varint,7,opt,name=is_available,json=isAvailable,proto3" json:"is_available,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// ISO 4217 currency code of the price, e.g. SEK.
+	Currency string `protobuf:"bytes,10,opt,name=currency,proto3" json:"currency,omitempty"`
+	// VAT rate of the item's category in percent.
+	VatRate      float64 `protobuf:"fixed64,11,opt,name=vat_rate,json=vatRate,proto3" json:"vat_rate,omitempty"`
+	PriceExclVat float64 `protobuf:"fixed64,12,opt,name=price_excl_vat,json=priceExclVat,proto3" json:"price_excl_vat,omitempty"`
+	VatAmount    float64 `protobuf:"fixed64,13,opt,name=vat_amount,json=vatAmount,proto3" json:"vat_amount,omitempty"`
+	// Named price variants, e.g. the student price; the default one's price is price.
+	Prices []*PriceVariant `protobuf:"bytes,14,rep,name=prices,proto3" json:"prices,omitempty"`
+	// Variant whose price is in price, when a price tier was requested and the item has it.
+	PriceTier     string `protobuf:"bytes,15,opt,name=price_tier,json=priceTier,proto3" json:"price_tier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MenuItem) Reset() {
+	*x = MenuItem{}
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MenuItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MenuItem) ProtoMessage() {}
+
+func (x *MenuItem) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MenuItem.ProtoReflect.Descriptor instead.
+func (*MenuItem) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_menu_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MenuItem) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MenuItem) GetRestaurantId() uint32 {
+	if x != nil {
+		return x.RestaurantId
+	}
+	return 0
+}
+
+func (x *MenuItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MenuItem) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *MenuItem) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *MenuItem) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *MenuItem) GetIsAvailable() bool {
+	if x != nil {
+		return x.IsAvailable
+	}
+	return false
+}
+
+func (x *MenuItem) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *MenuItem) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *MenuItem) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *MenuItem) GetVatRate() float64 {
+	if x != nil {
+		return x.VatRate
+	}
+	return 0
+}
+
+func (x *MenuItem) GetPriceExclVat() float64 {
+	if x != nil {
+		return x.PriceExclVat
+	}
+	return 0
+}
+
+func (x *MenuItem) GetVatAmount() float64 {
+	if x != nil {
+		return x.VatAmount
+	}
+	return 0
+}
+
+func (x *MenuItem) GetPrices() []*PriceVariant {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+func (x *MenuItem) GetPriceTier() string {
+	if x != nil {
+		return x.PriceTier
+	}
+	return ""
+}
+
+type PriceVariant struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Lowercase name, e.g. student, employee or takeaway.
+	Tier string `protobuf:"bytes,1,opt,name=tier,proto3" json:"tier,omitempty"`
+	// Price including VAT, with at most two decimals.
+	Price         float64 `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`
+	IsDefault     bool    `protobuf:"varint,3,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceVariant) Reset() {
+	*x = PriceVariant{}
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceVariant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceVariant) ProtoMessage() {}
+
+func (x *PriceVariant) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceVariant.ProtoReflect.Descriptor instead.
+func (*PriceVariant) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_menu_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PriceVariant) GetTier() string {
+	if x != nil {
+		return x.Tier
+	}
+	return ""
+}
+
+func (x *PriceVariant) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *PriceVariant) GetIsDefault() bool {
+	if x != nil {
+		return x.IsDefault
+	}
+	return false
+}
+
+// PriceVariants wraps a list of variants so that an update can tell an empty
+// list from an unset one.
+type PriceVariants struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Variants      []*PriceVariant        `protobuf:"bytes,1,rep,name=variants,proto3" json:"variants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceVariants) Reset() {
+	*x = PriceVariants{}
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceVariants) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceVariants) ProtoMessage() {}
+
+func (x *PriceVariants) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceVariants.ProtoReflect.Descriptor instead.
+func (*PriceVariants) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_menu_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PriceVariants) GetVariants() []*PriceVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+type ListMenuItemsRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	RestaurantId uint32                 `protobuf:"varint,1,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	// Defaults to 10.
+	Limit  int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Show the prices of this variant; items without it keep their default price.
+	PriceTier     string `protobuf:"bytes,4,opt,name=price_tier,json=priceTier,proto3" json:"price_tier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMenuItemsRequest) Reset() {
+	*x = ListMenuItemsRequest{}
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMenuItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMenuItemsRequest) ProtoMessage() {}
+
+func (x *ListMenuItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMenuItemsRequest.ProtoReflect.Descriptor instead.
+func (*ListMenuItemsRequest) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_menu_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListMenuItemsRequest) GetRestaurantId() uint32 {
+	if x != nil {
+		return x.RestaurantId
+	}
+	return 0
+}
+
+func (x *ListMenuItemsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListMenuItemsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListMenuItemsRequest) GetPriceTier() string {
+	if x != nil {
+		return x.PriceTier
+	}
+	return ""
+}
+
+type ListMenuItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MenuItems     []*MenuItem            `protobuf:"bytes,1,rep,name=menu_items,json=menuItems,proto3" json:"menu_items,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMenuItemsResponse) Reset() {
+	*x = ListMenuItemsResponse{}
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMenuItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMenuItemsResponse) ProtoMessage() {}
+
+func (x *ListMenuItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMenuItemsResponse.ProtoReflect.Descriptor instead.
+func (*ListMenuItemsResponse) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_menu_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListMenuItemsResponse) GetMenuItems() []*MenuItem {
+	if x != nil {
+		return x.MenuItems
+	}
+	return nil
+}
+
+func (x *ListMenuItemsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetMenuItemRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Show the price of this variant, if the item has it.
+	PriceTier     string `protobuf:"bytes,2,opt,name=price_tier,json=priceTier,proto3" json:"price_tier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMenuItemRequest) Reset() {
+	*x = GetMenuItemRequest{}
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMenuItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMenuItemRequest) ProtoMessage() {}
+
+func (x *GetMenuItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMenuItemRequest.ProtoReflect.Descriptor instead.
+func (*GetMenuItemRequest) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_menu_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetMenuItemRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetMenuItemRequest) GetPriceTier() string {
+	if x != nil {
+		return x.PriceTier
+	}
+	return ""
+}
+
+type GetMenuItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MenuItem      *MenuItem              `protobuf:"bytes,1,opt,name=menu_item,json=menuItem,proto3" json:"menu_item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMenuItemResponse) Reset() {
+	*x = GetMenuItemResponse{}
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMenuItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMenuItemResponse) ProtoMessage() {}
+
+func (x *GetMenuItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMenuItemResponse.ProtoReflect.Descriptor instead.
+func (*GetMenuItemResponse) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_menu_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetMenuItemResponse) GetMenuItem() *MenuItem {
+	if x != nil {
+		return x.MenuItem
+	}
+	return nil
+}
+
+type CreateMenuItemRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	RestaurantId uint32                 `protobuf:"varint,1,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	Name         string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description  string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Price including VAT, with at most two decimals.
+	Price    float64 `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	Category string  `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
+	// Defaults to the configured currency.
+	Currency string `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Optional variants, exactly one of them the default; price may then be 0.
+	Prices        []*PriceVariant `protobuf:"bytes,7,rep,name=prices,proto3" json:"prices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMenuItemRequest) Reset() {
+	*x = CreateMenuItemRequest{}
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMenuItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMenuItemRequest) ProtoMessage() {}
+
+func (x *CreateMenuItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMenuItemRequest.ProtoReflect.Descriptor instead.
+func (*CreateMenuItemRequest) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_menu_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateMenuItemRequest) GetRestaurantId() uint32 {
+	if x != nil {
+		return x.RestaurantId
+	}
+	return 0
+}
+
+func (x *CreateMenuItemRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateMenuItemRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateMenuItemRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *CreateMenuItemRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CreateMenuItemRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreateMenuItemRequest) GetPrices() []*PriceVariant {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+type CreateMenuItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MenuItem      *MenuItem              `protobuf:"bytes,1,opt,name=menu_item,json=menuItem,proto3" json:"menu_item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMenuItemResponse) Reset() {
+	*x = CreateMenuItemResponse{}
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMenuItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMenuItemResponse) ProtoMessage() {}
+
+func (x *CreateMenuItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMenuItemResponse.ProtoReflect.Descriptor instead.
+func (*CreateMenuItemResponse) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_menu_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateMenuItemResponse) GetMenuItem() *MenuItem {
+	if x != nil {
+		return x.MenuItem
+	}
+	return nil
+}
+
+type UpdateMenuItemRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	RestaurantId *uint32                `protobuf:"varint,2,opt,name=restaurant_id,json=restaurantId,proto3,oneof" json:"restaurant_id,omitempty"`
+	Name         *string                `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Description  *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Price        *float64               `protobuf:"fixed64,5,opt,name=price,proto3,oneof" json:"price,omitempty"`
+	Category     *string                `protobuf:"bytes,6,opt,name=category,proto3,oneof" json:"category,omitempty"`
+	IsAvailable  *bool                  `protobuf:"varint,7,opt,name=is_available,json=isAvailable,proto3,oneof" json:"is_available,omitempty"`
+	Currency     *string                `protobuf:"bytes,8,opt,name=currency,proto3,oneof" json:"currency,omitempty"`
+	// Replaces all variants; an empty list removes them.
+	Prices        *PriceVariants `protobuf:"bytes,9,opt,name=prices,proto3" json:"prices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMenuItemRequest) Reset() {
+	*x = UpdateMenuItemRequest{}
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMenuItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMenuItemRequest) ProtoMessage() {}
+
+func (x *UpdateMenuItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMenuItemRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMenuItemRequest) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_menu_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateMenuItemRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateMenuItemRequest) GetRestaurantId() uint32 {
+	if x != nil && x.RestaurantId != nil {
+		return *x.RestaurantId
+	}
+	return 0
+}
+
+func (x *UpdateMenuItemRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateMenuItemRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateMenuItemRequest) GetPrice() float64 {
+	if x != nil && x.Price != nil {
+		return *x.Price
+	}
+	return 0
+}
+
+func (x *UpdateMenuItemRequest) GetCategory() string {
+	if x != nil && x.Category != nil {
+		return *x.Category
+	}
+	return ""
+}
+
+func (x *UpdateMenuItemRequest) GetIsAvailable() bool {
+	if x != nil && x.IsAvailable != nil {
+		return *x.IsAvailable
+	}
+	return false
+}
+
+func (x *UpdateMenuItemRequest) GetCurrency() string {
+	if x != nil && x.Currency != nil {
+		return *x.Currency
+	}
+	return ""
+}
+
+func (x *UpdateMenuItemRequest) GetPrices() *PriceVariants {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+type UpdateMenuItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MenuItem      *MenuItem              `protobuf:"bytes,1,opt,name=menu_item,json=menuItem,proto3" json:"menu_item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMenuItemResponse) Reset() {
+	*x = UpdateMenuItemResponse{}
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMenuItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMenuItemResponse) ProtoMessage() {}
+
+func (x *UpdateMenuItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMenuItemResponse.ProtoReflect.Descriptor instead.
+func (*UpdateMenuItemResponse) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_menu_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateMenuItemResponse) GetMenuItem() *MenuItem {
+	if x != nil {
+		return x.MenuItem
+	}
+	return nil
+}
+
+type DeleteMenuItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMenuItemRequest) Reset() {
+	*x = DeleteMenuItemRequest{}
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMenuItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMenuItemRequest) ProtoMessage() {}
+
+func (x *DeleteMenuItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMenuItemRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMenuItemRequest) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_menu_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteMenuItemRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteMenuItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMenuItemResponse) Reset() {
+	*x = DeleteMenuItemResponse{}
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMenuItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMenuItemResponse) ProtoMessage() {}
+
+func (x *DeleteMenuItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMenuItemResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMenuItemResponse) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_menu_proto_rawDescGZIP(), []int{12}
+}
+
+type WatchMenuChangesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only changes of restaurants in this region; empty for all.
+	Region string `protobuf:"bytes,1,opt,name=region,proto3" json:"region,omitempty"`
+	// Only changes of this restaurant; 0 for all.
+	RestaurantId uint32 `protobuf:"varint,2,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	// Resume after this change ID; 0 streams new changes only.
+	LastChangeId  uint32 `protobuf:"varint,3,opt,name=last_change_id,json=lastChangeId,proto3" json:"last_change_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchMenuChangesRequest) Reset() {
+	*x = WatchMenuChangesRequest{}
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchMenuChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchMenuChangesRequest) ProtoMessage() {}
+
+func (x *WatchMenuChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchMenuChangesRequest.ProtoReflect.Descriptor instead.
+func (*WatchMenuChangesRequest) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_menu_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WatchMenuChangesRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *WatchMenuChangesRequest) GetRestaurantId() uint32 {
+	if x != nil {
+		return x.RestaurantId
+	}
+	return 0
+}
+
+func (x *WatchMenuChangesRequest) GetLastChangeId() uint32 {
+	if x != nil {
+		return x.LastChangeId
+	}
+	return 0
+}
+
+type WatchMenuChangesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the change, increasing; pass the last one received as last_change_id to resume.
+	Id uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// menu_item.created, menu_item.updated or menu_item.deleted
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Region        string                 `protobuf:"bytes,3,opt,name=region,proto3" json:"region,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	MenuItem      *MenuItem              `protobuf:"bytes,5,opt,name=menu_item,json=menuItem,proto3" json:"menu_item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchMenuChangesResponse) Reset() {
+	*x = WatchMenuChangesResponse{}
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchMenuChangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchMenuChangesResponse) ProtoMessage() {}
+
+func (x *WatchMenuChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_menu_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchMenuChangesResponse.ProtoReflect.Descriptor instead.
+func (*WatchMenuChangesResponse) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_menu_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *WatchMenuChangesResponse) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *WatchMenuChangesResponse) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *WatchMenuChangesResponse) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *WatchMenuChangesResponse) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *WatchMenuChangesResponse) GetMenuItem() *MenuItem {
+	if x != nil {
+		return x.MenuItem
+	}
+	return nil
+}
+
+var File_lunchmenu_v1_menu_proto protoreflect.FileDescriptor
+
+const file_lunchmenu_v1_menu_proto_rawDesc = "" +
+	"\n" +
+	"\x17lunchmenu/v1/menu.proto\x12\flunchmenu.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8f\x04\n" +
+	"\bMenuItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12#\n" +
+	"\rrestaurant_id\x18\x02 \x01(\rR\frestaurantId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x01R\x05price\x12\x1a\n" +
+	"\bcategory\x18\x06 \x01(\tR\bcategory\x12!\n" +
+	"\fis_available\x18\a \x01(\bR\visAvailable\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1a\n" +
+	"\bcurrency\x18\n" +
+	" \x01(\tR\bcurrency\x12\x19\n" +
+	"\bvat_rate\x18\v \x01(\x01R\avatRate\x12$\n" +
+	"\x0eprice_excl_vat\x18\f \x01(\x01R\fpriceExclVat\x12\x1d\n" +
+	"\n" +
+	"vat_amount\x18\r \x01(\x01R\tvatAmount\x122\n" +
+	"\x06prices\x18\x0e \x03(\v2\x1a.lunchmenu.v1.PriceVariantR\x06prices\x12\x1d\n" +
+	"\n" +
+	"price_tier\x18\x0f \x01(\tR\tpriceTier\"W\n" +
+	"\fPriceVariant\x12\x12\n" +
+	"\x04tier\x18\x01 \x01(\tR\x04tier\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\x01R\x05price\x12\x1d\n" +
+	"\n" +
+	"is_default\x18\x03 \x01(\bR\tisDefault\"G\n" +
+	"\rPriceVariants\x126\n" +
+	"\bvariants\x18\x01 \x03(\v2\x1a.lunchmenu.v1.PriceVariantR\bvariants\"\x88\x01\n" +
+	"\x14ListMenuItemsRequest\x12#\n" +
+	"\rrestaurant_id\x18\x01 \x01(\rR\frestaurantId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x1d\n" +
+	"\n" +
+	"price_tier\x18\x04 \x01(\tR\tpriceTier\"d\n" +
+	"\x15ListMenuItemsResponse\x125\n" +
+	"\n" +
+	"menu_items\x18\x01 \x03(\v2\x16.lunchmenu.v1.MenuItemR\tmenuItems\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"C\n" +
+	"\x12GetMenuItemRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1d\n" +
+	"\n" +
+	"price_tier\x18\x02 \x01(\tR\tpriceTier\"J\n" +
+	"\x13GetMenuItemResponse\x123\n" +
+	"\tmenu_item\x18\x01 \x01(\v2\x16.lunchmenu.v1.MenuItemR\bmenuItem\"\xf4\x01\n" +
+	"\x15CreateMenuItemRequest\x12#\n" +
+	"\rrestaurant_id\x18\x01 \x01(\rR\frestaurantId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x01R\x05price\x12\x1a\n" +
+	"\bcategory\x18\x05 \x01(\tR\bcategory\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x122\n" +
+	"\x06prices\x18\a \x03(\v2\x1a.lunchmenu.v1.PriceVariantR\x06prices\"M\n" +
+	"\x16CreateMenuItemResponse\x123\n" +
+	"\tmenu_item\x18\x01 \x01(\v2\x16.lunchmenu.v1.MenuItemR\bmenuItem\"\xab\x03\n" +
+	"\x15UpdateMenuItemRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12(\n" +
+	"\rrestaurant_id\x18\x02 \x01(\rH\x00R\frestaurantId\x88\x01\x01\x12\x17\n" +
+	"\x04name\x18\x03 \x01(\tH\x01R\x04name\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x02R\vdescription\x88\x01\x01\x12\x19\n" +
+	"\x05price\x18\x05 \x01(\x01H\x03R\x05price\x88\x01\x01\x12\x1f\n" +
+	"\bcategory\x18\x06 \x01(\tH\x04R\bcategory\x88\x01\x01\x12&\n" +
+	"\fis_available\x18\a \x01(\bH\x05R\visAvailable\x88\x01\x01\x12\x1f\n" +
+	"\bcurrency\x18\b \x01(\tH\x06R\bcurrency\x88\x01\x01\x123\n" +
+	"\x06prices\x18\t \x01(\v2\x1b.lunchmenu.v1.PriceVariantsR\x06pricesB\x10\n" +
+	"\x0e_restaurant_idB\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_descriptionB\b\n" +
+	"\x06_priceB\v\n" +
+	"\t_categoryB\x0f\n" +
+	"\r_is_availableB\v\n" +
+	"\t_currency\"M\n" +
+	"\x16UpdateMenuItemResponse\x123\n" +
+	"\tmenu_item\x18\x01 \x01(\v2\x16.lunchmenu.v1.MenuItemR\bmenuItem\"'\n" +
+	"\x15DeleteMenuItemRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"\x18\n" +
+	"\x16DeleteMenuItemResponse\"|\n" +
+	"\x17WatchMenuChangesRequest\x12\x16\n" +
+	"\x06region\x18\x01 \x01(\tR\x06region\x12#\n" +
+	"\rrestaurant_id\x18\x02 \x01(\rR\frestaurantId\x12$\n" +
+	"\x0elast_change_id\x18\x03 \x01(\rR\flastChangeId\"\xc8\x01\n" +
+	"\x18WatchMenuChangesResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06region\x18\x03 \x01(\tR\x06region\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x123\n" +
+	"\tmenu_item\x18\x05 \x01(\v2\x16.lunchmenu.v1.MenuItemR\bmenuItem2\xb7\x04\n" +
+	"\vMenuService\x12X\n" +
+	"\rListMenuItems\x12\".lunchmenu.v1.ListMenuItemsRequest\x1a#.lunchmenu.v1.ListMenuItemsResponse\x12R\n" +
+	"\vGetMenuItem\x12 .lunchmenu.v1.GetMenuItemRequest\x1a!.lunchmenu.v1.GetMenuItemResponse\x12[\n" +
+	"\x0eCreateMenuItem\x12#.lunchmenu.v1.CreateMenuItemRequest\x1a$.lunchmenu.v1.CreateMenuItemResponse\x12[\n" +
+	"\x0eUpdateMenuItem\x12#.lunchmenu.v1.UpdateMenuItemRequest\x1a$.lunchmenu.v1.UpdateMenuItemResponse\x12[\n" +
+	"\x0eDeleteMenuItem\x12#.lunchmenu.v1.DeleteMenuItemRequest\x1a$.lunchmenu.v1.DeleteMenuItemResponse\x12c\n" +
+	"\x10WatchMenuChanges\x12%.lunchmenu.v1.WatchMenuChangesRequest\x1a&.lunchmenu.v1.WatchMenuChangesResponse0\x01B1Z/lunch_menu/internal/pb/lunchmenu/v1;lunchmenuv1b\x06proto3"
+
+var (
+	file_lunchmenu_v1_menu_proto_rawDescOnce sync.Once
+	file_lunchmenu_v1_menu_proto_rawDescData []byte
+)
+
+func file_lunchmenu_v1_menu_proto_rawDescGZIP() []byte {
+	file_lunchmenu_v1_menu_proto_rawDescOnce.Do(func() {
+		file_lunchmenu_v1_menu_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_lunchmenu_v1_menu_proto_rawDesc), len(file_lunchmenu_v1_menu_proto_rawDesc)))
+	})
+	return file_lunchmenu_v1_menu_proto_rawDescData
+}
+
+var file_lunchmenu_v1_menu_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_lunchmenu_v1_menu_proto_goTypes = []any{
+	(*MenuItem)(nil),                 // 0: lunchmenu.v1.MenuItem
+	(*PriceVariant)(nil),             // 1: lunchmenu.v1.PriceVariant
+	(*PriceVariants)(nil),            // 2: lunchmenu.v1.PriceVariants
+	(*ListMenuItemsRequest)(nil),     // 3: lunchmenu.v1.ListMenuItemsRequest
+	(*ListMenuItemsResponse)(nil),    // 4: lunchmenu.v1.ListMenuItemsResponse
+	(*GetMenuItemRequest)(nil),       // 5: lunchmenu.v1.GetMenuItemRequest
+	(*GetMenuItemResponse)(nil),      // 6: lunchmenu.v1.GetMenuItemResponse
+	(*CreateMenuItemRequest)(nil),    // 7: lunchmenu.v1.CreateMenuItemRequest
+	(*CreateMenuItemResponse)(nil),   // 8: lunchmenu.v1.CreateMenuItemResponse
+	(*UpdateMenuItemRequest)(nil),    // 9: lunchmenu.v1.UpdateMenuItemRequest
+	(*UpdateMenuItemResponse)(nil),   // 10: lunchmenu.v1.UpdateMenuItemResponse
+	(*DeleteMenuItemRequest)(nil),    // 11: lunchmenu.v1.DeleteMenuItemRequest
+	(*DeleteMenuItemResponse)(nil),   // 12: lunchmenu.v1.DeleteMenuItemResponse
+	(*WatchMenuChangesRequest)(nil),  // 13: lunchmenu.v1.WatchMenuChangesRequest
+	(*WatchMenuChangesResponse)(nil), // 14: lunchmenu.v1.WatchMenuChangesResponse
+	(*timestamppb.Timestamp)(nil),    // 15: google.protobuf.Timestamp
+}
+var file_lunchmenu_v1_menu_proto_depIdxs = []int32{
+	15, // 0: lunchmenu.v1.MenuItem.created_at:type_name -> google.protobuf.Timestamp
+	15, // 1: lunchmenu.v1.MenuItem.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 2: lunchmenu.v1.MenuItem.prices:type_name -> lunchmenu.v1.PriceVariant
+	1,  // 3: lunchmenu.v1.PriceVariants.variants:type_name -> lunchmenu.v1.PriceVariant
+	0,  // 4: lunchmenu.v1.ListMenuItemsResponse.menu_items:type_name -> lunchmenu.v1.MenuItem
+	0,  // 5: lunchmenu.v1.GetMenuItemResponse.menu_item:type_name -> lunchmenu.v1.MenuItem
+	1,  // 6: lunchmenu.v1.CreateMenuItemRequest.prices:type_name -> lunchmenu.v1.PriceVariant
+	0,  // 7: lunchmenu.v1.CreateMenuItemResponse.menu_item:type_name -> lunchmenu.v1.MenuItem
+	2,  // 8: lunchmenu.v1.UpdateMenuItemRequest.prices:type_name -> lunchmenu.v1.PriceVariants
+	0,  // 9: lunchmenu.v1.UpdateMenuItemResponse.menu_item:type_name -> lunchmenu.v1.MenuItem
+	15, // 10: lunchmenu.v1.WatchMenuChangesResponse.occurred_at:type_name -> google.protobuf.Timestamp
+	0,  // 11: lunchmenu.v1.WatchMenuChangesResponse.menu_item:type_name -> lunchmenu.v1.MenuItem
+	3,  // 12: lunchmenu.v1.MenuService.ListMenuItems:input_type -> lunchmenu.v1.ListMenuItemsRequest
+	5,  // 13: lunchmenu.v1.MenuService.GetMenuItem:input_type -> lunchmenu.v1.GetMenuItemRequest
+	7,  // 14: lunchmenu.v1.MenuService.CreateMenuItem:input_type -> lunchmenu.v1.CreateMenuItemRequest
+	9,  // 15: lunchmenu.v1.MenuService.UpdateMenuItem:input_type -> lunchmenu.v1.UpdateMenuItemRequest
+	11, // 16: lunchmenu.v1.MenuService.DeleteMenuItem:input_type -> lunchmenu.v1.DeleteMenuItemRequest
+	13, // 17: lunchmenu.v1.MenuService.WatchMenuChanges:input_type -> lunchmenu.v1.WatchMenuChangesRequest
+	4,  // 18: lunchmenu.v1.MenuService.ListMenuItems:output_type -> lunchmenu.v1.ListMenuItemsResponse
+	6,  // 19: lunchmenu.v1.MenuService.GetMenuItem:output_type -> lunchmenu.v1.GetMenuItemResponse
+	8,  // 20: lunchmenu.v1.MenuService.CreateMenuItem:output_type -> lunchmenu.v1.CreateMenuItemResponse
+	10, // 21: lunchmenu.v1.MenuService.UpdateMenuItem:output_type -> lunchmenu.v1.UpdateMenuItemResponse
+	12, // 22: lunchmenu.v1.MenuService.DeleteMenuItem:output_type -> lunchmenu.v1.DeleteMenuItemResponse
+	14, // 23: lunchmenu.v1.MenuService.WatchMenuChanges:output_type -> lunchmenu.v1.WatchMenuChangesResponse
+	18, // [18:24] is the sub-list for method output_type
+	12, // [12:18] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_lunchmenu_v1_menu_proto_init() }
+func file_lunchmenu_v1_menu_proto_init() {
+	if File_lunchmenu_v1_menu_proto != nil {
+		return
+	}
+	file_lunchmenu_v1_menu_proto_msgTypes[9].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_lunchmenu_v1_menu_proto_rawDesc), len(file_lunchmenu_v1_menu_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_lunchmenu_v1_menu_proto_goTypes,
+		DependencyIndexes: file_lunchmenu_v1_menu_proto_depIdxs,
+		MessageInfos:      file_lunchmenu_v1_menu_proto_msgTypes,
+	}.Build()
+	File_lunchmenu_v1_menu_proto = out.File
+	file_lunchmenu_v1_menu_proto_goTypes = nil
+	file_lunchmenu_v1_menu_proto_depIdxs = nil
+}
@@ -0,0 +1,922 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: lunchmenu/v1/restaurant.proto
+
+package lunchmenuv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Restaurant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Address       string                 `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	Latitude      float64                `protobuf:"fixed64,5,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,6,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Homepage      string                 `protobuf:"bytes,7,opt,name=homepage,proto3" json:"homepage,omitempty"`
+	Region        string                 `protobuf:"bytes,8,opt,name=region,proto3" json:"region,omitempty"`
+	Phone         string                 `protobuf:"bytes,9,opt,name=phone,proto3" json:"phone,omitempty"`
+	Email         string                 `protobuf:"bytes,10,opt,name=email,proto3" json:"email,omitempty"`
+	IsActive      bool                   `protobuf:"varint,11,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Restaurant) Reset() {
+	*x = Restaurant{}
+	mi := &file_lunchmenu_v1_restaurant_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Restaurant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Restaurant) ProtoMessage() {}
+
+func (x *Restaurant) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_restaurant_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Restaurant.ProtoReflect.Descriptor instead.
+func (*Restaurant) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_restaurant_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Restaurant) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Restaurant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Restaurant) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Restaurant) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Restaurant) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Restaurant) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *Restaurant) GetHomepage() string {
+	if x != nil {
+		return x.Homepage
+	}
+	return ""
+}
+
+func (x *Restaurant) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Restaurant) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *Restaurant) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Restaurant) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *Restaurant) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Restaurant) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListRestaurantsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Defaults to 10.
+	Limit         int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRestaurantsRequest) Reset() {
+	*x = ListRestaurantsRequest{}
+	mi := &file_lunchmenu_v1_restaurant_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRestaurantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRestaurantsRequest) ProtoMessage() {}
+
+func (x *ListRestaurantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_restaurant_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRestaurantsRequest.ProtoReflect.Descriptor instead.
+func (*ListRestaurantsRequest) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_restaurant_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListRestaurantsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListRestaurantsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListRestaurantsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Restaurants   []*Restaurant          `protobuf:"bytes,1,rep,name=restaurants,proto3" json:"restaurants,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRestaurantsResponse) Reset() {
+	*x = ListRestaurantsResponse{}
+	mi := &file_lunchmenu_v1_restaurant_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRestaurantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRestaurantsResponse) ProtoMessage() {}
+
+func (x *ListRestaurantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_restaurant_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRestaurantsResponse.ProtoReflect.Descriptor instead.
+func (*ListRestaurantsResponse) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_restaurant_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListRestaurantsResponse) GetRestaurants() []*Restaurant {
+	if x != nil {
+		return x.Restaurants
+	}
+	return nil
+}
+
+func (x *ListRestaurantsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetRestaurantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRestaurantRequest) Reset() {
+	*x = GetRestaurantRequest{}
+	mi := &file_lunchmenu_v1_restaurant_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRestaurantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRestaurantRequest) ProtoMessage() {}
+
+func (x *GetRestaurantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_restaurant_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRestaurantRequest.ProtoReflect.Descriptor instead.
+func (*GetRestaurantRequest) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_restaurant_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetRestaurantRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetRestaurantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Restaurant    *Restaurant            `protobuf:"bytes,1,opt,name=restaurant,proto3" json:"restaurant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRestaurantResponse) Reset() {
+	*x = GetRestaurantResponse{}
+	mi := &file_lunchmenu_v1_restaurant_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRestaurantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRestaurantResponse) ProtoMessage() {}
+
+func (x *GetRestaurantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_restaurant_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRestaurantResponse.ProtoReflect.Descriptor instead.
+func (*GetRestaurantResponse) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_restaurant_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetRestaurantResponse) GetRestaurant() *Restaurant {
+	if x != nil {
+		return x.Restaurant
+	}
+	return nil
+}
+
+type CreateRestaurantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Address       string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Latitude      float64                `protobuf:"fixed64,4,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,5,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Homepage      string                 `protobuf:"bytes,6,opt,name=homepage,proto3" json:"homepage,omitempty"`
+	Region        string                 `protobuf:"bytes,7,opt,name=region,proto3" json:"region,omitempty"`
+	Phone         string                 `protobuf:"bytes,8,opt,name=phone,proto3" json:"phone,omitempty"`
+	Email         string                 `protobuf:"bytes,9,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRestaurantRequest) Reset() {
+	*x = CreateRestaurantRequest{}
+	mi := &file_lunchmenu_v1_restaurant_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRestaurantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRestaurantRequest) ProtoMessage() {}
+
+func (x *CreateRestaurantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_restaurant_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRestaurantRequest.ProtoReflect.Descriptor instead.
+func (*CreateRestaurantRequest) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_restaurant_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateRestaurantRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateRestaurantRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateRestaurantRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *CreateRestaurantRequest) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *CreateRestaurantRequest) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *CreateRestaurantRequest) GetHomepage() string {
+	if x != nil {
+		return x.Homepage
+	}
+	return ""
+}
+
+func (x *CreateRestaurantRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *CreateRestaurantRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *CreateRestaurantRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type CreateRestaurantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Restaurant    *Restaurant            `protobuf:"bytes,1,opt,name=restaurant,proto3" json:"restaurant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRestaurantResponse) Reset() {
+	*x = CreateRestaurantResponse{}
+	mi := &file_lunchmenu_v1_restaurant_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRestaurantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRestaurantResponse) ProtoMessage() {}
+
+func (x *CreateRestaurantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_restaurant_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRestaurantResponse.ProtoReflect.Descriptor instead.
+func (*CreateRestaurantResponse) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_restaurant_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateRestaurantResponse) GetRestaurant() *Restaurant {
+	if x != nil {
+		return x.Restaurant
+	}
+	return nil
+}
+
+type UpdateRestaurantRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Description *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Address     *string                `protobuf:"bytes,4,opt,name=address,proto3,oneof" json:"address,omitempty"`
+	// latitude and longitude must be set together.
+	Latitude      *float64 `protobuf:"fixed64,5,opt,name=latitude,proto3,oneof" json:"latitude,omitempty"`
+	Longitude     *float64 `protobuf:"fixed64,6,opt,name=longitude,proto3,oneof" json:"longitude,omitempty"`
+	Homepage      *string  `protobuf:"bytes,7,opt,name=homepage,proto3,oneof" json:"homepage,omitempty"`
+	Region        *string  `protobuf:"bytes,8,opt,name=region,proto3,oneof" json:"region,omitempty"`
+	Phone         *string  `protobuf:"bytes,9,opt,name=phone,proto3,oneof" json:"phone,omitempty"`
+	Email         *string  `protobuf:"bytes,10,opt,name=email,proto3,oneof" json:"email,omitempty"`
+	IsActive      *bool    `protobuf:"varint,11,opt,name=is_active,json=isActive,proto3,oneof" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRestaurantRequest) Reset() {
+	*x = UpdateRestaurantRequest{}
+	mi := &file_lunchmenu_v1_restaurant_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRestaurantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRestaurantRequest) ProtoMessage() {}
+
+func (x *UpdateRestaurantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_restaurant_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRestaurantRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRestaurantRequest) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_restaurant_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateRestaurantRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateRestaurantRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateRestaurantRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateRestaurantRequest) GetAddress() string {
+	if x != nil && x.Address != nil {
+		return *x.Address
+	}
+	return ""
+}
+
+func (x *UpdateRestaurantRequest) GetLatitude() float64 {
+	if x != nil && x.Latitude != nil {
+		return *x.Latitude
+	}
+	return 0
+}
+
+func (x *UpdateRestaurantRequest) GetLongitude() float64 {
+	if x != nil && x.Longitude != nil {
+		return *x.Longitude
+	}
+	return 0
+}
+
+func (x *UpdateRestaurantRequest) GetHomepage() string {
+	if x != nil && x.Homepage != nil {
+		return *x.Homepage
+	}
+	return ""
+}
+
+func (x *UpdateRestaurantRequest) GetRegion() string {
+	if x != nil && x.Region != nil {
+		return *x.Region
+	}
+	return ""
+}
+
+func (x *UpdateRestaurantRequest) GetPhone() string {
+	if x != nil && x.Phone != nil {
+		return *x.Phone
+	}
+	return ""
+}
+
+func (x *UpdateRestaurantRequest) GetEmail() string {
+	if x != nil && x.Email != nil {
+		return *x.Email
+	}
+	return ""
+}
+
+func (x *UpdateRestaurantRequest) GetIsActive() bool {
+	if x != nil && x.IsActive != nil {
+		return *x.IsActive
+	}
+	return false
+}
+
+type UpdateRestaurantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Restaurant    *Restaurant            `protobuf:"bytes,1,opt,name=restaurant,proto3" json:"restaurant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRestaurantResponse) Reset() {
+	*x = UpdateRestaurantResponse{}
+	mi := &file_lunchmenu_v1_restaurant_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRestaurantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRestaurantResponse) ProtoMessage() {}
+
+func (x *UpdateRestaurantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_restaurant_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRestaurantResponse.ProtoReflect.Descriptor instead.
+func (*UpdateRestaurantResponse) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_restaurant_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateRestaurantResponse) GetRestaurant() *Restaurant {
+	if x != nil {
+		return x.Restaurant
+	}
+	return nil
+}
+
+type DeleteRestaurantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRestaurantRequest) Reset() {
+	*x = DeleteRestaurantRequest{}
+	mi := &file_lunchmenu_v1_restaurant_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRestaurantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRestaurantRequest) ProtoMessage() {}
+
+func (x *DeleteRestaurantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_restaurant_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRestaurantRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRestaurantRequest) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_restaurant_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteRestaurantRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteRestaurantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRestaurantResponse) Reset() {
+	*x = DeleteRestaurantResponse{}
+	mi := &file_lunchmenu_v1_restaurant_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRestaurantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRestaurantResponse) ProtoMessage() {}
+
+func (x *DeleteRestaurantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_restaurant_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRestaurantResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRestaurantResponse) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_restaurant_proto_rawDescGZIP(), []int{10}
+}
+
+var File_lunchmenu_v1_restaurant_proto protoreflect.FileDescriptor
+
+const file_lunchmenu_v1_restaurant_proto_rawDesc = "" +
+	"\n" +
+	"\x1dlunchmenu/v1/restaurant.proto\x12\flunchmenu.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x99\x03\n" +
+	"\n" +
+	"Restaurant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x18\n" +
+	"\aaddress\x18\x04 \x01(\tR\aaddress\x12\x1a\n" +
+	"\blatitude\x18\x05 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x06 \x01(\x01R\tlongitude\x12\x1a\n" +
+	"\bhomepage\x18\a \x01(\tR\bhomepage\x12\x16\n" +
+	"\x06region\x18\b \x01(\tR\x06region\x12\x14\n" +
+	"\x05phone\x18\t \x01(\tR\x05phone\x12\x14\n" +
+	"\x05email\x18\n" +
+	" \x01(\tR\x05email\x12\x1b\n" +
+	"\tis_active\x18\v \x01(\bR\bisActive\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"F\n" +
+	"\x16ListRestaurantsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\"k\n" +
+	"\x17ListRestaurantsResponse\x12:\n" +
+	"\vrestaurants\x18\x01 \x03(\v2\x18.lunchmenu.v1.RestaurantR\vrestaurants\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"&\n" +
+	"\x14GetRestaurantRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"Q\n" +
+	"\x15GetRestaurantResponse\x128\n" +
+	"\n" +
+	"restaurant\x18\x01 \x01(\v2\x18.lunchmenu.v1.RestaurantR\n" +
+	"restaurant\"\x83\x02\n" +
+	"\x17CreateRestaurantRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\x12\x1a\n" +
+	"\blatitude\x18\x04 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x05 \x01(\x01R\tlongitude\x12\x1a\n" +
+	"\bhomepage\x18\x06 \x01(\tR\bhomepage\x12\x16\n" +
+	"\x06region\x18\a \x01(\tR\x06region\x12\x14\n" +
+	"\x05phone\x18\b \x01(\tR\x05phone\x12\x14\n" +
+	"\x05email\x18\t \x01(\tR\x05email\"T\n" +
+	"\x18CreateRestaurantResponse\x128\n" +
+	"\n" +
+	"restaurant\x18\x01 \x01(\v2\x18.lunchmenu.v1.RestaurantR\n" +
+	"restaurant\"\xdc\x03\n" +
+	"\x17UpdateRestaurantRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12\x1d\n" +
+	"\aaddress\x18\x04 \x01(\tH\x02R\aaddress\x88\x01\x01\x12\x1f\n" +
+	"\blatitude\x18\x05 \x01(\x01H\x03R\blatitude\x88\x01\x01\x12!\n" +
+	"\tlongitude\x18\x06 \x01(\x01H\x04R\tlongitude\x88\x01\x01\x12\x1f\n" +
+	"\bhomepage\x18\a \x01(\tH\x05R\bhomepage\x88\x01\x01\x12\x1b\n" +
+	"\x06region\x18\b \x01(\tH\x06R\x06region\x88\x01\x01\x12\x19\n" +
+	"\x05phone\x18\t \x01(\tH\aR\x05phone\x88\x01\x01\x12\x19\n" +
+	"\x05email\x18\n" +
+	" \x01(\tH\bR\x05email\x88\x01\x01\x12 \n" +
+	"\tis_active\x18\v \x01(\bH\tR\bisActive\x88\x01\x01B\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_descriptionB\n" +
+	"\n" +
+	"\b_addressB\v\n" +
+	"\t_latitudeB\f\n" +
+	"\n" +
+	"_longitudeB\v\n" +
+	"\t_homepageB\t\n" +
+	"\a_regionB\b\n" +
+	"\x06_phoneB\b\n" +
+	"\x06_emailB\f\n" +
+	"\n" +
+	"_is_active\"T\n" +
+	"\x18UpdateRestaurantResponse\x128\n" +
+	"\n" +
+	"restaurant\x18\x01 \x01(\v2\x18.lunchmenu.v1.RestaurantR\n" +
+	"restaurant\")\n" +
+	"\x17DeleteRestaurantRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"\x1a\n" +
+	"\x18DeleteRestaurantResponse2\xf6\x03\n" +
+	"\x11RestaurantService\x12^\n" +
+	"\x0fListRestaurants\x12$.lunchmenu.v1.ListRestaurantsRequest\x1a%.lunchmenu.v1.ListRestaurantsResponse\x12X\n" +
+	"\rGetRestaurant\x12\".lunchmenu.v1.GetRestaurantRequest\x1a#.lunchmenu.v1.GetRestaurantResponse\x12a\n" +
+	"\x10CreateRestaurant\x12%.lunchmenu.v1.CreateRestaurantRequest\x1a&.lunchmenu.v1.CreateRestaurantResponse\x12a\n" +
+	"\x10UpdateRestaurant\x12%.lunchmenu.v1.UpdateRestaurantRequest\x1a&.lunchmenu.v1.UpdateRestaurantResponse\x12a\n" +
+	"\x10DeleteRestaurant\x12%.lunchmenu.v1.DeleteRestaurantRequest\x1a&.lunchmenu.v1.DeleteRestaurantResponseB1Z/lunch_menu/internal/pb/lunchmenu/v1;lunchmenuv1b\x06proto3"
+
+var (
+	file_lunchmenu_v1_restaurant_proto_rawDescOnce sync.Once
+	file_lunchmenu_v1_restaurant_proto_rawDescData []byte
+)
+
+func file_lunchmenu_v1_restaurant_proto_rawDescGZIP() []byte {
+	file_lunchmenu_v1_restaurant_proto_rawDescOnce.Do(func() {
+		file_lunchmenu_v1_restaurant_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_lunchmenu_v1_restaurant_proto_rawDesc), len(file_lunchmenu_v1_restaurant_proto_rawDesc)))
+	})
+	return file_lunchmenu_v1_restaurant_proto_rawDescData
+}
+
+var file_lunchmenu_v1_restaurant_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_lunchmenu_v1_restaurant_proto_goTypes = []any{
+	(*Restaurant)(nil),               // 0: lunchmenu.v1.Restaurant
+	(*ListRestaurantsRequest)(nil),   // 1: lunchmenu.v1.ListRestaurantsRequest
+	(*ListRestaurantsResponse)(nil),  // 2: lunchmenu.v1.ListRestaurantsResponse
+	(*GetRestaurantRequest)(nil),     // 3: lunchmenu.v1.GetRestaurantRequest
+	(*GetRestaurantResponse)(nil),    // 4: lunchmenu.v1.GetRestaurantResponse
+	(*CreateRestaurantRequest)(nil),  // 5: lunchmenu.v1.CreateRestaurantRequest
+	(*CreateRestaurantResponse)(nil), // 6: lunchmenu.v1.CreateRestaurantResponse
+	(*UpdateRestaurantRequest)(nil),  // 7: lunchmenu.v1.UpdateRestaurantRequest
+	(*UpdateRestaurantResponse)(nil), // 8: lunchmenu.v1.UpdateRestaurantResponse
+	(*DeleteRestaurantRequest)(nil),  // 9: lunchmenu.v1.DeleteRestaurantRequest
+	(*DeleteRestaurantResponse)(nil), // 10: lunchmenu.v1.DeleteRestaurantResponse
+	(*timestamppb.Timestamp)(nil),    // 11: google.protobuf.Timestamp
+}
+var file_lunchmenu_v1_restaurant_proto_depIdxs = []int32{
+	11, // 0: lunchmenu.v1.Restaurant.created_at:type_name -> google.protobuf.Timestamp
+	11, // 1: lunchmenu.v1.Restaurant.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: lunchmenu.v1.ListRestaurantsResponse.restaurants:type_name -> lunchmenu.v1.Restaurant
+	0,  // 3: lunchmenu.v1.GetRestaurantResponse.restaurant:type_name -> lunchmenu.v1.Restaurant
+	0,  // 4: lunchmenu.v1.CreateRestaurantResponse.restaurant:type_name -> lunchmenu.v1.Restaurant
+	0,  // 5: lunchmenu.v1.UpdateRestaurantResponse.restaurant:type_name -> lunchmenu.v1.Restaurant
+	1,  // 6: lunchmenu.v1.RestaurantService.ListRestaurants:input_type -> lunchmenu.v1.ListRestaurantsRequest
+	3,  // 7: lunchmenu.v1.RestaurantService.GetRestaurant:input_type -> lunchmenu.v1.GetRestaurantRequest
+	5,  // 8: lunchmenu.v1.RestaurantService.CreateRestaurant:input_type -> lunchmenu.v1.CreateRestaurantRequest
+	7,  // 9: lunchmenu.v1.RestaurantService.UpdateRestaurant:input_type -> lunchmenu.v1.UpdateRestaurantRequest
+	9,  // 10: lunchmenu.v1.RestaurantService.DeleteRestaurant:input_type -> lunchmenu.v1.DeleteRestaurantRequest
+	2,  // 11: lunchmenu.v1.RestaurantService.ListRestaurants:output_type -> lunchmenu.v1.ListRestaurantsResponse
+	4,  // 12: lunchmenu.v1.RestaurantService.GetRestaurant:output_type -> lunchmenu.v1.GetRestaurantResponse
+	6,  // 13: lunchmenu.v1.RestaurantService.CreateRestaurant:output_type -> lunchmenu.v1.CreateRestaurantResponse
+	8,  // 14: lunchmenu.v1.RestaurantService.UpdateRestaurant:output_type -> lunchmenu.v1.UpdateRestaurantResponse
+	10, // 15: lunchmenu.v1.RestaurantService.DeleteRestaurant:output_type -> lunchmenu.v1.DeleteRestaurantResponse
+	11, // [11:16] is the sub-list for method output_type
+	6,  // [6:11] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_lunchmenu_v1_restaurant_proto_init() }
+func file_lunchmenu_v1_restaurant_proto_init() {
+	if File_lunchmenu_v1_restaurant_proto != nil {
+		return
+	}
+	file_lunchmenu_v1_restaurant_proto_msgTypes[7].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_lunchmenu_v1_restaurant_proto_rawDesc), len(file_lunchmenu_v1_restaurant_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_lunchmenu_v1_restaurant_proto_goTypes,
+		DependencyIndexes: file_lunchmenu_v1_restaurant_proto_depIdxs,
+		MessageInfos:      file_lunchmenu_v1_restaurant_proto_msgTypes,
+	}.Build()
+	File_lunchmenu_v1_restaurant_proto = out.File
+	file_lunchmenu_v1_restaurant_proto_goTypes = nil
+	file_lunchmenu_v1_restaurant_proto_depIdxs = nil
+}
@@ -0,0 +1,942 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: lunchmenu/v1/statistics.proto
+
+package lunchmenuv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetBusinessStatisticsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Aggregate the prices of this variant, the default price of items without it.
+	PriceTier string `protobuf:"bytes,1,opt,name=price_tier,json=priceTier,proto3" json:"price_tier,omitempty"`
+	// Only restaurants of this region.
+	Region string `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
+	// Only menu items created at or after from and before to.
+	From *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	// Width of the price histogram buckets, 25.00 if not set.
+	BucketWidth   float64 `protobuf:"fixed64,5,opt,name=bucket_width,json=bucketWidth,proto3" json:"bucket_width,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBusinessStatisticsRequest) Reset() {
+	*x = GetBusinessStatisticsRequest{}
+	mi := &file_lunchmenu_v1_statistics_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBusinessStatisticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBusinessStatisticsRequest) ProtoMessage() {}
+
+func (x *GetBusinessStatisticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_statistics_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBusinessStatisticsRequest.ProtoReflect.Descriptor instead.
+func (*GetBusinessStatisticsRequest) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_statistics_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetBusinessStatisticsRequest) GetPriceTier() string {
+	if x != nil {
+		return x.PriceTier
+	}
+	return ""
+}
+
+func (x *GetBusinessStatisticsRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *GetBusinessStatisticsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetBusinessStatisticsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetBusinessStatisticsRequest) GetBucketWidth() float64 {
+	if x != nil {
+		return x.BucketWidth
+	}
+	return 0
+}
+
+type GetBusinessStatisticsResponse struct {
+	state               protoimpl.MessageState    `protogen:"open.v1"`
+	TotalRestaurants    int64                     `protobuf:"varint,1,opt,name=total_restaurants,json=totalRestaurants,proto3" json:"total_restaurants,omitempty"`
+	ActiveRestaurants   int64                     `protobuf:"varint,2,opt,name=active_restaurants,json=activeRestaurants,proto3" json:"active_restaurants,omitempty"`
+	InactiveRestaurants int64                     `protobuf:"varint,3,opt,name=inactive_restaurants,json=inactiveRestaurants,proto3" json:"inactive_restaurants,omitempty"`
+	TotalMenuItems      int64                     `protobuf:"varint,4,opt,name=total_menu_items,json=totalMenuItems,proto3" json:"total_menu_items,omitempty"`
+	AveragePrice        float64                   `protobuf:"fixed64,5,opt,name=average_price,json=averagePrice,proto3" json:"average_price,omitempty"`
+	RestaurantDetails   []*RestaurantBusinessData `protobuf:"bytes,7,rep,name=restaurant_details,json=restaurantDetails,proto3" json:"restaurant_details,omitempty"`
+	// Currency of the aggregated prices; items priced in other currencies are not included.
+	Currency         string                `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"`
+	PriceTier        string                `protobuf:"bytes,9,opt,name=price_tier,json=priceTier,proto3" json:"price_tier,omitempty"`
+	Region           string                `protobuf:"bytes,10,opt,name=region,proto3" json:"region,omitempty"`
+	StaleRestaurants int64                 `protobuf:"varint,11,opt,name=stale_restaurants,json=staleRestaurants,proto3" json:"stale_restaurants,omitempty"`
+	Prices           *PriceSummary         `protobuf:"bytes,12,opt,name=prices,proto3" json:"prices,omitempty"`
+	PriceHistogram   []*HistogramBucket    `protobuf:"bytes,13,rep,name=price_histogram,json=priceHistogram,proto3" json:"price_histogram,omitempty"`
+	Categories       []*CategoryStatistics `protobuf:"bytes,14,rep,name=categories,proto3" json:"categories,omitempty"`
+	Regions          []*RegionStatistics   `protobuf:"bytes,15,rep,name=regions,proto3" json:"regions,omitempty"`
+	// Not set without menu items.
+	LastMenuUpdate *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=last_menu_update,json=lastMenuUpdate,proto3" json:"last_menu_update,omitempty"`
+	// When the statistics were computed; they may be served from a cache.
+	GeneratedAt *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	// Views of the active restaurants, within from and to if set.
+	TotalViews    int64 `protobuf:"varint,18,opt,name=total_views,json=totalViews,proto3" json:"total_views,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBusinessStatisticsResponse) Reset() {
+	*x = GetBusinessStatisticsResponse{}
+	mi := &file_lunchmenu_v1_statistics_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBusinessStatisticsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBusinessStatisticsResponse) ProtoMessage() {}
+
+func (x *GetBusinessStatisticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_statistics_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBusinessStatisticsResponse.ProtoReflect.Descriptor instead.
+func (*GetBusinessStatisticsResponse) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_statistics_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetBusinessStatisticsResponse) GetTotalRestaurants() int64 {
+	if x != nil {
+		return x.TotalRestaurants
+	}
+	return 0
+}
+
+func (x *GetBusinessStatisticsResponse) GetActiveRestaurants() int64 {
+	if x != nil {
+		return x.ActiveRestaurants
+	}
+	return 0
+}
+
+func (x *GetBusinessStatisticsResponse) GetInactiveRestaurants() int64 {
+	if x != nil {
+		return x.InactiveRestaurants
+	}
+	return 0
+}
+
+func (x *GetBusinessStatisticsResponse) GetTotalMenuItems() int64 {
+	if x != nil {
+		return x.TotalMenuItems
+	}
+	return 0
+}
+
+func (x *GetBusinessStatisticsResponse) GetAveragePrice() float64 {
+	if x != nil {
+		return x.AveragePrice
+	}
+	return 0
+}
+
+func (x *GetBusinessStatisticsResponse) GetRestaurantDetails() []*RestaurantBusinessData {
+	if x != nil {
+		return x.RestaurantDetails
+	}
+	return nil
+}
+
+func (x *GetBusinessStatisticsResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *GetBusinessStatisticsResponse) GetPriceTier() string {
+	if x != nil {
+		return x.PriceTier
+	}
+	return ""
+}
+
+func (x *GetBusinessStatisticsResponse) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *GetBusinessStatisticsResponse) GetStaleRestaurants() int64 {
+	if x != nil {
+		return x.StaleRestaurants
+	}
+	return 0
+}
+
+func (x *GetBusinessStatisticsResponse) GetPrices() *PriceSummary {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+func (x *GetBusinessStatisticsResponse) GetPriceHistogram() []*HistogramBucket {
+	if x != nil {
+		return x.PriceHistogram
+	}
+	return nil
+}
+
+func (x *GetBusinessStatisticsResponse) GetCategories() []*CategoryStatistics {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *GetBusinessStatisticsResponse) GetRegions() []*RegionStatistics {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+func (x *GetBusinessStatisticsResponse) GetLastMenuUpdate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastMenuUpdate
+	}
+	return nil
+}
+
+func (x *GetBusinessStatisticsResponse) GetGeneratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return nil
+}
+
+func (x *GetBusinessStatisticsResponse) GetTotalViews() int64 {
+	if x != nil {
+		return x.TotalViews
+	}
+	return 0
+}
+
+type PriceSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PricedItems   int64                  `protobuf:"varint,1,opt,name=priced_items,json=pricedItems,proto3" json:"priced_items,omitempty"`
+	Average       float64                `protobuf:"fixed64,2,opt,name=average,proto3" json:"average,omitempty"`
+	Min           float64                `protobuf:"fixed64,3,opt,name=min,proto3" json:"min,omitempty"`
+	P25           float64                `protobuf:"fixed64,4,opt,name=p25,proto3" json:"p25,omitempty"`
+	Median        float64                `protobuf:"fixed64,5,opt,name=median,proto3" json:"median,omitempty"`
+	P75           float64                `protobuf:"fixed64,6,opt,name=p75,proto3" json:"p75,omitempty"`
+	P90           float64                `protobuf:"fixed64,7,opt,name=p90,proto3" json:"p90,omitempty"`
+	Max           float64                `protobuf:"fixed64,8,opt,name=max,proto3" json:"max,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceSummary) Reset() {
+	*x = PriceSummary{}
+	mi := &file_lunchmenu_v1_statistics_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceSummary) ProtoMessage() {}
+
+func (x *PriceSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_statistics_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceSummary.ProtoReflect.Descriptor instead.
+func (*PriceSummary) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_statistics_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PriceSummary) GetPricedItems() int64 {
+	if x != nil {
+		return x.PricedItems
+	}
+	return 0
+}
+
+func (x *PriceSummary) GetAverage() float64 {
+	if x != nil {
+		return x.Average
+	}
+	return 0
+}
+
+func (x *PriceSummary) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *PriceSummary) GetP25() float64 {
+	if x != nil {
+		return x.P25
+	}
+	return 0
+}
+
+func (x *PriceSummary) GetMedian() float64 {
+	if x != nil {
+		return x.Median
+	}
+	return 0
+}
+
+func (x *PriceSummary) GetP75() float64 {
+	if x != nil {
+		return x.P75
+	}
+	return 0
+}
+
+func (x *PriceSummary) GetP90() float64 {
+	if x != nil {
+		return x.P90
+	}
+	return 0
+}
+
+func (x *PriceSummary) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+type HistogramBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          float64                `protobuf:"fixed64,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            float64                `protobuf:"fixed64,2,opt,name=to,proto3" json:"to,omitempty"`
+	Count         int64                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistogramBucket) Reset() {
+	*x = HistogramBucket{}
+	mi := &file_lunchmenu_v1_statistics_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistogramBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistogramBucket) ProtoMessage() {}
+
+func (x *HistogramBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_statistics_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistogramBucket.ProtoReflect.Descriptor instead.
+func (*HistogramBucket) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_statistics_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *HistogramBucket) GetFrom() float64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *HistogramBucket) GetTo() float64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *HistogramBucket) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type CategoryStatistics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	MenuItems     int64                  `protobuf:"varint,2,opt,name=menu_items,json=menuItems,proto3" json:"menu_items,omitempty"`
+	Prices        *PriceSummary          `protobuf:"bytes,3,opt,name=prices,proto3" json:"prices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryStatistics) Reset() {
+	*x = CategoryStatistics{}
+	mi := &file_lunchmenu_v1_statistics_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryStatistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryStatistics) ProtoMessage() {}
+
+func (x *CategoryStatistics) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_statistics_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryStatistics.ProtoReflect.Descriptor instead.
+func (*CategoryStatistics) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_statistics_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CategoryStatistics) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CategoryStatistics) GetMenuItems() int64 {
+	if x != nil {
+		return x.MenuItems
+	}
+	return 0
+}
+
+func (x *CategoryStatistics) GetPrices() *PriceSummary {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+type RegionStatistics struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Region      string                 `protobuf:"bytes,1,opt,name=region,proto3" json:"region,omitempty"`
+	Restaurants int64                  `protobuf:"varint,2,opt,name=restaurants,proto3" json:"restaurants,omitempty"`
+	MenuItems   int64                  `protobuf:"varint,3,opt,name=menu_items,json=menuItems,proto3" json:"menu_items,omitempty"`
+	Prices      *PriceSummary          `protobuf:"bytes,4,opt,name=prices,proto3" json:"prices,omitempty"`
+	// Not set without priced items.
+	Cheapest      *PricedMenuItem `protobuf:"bytes,5,opt,name=cheapest,proto3" json:"cheapest,omitempty"`
+	MostExpensive *PricedMenuItem `protobuf:"bytes,6,opt,name=most_expensive,json=mostExpensive,proto3" json:"most_expensive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegionStatistics) Reset() {
+	*x = RegionStatistics{}
+	mi := &file_lunchmenu_v1_statistics_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegionStatistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegionStatistics) ProtoMessage() {}
+
+func (x *RegionStatistics) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_statistics_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegionStatistics.ProtoReflect.Descriptor instead.
+func (*RegionStatistics) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_statistics_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RegionStatistics) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *RegionStatistics) GetRestaurants() int64 {
+	if x != nil {
+		return x.Restaurants
+	}
+	return 0
+}
+
+func (x *RegionStatistics) GetMenuItems() int64 {
+	if x != nil {
+		return x.MenuItems
+	}
+	return 0
+}
+
+func (x *RegionStatistics) GetPrices() *PriceSummary {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+func (x *RegionStatistics) GetCheapest() *PricedMenuItem {
+	if x != nil {
+		return x.Cheapest
+	}
+	return nil
+}
+
+func (x *RegionStatistics) GetMostExpensive() *PricedMenuItem {
+	if x != nil {
+		return x.MostExpensive
+	}
+	return nil
+}
+
+type PricedMenuItem struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	MenuItemId     uint32                 `protobuf:"varint,1,opt,name=menu_item_id,json=menuItemId,proto3" json:"menu_item_id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	RestaurantId   uint32                 `protobuf:"varint,3,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	RestaurantName string                 `protobuf:"bytes,4,opt,name=restaurant_name,json=restaurantName,proto3" json:"restaurant_name,omitempty"`
+	Price          float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PricedMenuItem) Reset() {
+	*x = PricedMenuItem{}
+	mi := &file_lunchmenu_v1_statistics_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PricedMenuItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PricedMenuItem) ProtoMessage() {}
+
+func (x *PricedMenuItem) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_statistics_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PricedMenuItem.ProtoReflect.Descriptor instead.
+func (*PricedMenuItem) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_statistics_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PricedMenuItem) GetMenuItemId() uint32 {
+	if x != nil {
+		return x.MenuItemId
+	}
+	return 0
+}
+
+func (x *PricedMenuItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PricedMenuItem) GetRestaurantId() uint32 {
+	if x != nil {
+		return x.RestaurantId
+	}
+	return 0
+}
+
+func (x *PricedMenuItem) GetRestaurantName() string {
+	if x != nil {
+		return x.RestaurantName
+	}
+	return ""
+}
+
+func (x *PricedMenuItem) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+type RestaurantBusinessData struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RestaurantId   uint32                 `protobuf:"varint,1,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	RestaurantName string                 `protobuf:"bytes,2,opt,name=restaurant_name,json=restaurantName,proto3" json:"restaurant_name,omitempty"`
+	MenuItemCount  int64                  `protobuf:"varint,3,opt,name=menu_item_count,json=menuItemCount,proto3" json:"menu_item_count,omitempty"`
+	AveragePrice   float64                `protobuf:"fixed64,4,opt,name=average_price,json=averagePrice,proto3" json:"average_price,omitempty"`
+	Region         string                 `protobuf:"bytes,6,opt,name=region,proto3" json:"region,omitempty"`
+	MedianPrice    float64                `protobuf:"fixed64,7,opt,name=median_price,json=medianPrice,proto3" json:"median_price,omitempty"`
+	MinPrice       float64                `protobuf:"fixed64,8,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	MaxPrice       float64                `protobuf:"fixed64,9,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	// Not set without menu items.
+	LastMenuUpdate *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_menu_update,json=lastMenuUpdate,proto3" json:"last_menu_update,omitempty"`
+	Stale          bool                   `protobuf:"varint,11,opt,name=stale,proto3" json:"stale,omitempty"`
+	// Views of the restaurant, its menu and its menu items, within from and to if set.
+	Views         int64 `protobuf:"varint,12,opt,name=views,proto3" json:"views,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestaurantBusinessData) Reset() {
+	*x = RestaurantBusinessData{}
+	mi := &file_lunchmenu_v1_statistics_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestaurantBusinessData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestaurantBusinessData) ProtoMessage() {}
+
+func (x *RestaurantBusinessData) ProtoReflect() protoreflect.Message {
+	mi := &file_lunchmenu_v1_statistics_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestaurantBusinessData.ProtoReflect.Descriptor instead.
+func (*RestaurantBusinessData) Descriptor() ([]byte, []int) {
+	return file_lunchmenu_v1_statistics_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RestaurantBusinessData) GetRestaurantId() uint32 {
+	if x != nil {
+		return x.RestaurantId
+	}
+	return 0
+}
+
+func (x *RestaurantBusinessData) GetRestaurantName() string {
+	if x != nil {
+		return x.RestaurantName
+	}
+	return ""
+}
+
+func (x *RestaurantBusinessData) GetMenuItemCount() int64 {
+	if x != nil {
+		return x.MenuItemCount
+	}
+	return 0
+}
+
+func (x *RestaurantBusinessData) GetAveragePrice() float64 {
+	if x != nil {
+		return x.AveragePrice
+	}
+	return 0
+}
+
+func (x *RestaurantBusinessData) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *RestaurantBusinessData) GetMedianPrice() float64 {
+	if x != nil {
+		return x.MedianPrice
+	}
+	return 0
+}
+
+func (x *RestaurantBusinessData) GetMinPrice() float64 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+func (x *RestaurantBusinessData) GetMaxPrice() float64 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *RestaurantBusinessData) GetLastMenuUpdate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastMenuUpdate
+	}
+	return nil
+}
+
+func (x *RestaurantBusinessData) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+func (x *RestaurantBusinessData) GetViews() int64 {
+	if x != nil {
+		return x.Views
+	}
+	return 0
+}
+
+var File_lunchmenu_v1_statistics_proto protoreflect.FileDescriptor
+
+const file_lunchmenu_v1_statistics_proto_rawDesc = "" +
+	"\n" +
+	"\x1dlunchmenu/v1/statistics.proto\x12\flunchmenu.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd4\x01\n" +
+	"\x1cGetBusinessStatisticsRequest\x12\x1d\n" +
+	"\n" +
+	"price_tier\x18\x01 \x01(\tR\tpriceTier\x12\x16\n" +
+	"\x06region\x18\x02 \x01(\tR\x06region\x12.\n" +
+	"\x04from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12!\n" +
+	"\fbucket_width\x18\x05 \x01(\x01R\vbucketWidth\"\x8b\a\n" +
+	"\x1dGetBusinessStatisticsResponse\x12+\n" +
+	"\x11total_restaurants\x18\x01 \x01(\x03R\x10totalRestaurants\x12-\n" +
+	"\x12active_restaurants\x18\x02 \x01(\x03R\x11activeRestaurants\x121\n" +
+	"\x14inactive_restaurants\x18\x03 \x01(\x03R\x13inactiveRestaurants\x12(\n" +
+	"\x10total_menu_items\x18\x04 \x01(\x03R\x0etotalMenuItems\x12#\n" +
+	"\raverage_price\x18\x05 \x01(\x01R\faveragePrice\x12S\n" +
+	"\x12restaurant_details\x18\a \x03(\v2$.lunchmenu.v1.RestaurantBusinessDataR\x11restaurantDetails\x12\x1a\n" +
+	"\bcurrency\x18\b \x01(\tR\bcurrency\x12\x1d\n" +
+	"\n" +
+	"price_tier\x18\t \x01(\tR\tpriceTier\x12\x16\n" +
+	"\x06region\x18\n" +
+	" \x01(\tR\x06region\x12+\n" +
+	"\x11stale_restaurants\x18\v \x01(\x03R\x10staleRestaurants\x122\n" +
+	"\x06prices\x18\f \x01(\v2\x1a.lunchmenu.v1.PriceSummaryR\x06prices\x12F\n" +
+	"\x0fprice_histogram\x18\r \x03(\v2\x1d.lunchmenu.v1.HistogramBucketR\x0epriceHistogram\x12@\n" +
+	"\n" +
+	"categories\x18\x0e \x03(\v2 .lunchmenu.v1.CategoryStatisticsR\n" +
+	"categories\x128\n" +
+	"\aregions\x18\x0f \x03(\v2\x1e.lunchmenu.v1.RegionStatisticsR\aregions\x12D\n" +
+	"\x10last_menu_update\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\x0elastMenuUpdate\x12=\n" +
+	"\fgenerated_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt\x12\x1f\n" +
+	"\vtotal_views\x18\x12 \x01(\x03R\n" +
+	"totalViewsJ\x04\b\x06\x10\aR\x13revenue_by_category\"\xbd\x01\n" +
+	"\fPriceSummary\x12!\n" +
+	"\fpriced_items\x18\x01 \x01(\x03R\vpricedItems\x12\x18\n" +
+	"\aaverage\x18\x02 \x01(\x01R\aaverage\x12\x10\n" +
+	"\x03min\x18\x03 \x01(\x01R\x03min\x12\x10\n" +
+	"\x03p25\x18\x04 \x01(\x01R\x03p25\x12\x16\n" +
+	"\x06median\x18\x05 \x01(\x01R\x06median\x12\x10\n" +
+	"\x03p75\x18\x06 \x01(\x01R\x03p75\x12\x10\n" +
+	"\x03p90\x18\a \x01(\x01R\x03p90\x12\x10\n" +
+	"\x03max\x18\b \x01(\x01R\x03max\"K\n" +
+	"\x0fHistogramBucket\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\x01R\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\x01R\x02to\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x03R\x05count\"\x83\x01\n" +
+	"\x12CategoryStatistics\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x1d\n" +
+	"\n" +
+	"menu_items\x18\x02 \x01(\x03R\tmenuItems\x122\n" +
+	"\x06prices\x18\x03 \x01(\v2\x1a.lunchmenu.v1.PriceSummaryR\x06prices\"\x9e\x02\n" +
+	"\x10RegionStatistics\x12\x16\n" +
+	"\x06region\x18\x01 \x01(\tR\x06region\x12 \n" +
+	"\vrestaurants\x18\x02 \x01(\x03R\vrestaurants\x12\x1d\n" +
+	"\n" +
+	"menu_items\x18\x03 \x01(\x03R\tmenuItems\x122\n" +
+	"\x06prices\x18\x04 \x01(\v2\x1a.lunchmenu.v1.PriceSummaryR\x06prices\x128\n" +
+	"\bcheapest\x18\x05 \x01(\v2\x1c.lunchmenu.v1.PricedMenuItemR\bcheapest\x12C\n" +
+	"\x0emost_expensive\x18\x06 \x01(\v2\x1c.lunchmenu.v1.PricedMenuItemR\rmostExpensive\"\xaa\x01\n" +
+	"\x0ePricedMenuItem\x12 \n" +
+	"\fmenu_item_id\x18\x01 \x01(\rR\n" +
+	"menuItemId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12#\n" +
+	"\rrestaurant_id\x18\x03 \x01(\rR\frestaurantId\x12'\n" +
+	"\x0frestaurant_name\x18\x04 \x01(\tR\x0erestaurantName\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x01R\x05price\"\xaf\x03\n" +
+	"\x16RestaurantBusinessData\x12#\n" +
+	"\rrestaurant_id\x18\x01 \x01(\rR\frestaurantId\x12'\n" +
+	"\x0frestaurant_name\x18\x02 \x01(\tR\x0erestaurantName\x12&\n" +
+	"\x0fmenu_item_count\x18\x03 \x01(\x03R\rmenuItemCount\x12#\n" +
+	"\raverage_price\x18\x04 \x01(\x01R\faveragePrice\x12\x16\n" +
+	"\x06region\x18\x06 \x01(\tR\x06region\x12!\n" +
+	"\fmedian_price\x18\a \x01(\x01R\vmedianPrice\x12\x1b\n" +
+	"\tmin_price\x18\b \x01(\x01R\bminPrice\x12\x1b\n" +
+	"\tmax_price\x18\t \x01(\x01R\bmaxPrice\x12D\n" +
+	"\x10last_menu_update\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x0elastMenuUpdate\x12\x14\n" +
+	"\x05stale\x18\v \x01(\bR\x05stale\x12\x14\n" +
+	"\x05views\x18\f \x01(\x03R\x05viewsJ\x04\b\x05\x10\x06R\rtotal_revenue2\x85\x01\n" +
+	"\x11StatisticsService\x12p\n" +
+	"\x15GetBusinessStatistics\x12*.lunchmenu.v1.GetBusinessStatisticsRequest\x1a+.lunchmenu.v1.GetBusinessStatisticsResponseB1Z/lunch_menu/internal/pb/lunchmenu/v1;lunchmenuv1b\x06proto3"
+
+var (
+	file_lunchmenu_v1_statistics_proto_rawDescOnce sync.Once
+	file_lunchmenu_v1_statistics_proto_rawDescData []byte
+)
+
+func file_lunchmenu_v1_statistics_proto_rawDescGZIP() []byte {
+	file_lunchmenu_v1_statistics_proto_rawDescOnce.Do(func() {
+		file_lunchmenu_v1_statistics_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_lunchmenu_v1_statistics_proto_rawDesc), len(file_lunchmenu_v1_statistics_proto_rawDesc)))
+	})
+	return file_lunchmenu_v1_statistics_proto_rawDescData
+}
+
+var file_lunchmenu_v1_statistics_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_lunchmenu_v1_statistics_proto_goTypes = []any{
+	(*GetBusinessStatisticsRequest)(nil),  // 0: lunchmenu.v1.GetBusinessStatisticsRequest
+	(*GetBusinessStatisticsResponse)(nil), // 1: lunchmenu.v1.GetBusinessStatisticsResponse
+	(*PriceSummary)(nil),                  // 2: lunchmenu.v1.PriceSummary
+	(*HistogramBucket)(nil),               // 3: lunchmenu.v1.HistogramBucket
+	(*CategoryStatistics)(nil),            // 4: lunchmenu.v1.CategoryStatistics
+	(*RegionStatistics)(nil),              // 5: lunchmenu.v1.RegionStatistics
+	(*PricedMenuItem)(nil),                // 6: lunchmenu.v1.PricedMenuItem
+	(*RestaurantBusinessData)(nil),        // 7: lunchmenu.v1.RestaurantBusinessData
+	(*timestamppb.Timestamp)(nil),         // 8: google.protobuf.Timestamp
+}
+var file_lunchmenu_v1_statistics_proto_depIdxs = []int32{
+	8,  // 0: lunchmenu.v1.GetBusinessStatisticsRequest.from:type_name -> google.protobuf.Timestamp
+	8,  // 1: lunchmenu.v1.GetBusinessStatisticsRequest.to:type_name -> google.protobuf.Timestamp
+	7,  // 2: lunchmenu.v1.GetBusinessStatisticsResponse.restaurant_details:type_name -> lunchmenu.v1.RestaurantBusinessData
+	2,  // 3: lunchmenu.v1.GetBusinessStatisticsResponse.prices:type_name -> lunchmenu.v1.PriceSummary
+	3,  // 4: lunchmenu.v1.GetBusinessStatisticsResponse.price_histogram:type_name -> lunchmenu.v1.HistogramBucket
+	4,  // 5: lunchmenu.v1.GetBusinessStatisticsResponse.categories:type_name -> lunchmenu.v1.CategoryStatistics
+	5,  // 6: lunchmenu.v1.GetBusinessStatisticsResponse.regions:type_name -> lunchmenu.v1.RegionStatistics
+	8,  // 7: lunchmenu.v1.GetBusinessStatisticsResponse.last_menu_update:type_name -> google.protobuf.Timestamp
+	8,  // 8: lunchmenu.v1.GetBusinessStatisticsResponse.generated_at:type_name -> google.protobuf.Timestamp
+	2,  // 9: lunchmenu.v1.CategoryStatistics.prices:type_name -> lunchmenu.v1.PriceSummary
+	2,  // 10: lunchmenu.v1.RegionStatistics.prices:type_name -> lunchmenu.v1.PriceSummary
+	6,  // 11: lunchmenu.v1.RegionStatistics.cheapest:type_name -> lunchmenu.v1.PricedMenuItem
+	6,  // 12: lunchmenu.v1.RegionStatistics.most_expensive:type_name -> lunchmenu.v1.PricedMenuItem
+	8,  // 13: lunchmenu.v1.RestaurantBusinessData.last_menu_update:type_name -> google.protobuf.Timestamp
+	0,  // 14: lunchmenu.v1.StatisticsService.GetBusinessStatistics:input_type -> lunchmenu.v1.GetBusinessStatisticsRequest
+	1,  // 15: lunchmenu.v1.StatisticsService.GetBusinessStatistics:output_type -> lunchmenu.v1.GetBusinessStatisticsResponse
+	15, // [15:16] is the sub-list for method output_type
+	14, // [14:15] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_lunchmenu_v1_statistics_proto_init() }
+func file_lunchmenu_v1_statistics_proto_init() {
+	if File_lunchmenu_v1_statistics_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_lunchmenu_v1_statistics_proto_rawDesc), len(file_lunchmenu_v1_statistics_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_lunchmenu_v1_statistics_proto_goTypes,
+		DependencyIndexes: file_lunchmenu_v1_statistics_proto_depIdxs,
+		MessageInfos:      file_lunchmenu_v1_statistics_proto_msgTypes,
+	}.Build()
+	File_lunchmenu_v1_statistics_proto = out.File
+	file_lunchmenu_v1_statistics_proto_goTypes = nil
+	file_lunchmenu_v1_statistics_proto_depIdxs = nil
+}
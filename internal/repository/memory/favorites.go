@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/models"
+)
+
+type favoriteRepository struct {
+	s *Store
+}
+
+func (r favoriteRepository) Add(_ context.Context, favorite *models.Favorite) (*models.Favorite, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, other := range r.s.favorites {
+		if other.UserID == favorite.UserID && other.TargetType == favorite.TargetType && other.TargetID == favorite.TargetID {
+			*favorite = other
+			return favorite, nil
+		}
+	}
+	favorite.ID = r.s.nextID("favorites")
+	favorite.CreatedAt = time.Now()
+	r.s.favorites = append(r.s.favorites, *favorite)
+	return favorite, nil
+}
+
+func (r favoriteRepository) Remove(_ context.Context, userID uint, targetType string, targetID uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for i, f := range r.s.favorites {
+		if f.UserID == userID && f.TargetType == targetType && f.TargetID == targetID {
+			r.s.favorites = append(r.s.favorites[:i], r.s.favorites[i+1:]...)
+			return nil
+		}
+	}
+	return apperrors.NotFound("favorite", targetID)
+}
+
+func (r favoriteRepository) List(_ context.Context, userID uint) ([]models.Favorite, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	favorites := []models.Favorite{}
+	for _, f := range r.s.favorites {
+		if f.UserID == userID {
+			favorites = append(favorites, f)
+		}
+	}
+	return favorites, nil
+}
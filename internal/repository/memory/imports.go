@@ -0,0 +1,163 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"lunch_menu/internal/config"
+	"lunch_menu/internal/models"
+)
+
+type importRepository struct {
+	s *Store
+}
+
+// Import applies the rows to the store and restores it if any row is
+// invalid or dryRun is set, like the transaction of the GORM store
+func (r importRepository) Import(_ context.Context, data *models.CatalogueImport, dryRun bool) (*models.ImportReport, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	report := &models.ImportReport{DryRun: dryRun, Errors: []models.ImportRowError{}}
+
+	restaurants, menuItems := maps.Clone(r.s.restaurants), maps.Clone(r.s.menuItems)
+	prices, outbox, lastID := slices.Clone(r.s.prices), slices.Clone(r.s.outbox), maps.Clone(r.s.lastID)
+	for i := range data.Restaurants {
+		r.s.importRestaurant(i+1, &data.Restaurants[i], report)
+	}
+	for i := range data.MenuItems {
+		r.s.importMenuItem(i+1, &data.MenuItems[i], report)
+	}
+	if dryRun || len(report.Errors) > 0 {
+		r.s.restaurants, r.s.menuItems = restaurants, menuItems
+		r.s.prices, r.s.outbox, r.s.lastID = prices, outbox, lastID
+		return report, nil
+	}
+	report.Applied = true
+	r.s.changed("restaurants")
+	r.s.changed("menu_items")
+	return report, nil
+}
+
+// importRestaurant upserts the restaurant of a row; s.mu must be held
+func (s *Store) importRestaurant(row int, input *models.RestaurantInput, report *models.ImportReport) {
+	if ok, invalid := input.Validate(); !ok {
+		report.Errors = append(report.Errors, invalidRow("restaurants", row, invalid))
+		return
+	}
+
+	restaurant, exists := s.restaurantNamed(input.Name)
+	now := time.Now()
+	if !exists {
+		restaurant = models.Restaurant{ID: s.nextID("restaurants"), IsActive: true, CreatedAt: now}
+	}
+	// A deleted restaurant stays deleted
+	restaurant.Name = input.Name
+	restaurant.Description = input.Description
+	restaurant.Address = input.Address
+	restaurant.Coordinate = input.Coordinate
+	restaurant.Homepage = input.Homepage
+	restaurant.Region = input.Region
+	restaurant.Phone = input.Phone
+	restaurant.Email = input.Email
+	restaurant.UpdatedAt = now
+	s.restaurants[restaurant.ID] = restaurant
+	eventType := models.EventRestaurantCreated
+	if exists {
+		eventType = models.EventRestaurantUpdated
+		report.Restaurants.Updated++
+	} else {
+		report.Restaurants.Created++
+	}
+	s.recordEvent(eventType, restaurant.ID, &restaurant)
+}
+
+// importMenuItem upserts the menu item of a row; s.mu must be held
+func (s *Store) importMenuItem(row int, input *models.MenuItemImportRow, report *models.ImportReport) {
+	if ok, invalid := input.Validate(); !ok {
+		report.Errors = append(report.Errors, invalidRow("menu_items", row, invalid))
+		return
+	}
+	restaurant, ok := s.restaurantNamed(input.RestaurantName)
+	if !ok {
+		report.Errors = append(report.Errors, models.ImportRowError{
+			Kind:    "menu_items",
+			Row:     row,
+			Fields:  []string{"restaurant_name"},
+			Message: fmt.Sprintf("restaurant %q does not exist", input.RestaurantName),
+		})
+		return
+	}
+
+	var item models.MenuItem
+	exists := false
+	for _, other := range s.items(func(other models.MenuItem) bool {
+		return other.RestaurantID == restaurant.ID && strings.EqualFold(other.Name, input.Name)
+	}) {
+		item, exists = other, true
+		break
+	}
+	now := time.Now()
+	if !exists {
+		item = models.MenuItem{ID: s.nextID("menu_items"), CreatedAt: now}
+	}
+	price, currency := item.Price, item.Currency
+
+	update := models.MenuItemUpdateInput{
+		RestaurantID: &restaurant.ID,
+		Name:         &input.Name,
+		Description:  &input.Description,
+		Price:        &input.Price,
+		Currency:     &input.Currency,
+		Category:     &input.Category,
+		IsAvailable:  input.IsAvailable,
+	}
+	if input.Currency == "" {
+		update.Currency = &config.AppConfig.Pricing.Currency
+	}
+	if input.IsAvailable == nil {
+		available := true
+		update.IsAvailable = &available
+	}
+	s.setPrices(&item)
+	update.Apply(&item)
+	item.UpdatedAt = now
+	s.menuItems[item.ID] = item
+	if !exists || item.Price != price || item.Currency != currency {
+		s.recordPriceChange(&item, now)
+	}
+	eventType := models.EventMenuItemCreated
+	if exists {
+		eventType = models.EventMenuItemUpdated
+		report.MenuItems.Updated++
+	} else {
+		report.MenuItems.Created++
+	}
+	s.recordEvent(eventType, item.RestaurantID, &item)
+}
+
+// restaurantNamed returns the restaurant with the lowest ID named name,
+// case-insensitively, including deleted ones; s.mu must be held
+func (s *Store) restaurantNamed(name string) (models.Restaurant, bool) {
+	var found models.Restaurant
+	ok := false
+	for _, restaurant := range s.restaurants {
+		if strings.EqualFold(restaurant.Name, name) && (!ok || restaurant.ID < found.ID) {
+			found, ok = restaurant, true
+		}
+	}
+	return found, ok
+}
+
+// invalidRow reports a row with invalid fields
+func invalidRow(kind string, row int, invalid []string) models.ImportRowError {
+	return models.ImportRowError{
+		Kind:    kind,
+		Row:     row,
+		Fields:  invalid,
+		Message: "Invalid fields: " + strings.Join(invalid, ", "),
+	}
+}
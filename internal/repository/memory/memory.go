@@ -0,0 +1,114 @@
+// Package memory implements the repository interfaces in memory, so that the
+// whole API can be run and tested without a database. It mimics the GORM
+// implementation, including soft deletes, its errors and the outbox events
+// recorded with every change of restaurants and menu items.
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/repository"
+)
+
+// Store holds the data of all repositories; it is safe for concurrent use
+type Store struct {
+	mu          sync.RWMutex
+	restaurants map[uint]models.Restaurant
+	menuItems   map[uint]models.MenuItem
+	prices      []models.PriceChange
+	views       []models.ViewEvent
+	reviews     map[uint]models.Review
+	favorites   []models.Favorite // in ID order
+	users       map[uint]models.User
+	refresh     []models.RefreshToken
+	blacklist   map[string]time.Time
+	webhooks    map[uint]models.WebhookSubscription
+	deliveries  []models.WebhookDelivery
+	outbox      []models.OutboxEvent // in ID order
+	snapshots   map[string]models.StatisticsSnapshot
+	lastID      map[string]uint
+	onChange    func(table string)
+}
+
+// NewStore creates an empty store
+func NewStore() *Store {
+	return &Store{
+		restaurants: make(map[uint]models.Restaurant),
+		menuItems:   make(map[uint]models.MenuItem),
+		reviews:     make(map[uint]models.Review),
+		users:       make(map[uint]models.User),
+		blacklist:   make(map[string]time.Time),
+		webhooks:    make(map[uint]models.WebhookSubscription),
+		snapshots:   make(map[string]models.StatisticsSnapshot),
+		lastID:      make(map[string]uint),
+	}
+}
+
+// OnChange sets fn to be called with the table name after every create,
+// update and delete, like a GORM callback; it must not use the store
+func (s *Store) OnChange(fn func(table string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onChange = fn
+}
+
+// changed calls the OnChange function; s.mu must be held
+func (s *Store) changed(table string) {
+	if s.onChange != nil {
+		s.onChange(table)
+	}
+}
+
+// Restaurants returns the restaurant repository of the store
+func (s *Store) Restaurants() repository.RestaurantRepository { return restaurantRepository{s} }
+
+// MenuItems returns the menu item repository of the store
+func (s *Store) MenuItems() repository.MenuItemRepository { return menuItemRepository{s} }
+
+// PriceHistory returns the price history repository of the store
+func (s *Store) PriceHistory() repository.PriceHistoryRepository { return priceHistoryRepository{s} }
+
+// Views returns the view event repository of the store
+func (s *Store) Views() repository.ViewRepository { return viewRepository{s} }
+
+// Reviews returns the review repository of the store
+func (s *Store) Reviews() repository.ReviewRepository { return reviewRepository{s} }
+
+// Favorites returns the favorite repository of the store
+func (s *Store) Favorites() repository.FavoriteRepository { return favoriteRepository{s} }
+
+// Users returns the user repository of the store
+func (s *Store) Users() repository.UserRepository { return userRepository{s} }
+
+// Tokens returns the token repository of the store
+func (s *Store) Tokens() repository.TokenRepository { return tokenRepository{s} }
+
+// Imports returns the import repository of the store
+func (s *Store) Imports() repository.ImportRepository { return importRepository{s} }
+
+// Webhooks returns the webhook repository of the store
+func (s *Store) Webhooks() repository.WebhookRepository { return webhookRepository{s} }
+
+// Outbox returns the outbox repository of the store
+func (s *Store) Outbox() repository.OutboxRepository { return outboxRepository{s} }
+
+// Statistics returns the statistics repository of the store
+func (s *Store) Statistics() repository.StatisticsRepository { return statisticsRepository{s} }
+
+// nextID returns the next ID of a table, like a Postgres serial; s.mu must be held
+func (s *Store) nextID(table string) uint {
+	s.lastID[table]++
+	return s.lastID[table]
+}
+
+// page returns the bounds of the page of n records starting at offset; a
+// negative limit means no limit
+func page(n, limit, offset int) (int, int) {
+	start := min(max(offset, 0), n)
+	if limit < 0 {
+		return start, n
+	}
+	return start, min(start+limit, n)
+}
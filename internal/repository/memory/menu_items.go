@@ -0,0 +1,179 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/models"
+)
+
+type menuItemRepository struct {
+	s *Store
+}
+
+func (r menuItemRepository) Create(_ context.Context, item *models.MenuItem) (*models.MenuItem, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.restaurants[item.RestaurantID]; !ok {
+		return nil, apperrors.Validation("menu item references a record that does not exist")
+	}
+	now := time.Now()
+	item.ID = r.s.nextID("menu_items")
+	item.IsAvailable = true
+	if item.Currency == "" {
+		item.Currency = "SEK" // the column default
+	}
+	item.CreatedAt, item.UpdatedAt = now, now
+	r.s.setPrices(item)
+	r.s.menuItems[item.ID] = *item
+	r.s.recordPriceChange(item, now)
+	r.s.recordEvent(models.EventMenuItemCreated, item.RestaurantID, item)
+	r.s.changed("menu_items")
+	return item, nil
+}
+
+func (r menuItemRepository) Update(_ context.Context, id uint, input *models.MenuItemUpdateInput) (*models.MenuItem, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	item, ok := r.s.menuItems[id]
+	if !ok {
+		return nil, apperrors.NotFound("menu item", id)
+	}
+	if input.RestaurantID != nil {
+		if _, ok := r.s.restaurants[*input.RestaurantID]; !ok {
+			return nil, apperrors.InvalidFields([]string{"restaurant_id"})
+		}
+	}
+	r.s.setPrices(&item)
+	price, currency := item.Price, item.Currency
+	input.Apply(&item)
+	item.UpdatedAt = time.Now()
+	r.s.setPrices(&item)
+	r.s.menuItems[id] = item
+	if item.Price != price || item.Currency != currency {
+		r.s.recordPriceChange(&item, item.UpdatedAt)
+	}
+	r.s.recordEvent(models.EventMenuItemUpdated, item.RestaurantID, &item)
+	r.s.changed("menu_items")
+	return &item, nil
+}
+
+func (r menuItemRepository) Delete(_ context.Context, id uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	item, ok := r.s.menuItems[id]
+	if !ok {
+		return apperrors.NotFound("menu item", id)
+	}
+	item.IsAvailable = false
+	item.UpdatedAt = time.Now()
+	r.s.menuItems[id] = item
+	r.s.recordEvent(models.EventMenuItemDeleted, item.RestaurantID, &item)
+	r.s.changed("menu_items")
+	return nil
+}
+
+func (r menuItemRepository) GetByID(_ context.Context, id uint) (*models.MenuItem, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	item, ok := r.s.menuItems[id]
+	if !ok || !item.IsAvailable {
+		return nil, apperrors.NotFound("menu item", id)
+	}
+	return &item, nil
+}
+
+func (r menuItemRepository) List(_ context.Context, restaurantID uint, limit, offset int) ([]models.MenuItem, int64, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	items := r.s.items(func(item models.MenuItem) bool {
+		return restaurantID == 0 || item.RestaurantID == restaurantID
+	})
+	start, end := page(len(items), limit, offset)
+	return items[start:end], int64(len(items)), nil
+}
+
+func (r menuItemRepository) ListByRestaurantIDs(_ context.Context, restaurantIDs []uint) ([]models.MenuItem, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	items := r.s.items(func(item models.MenuItem) bool {
+		return item.IsAvailable && slices.Contains(restaurantIDs, item.RestaurantID)
+	})
+	slices.SortStableFunc(items, func(a, b models.MenuItem) int { return cmp.Compare(a.RestaurantID, b.RestaurantID) })
+	return items, nil
+}
+
+func (r menuItemRepository) Stream(_ context.Context, restaurantID uint, limit, offset int, fn func(*models.MenuItemWithRestaurant) error) error {
+	r.s.mu.RLock()
+	items := r.s.items(func(item models.MenuItem) bool {
+		return restaurantID == 0 || item.RestaurantID == restaurantID
+	})
+	start, end := page(len(items), limit, offset)
+	items = items[start:end]
+	names := make(map[uint]string, len(r.s.restaurants))
+	for id, restaurant := range r.s.restaurants {
+		names[id] = restaurant.Name
+	}
+	r.s.mu.RUnlock()
+
+	for _, item := range items {
+		if err := fn(&models.MenuItemWithRestaurant{MenuItem: item, RestaurantName: names[item.RestaurantID]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setPrices gives the price variants of item IDs and a copy of their own, so
+// that callers do not share them with the store; s.mu must be held
+func (s *Store) setPrices(item *models.MenuItem) {
+	item.Prices = slices.Clone(item.Prices)
+	for i := range item.Prices {
+		if item.Prices[i].ID == 0 {
+			item.Prices[i].ID = s.nextID("menu_item_prices")
+		}
+		item.Prices[i].MenuItemID = item.ID
+	}
+}
+
+// items returns the menu items matching keep in ID order; s.mu must be held
+func (s *Store) items(keep func(models.MenuItem) bool) []models.MenuItem {
+	var items []models.MenuItem
+	for _, item := range s.menuItems {
+		if keep(item) {
+			items = append(items, item)
+		}
+	}
+	slices.SortFunc(items, func(a, b models.MenuItem) int { return cmp.Compare(a.ID, b.ID) })
+	return items
+}
+
+func (r menuItemRepository) Feed(_ context.Context, q models.FeedQuery, limit, offset int) ([]models.FeedItem, int64, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	feed := []models.FeedItem{}
+	for _, item := range r.s.items(func(item models.MenuItem) bool { return item.IsAvailable }) {
+		restaurant, ok := r.s.restaurants[item.RestaurantID]
+		if !ok || !restaurant.IsActive {
+			continue
+		}
+		feed = append(feed, models.FeedItem{
+			MenuItem:       item,
+			RestaurantName: restaurant.Name,
+			Region:         restaurant.Region,
+			Reasons:        q.Reasons(&item, restaurant.Region),
+		})
+	}
+	slices.SortFunc(feed, func(a, b models.FeedItem) int {
+		return cmp.Or(
+			cmp.Compare(models.FeedScore(b.Reasons), models.FeedScore(a.Reasons)),
+			cmp.Compare(a.RestaurantID, b.RestaurantID),
+			cmp.Compare(a.ID, b.ID),
+		)
+	})
+	start, end := page(len(feed), limit, offset)
+	return feed[start:end], int64(len(feed)), nil
+}
@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/models"
+)
+
+type outboxRepository struct {
+	s *Store
+}
+
+// recordEvent appends a change event to the outbox like the GORM store does
+// in the transaction of the change; s.mu must be held
+func (s *Store) recordEvent(eventType string, restaurantID uint, data interface{}) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		panic(fmt.Sprintf("memory: failed to encode %s event: %v", eventType, err))
+	}
+	s.outbox = append(s.outbox, models.OutboxEvent{
+		ID:           s.nextID("outbox_events"),
+		EventType:    eventType,
+		RestaurantID: restaurantID,
+		Region:       s.restaurants[restaurantID].Region,
+		Payload:      string(payload),
+		CreatedAt:    time.Now(),
+	})
+}
+
+func (r outboxRepository) Dispatch(_ context.Context, limit int) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	now := time.Now()
+	subscriptions := r.s.subscriptions()
+	handled := 0
+	for i := range r.s.outbox {
+		if handled == limit {
+			break
+		}
+		event := &r.s.outbox[i]
+		if event.DispatchedAt != nil {
+			continue
+		}
+		for _, d := range models.NewWebhookDeliveries(event, subscriptions, now) {
+			d.ID = r.s.nextID("webhook_deliveries")
+			d.CreatedAt, d.UpdatedAt = now, now
+			r.s.deliveries = append(r.s.deliveries, d)
+		}
+		event.DispatchedAt = &now
+		handled++
+	}
+	return handled, nil
+}
+
+func (r outboxRepository) GetByID(_ context.Context, id uint) (*models.OutboxEvent, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	for _, event := range r.s.outbox {
+		if event.ID == id {
+			return &event, nil
+		}
+	}
+	return nil, apperrors.NotFound("event", id)
+}
+
+func (r outboxRepository) After(_ context.Context, afterID uint, limit int) ([]models.OutboxEvent, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	var events []models.OutboxEvent
+	for _, event := range r.s.outbox {
+		if event.ID > afterID {
+			events = append(events, event)
+		}
+	}
+	start, end := page(len(events), limit, 0)
+	return events[start:end], nil
+}
+
+func (r outboxRepository) Latest(_ context.Context, limit int) ([]models.OutboxEvent, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	start := max(len(r.s.outbox)-limit, 0)
+	return append([]models.OutboxEvent(nil), r.s.outbox[start:]...), nil
+}
@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/models"
+)
+
+type priceHistoryRepository struct {
+	s *Store
+}
+
+// recordPriceChange adds the current price of item to its history; s.mu must
+// be held
+func (s *Store) recordPriceChange(item *models.MenuItem, at time.Time) {
+	s.prices = append(s.prices, models.PriceChange{
+		ID:            s.nextID("price_changes"),
+		MenuItemID:    item.ID,
+		Price:         item.Price,
+		Currency:      item.Currency,
+		EffectiveFrom: at,
+		AppliedAt:     &at,
+		CreatedAt:     at,
+	})
+}
+
+// byEffectiveFrom orders price changes like the GORM implementation
+func byEffectiveFrom(a, b models.PriceChange) int {
+	if c := a.EffectiveFrom.Compare(b.EffectiveFrom); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.ID, b.ID)
+}
+
+func (r priceHistoryRepository) History(_ context.Context, menuItemID uint) ([]models.PriceChange, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	var changes []models.PriceChange
+	for _, c := range r.s.prices {
+		if c.MenuItemID == menuItemID {
+			changes = append(changes, c)
+		}
+	}
+	slices.SortFunc(changes, byEffectiveFrom)
+	return changes, nil
+}
+
+func (r priceHistoryRepository) Schedule(_ context.Context, change *models.PriceChange) (*models.PriceChange, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.menuItems[change.MenuItemID]; !ok {
+		return nil, apperrors.Validation("price change references a record that does not exist")
+	}
+	change.ID = r.s.nextID("price_changes")
+	change.AppliedAt = nil
+	change.CreatedAt = time.Now()
+	r.s.prices = append(r.s.prices, *change)
+	return change, nil
+}
+
+func (r priceHistoryRepository) CancelScheduled(_ context.Context, menuItemID, changeID uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	i := slices.IndexFunc(r.s.prices, func(c models.PriceChange) bool {
+		return c.ID == changeID && c.MenuItemID == menuItemID
+	})
+	if i < 0 {
+		return apperrors.NotFound("price change", changeID)
+	}
+	if !r.s.prices[i].Scheduled() {
+		return apperrors.Conflict("price change has already taken effect")
+	}
+	r.s.prices = slices.Delete(r.s.prices, i, i+1)
+	return nil
+}
+
+func (r priceHistoryRepository) ApplyDue(_ context.Context, now time.Time) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var due []int
+	for i, c := range r.s.prices {
+		if c.Scheduled() && !c.EffectiveFrom.After(now) {
+			due = append(due, i)
+		}
+	}
+	slices.SortFunc(due, func(a, b int) int { return byEffectiveFrom(r.s.prices[a], r.s.prices[b]) })
+	applied := 0
+	for _, i := range due {
+		change := &r.s.prices[i]
+		change.AppliedAt = &now
+		item, ok := r.s.menuItems[change.MenuItemID]
+		if !ok {
+			continue // like the GORM store, the change stays claimed
+		}
+		applied++
+		r.s.setPrices(&item)
+		input := models.MenuItemUpdateInput{Price: &change.Price, Currency: &change.Currency}
+		input.Apply(&item)
+		item.UpdatedAt = now
+		r.s.menuItems[item.ID] = item
+		r.s.recordEvent(models.EventMenuItemUpdated, item.RestaurantID, &item)
+		r.s.changed("menu_items")
+	}
+	return applied, nil
+}
+
+func (r priceHistoryRepository) Changes(_ context.Context, from, to time.Time) ([]models.PriceChangeWithItem, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	previous := map[uint]models.PriceChange{} // last change of each item before from
+	for _, c := range r.s.prices {
+		if p, ok := previous[c.MenuItemID]; !c.Scheduled() && c.EffectiveFrom.Before(from) && (!ok || byEffectiveFrom(p, c) < 0) {
+			previous[c.MenuItemID] = c
+		}
+	}
+	var changes []models.PriceChangeWithItem
+	for _, c := range r.s.prices {
+		item, ok := r.s.menuItems[c.MenuItemID]
+		restaurant := r.s.restaurants[item.RestaurantID]
+		inRange := !c.EffectiveFrom.Before(from) && !c.EffectiveFrom.After(to)
+		if c.Scheduled() || !ok || !inRange && previous[c.MenuItemID].ID != c.ID {
+			continue
+		}
+		changes = append(changes, models.PriceChangeWithItem{
+			PriceChange:    c,
+			RestaurantID:   item.RestaurantID,
+			RestaurantName: restaurant.Name,
+			Region:         restaurant.Region,
+			Category:       item.Category,
+		})
+	}
+	slices.SortFunc(changes, func(a, b models.PriceChangeWithItem) int { return byEffectiveFrom(a.PriceChange, b.PriceChange) })
+	return changes, nil
+}
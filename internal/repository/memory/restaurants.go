@@ -0,0 +1,113 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/models"
+)
+
+type restaurantRepository struct {
+	s *Store
+}
+
+func (r restaurantRepository) Create(_ context.Context, restaurant *models.Restaurant) (*models.Restaurant, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	now := time.Now()
+	restaurant.ID = r.s.nextID("restaurants")
+	restaurant.IsActive = true
+	restaurant.CreatedAt, restaurant.UpdatedAt = now, now
+	r.s.restaurants[restaurant.ID] = *restaurant
+	r.s.recordEvent(models.EventRestaurantCreated, restaurant.ID, restaurant)
+	r.s.changed("restaurants")
+	return restaurant, nil
+}
+
+func (r restaurantRepository) Update(_ context.Context, id uint, input *models.RestaurantUpdateInput) (*models.Restaurant, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	restaurant, ok := r.s.restaurants[id]
+	if !ok {
+		return nil, apperrors.NotFound("restaurant", id)
+	}
+	input.Apply(&restaurant)
+	restaurant.UpdatedAt = time.Now()
+	r.s.restaurants[id] = restaurant
+	r.s.recordEvent(models.EventRestaurantUpdated, id, &restaurant)
+	r.s.changed("restaurants")
+	return &restaurant, nil
+}
+
+func (r restaurantRepository) Delete(_ context.Context, id uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	restaurant, ok := r.s.restaurants[id]
+	if !ok {
+		return apperrors.NotFound("restaurant", id)
+	}
+	restaurant.IsActive = false
+	restaurant.UpdatedAt = time.Now()
+	r.s.restaurants[id] = restaurant
+	r.s.recordEvent(models.EventRestaurantDeleted, id, &restaurant)
+	r.s.changed("restaurants")
+	return nil
+}
+
+func (r restaurantRepository) GetByID(_ context.Context, id uint) (*models.Restaurant, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	restaurant, ok := r.s.restaurants[id]
+	if !ok || !restaurant.IsActive {
+		return nil, apperrors.NotFound("restaurant", id)
+	}
+	return &restaurant, nil
+}
+
+func (r restaurantRepository) GetByIDs(_ context.Context, ids []uint) ([]models.Restaurant, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	var restaurants []models.Restaurant
+	for _, restaurant := range r.s.active() {
+		if slices.Contains(ids, restaurant.ID) {
+			restaurants = append(restaurants, restaurant)
+		}
+	}
+	return restaurants, nil
+}
+
+func (r restaurantRepository) List(_ context.Context, limit, offset int) ([]models.Restaurant, int64, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	restaurants := r.s.active()
+	start, end := page(len(restaurants), limit, offset)
+	return restaurants[start:end], int64(len(restaurants)), nil
+}
+
+func (r restaurantRepository) Stream(ctx context.Context, limit, offset int, fn func(*models.Restaurant) error) error {
+	restaurants, _, err := r.List(ctx, limit, offset)
+	if err != nil {
+		return err
+	}
+	for i := range restaurants {
+		if err := fn(&restaurants[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// active returns the active restaurants in ID order; s.mu must be held
+func (s *Store) active() []models.Restaurant {
+	restaurants := make([]models.Restaurant, 0, len(s.restaurants))
+	for _, restaurant := range s.restaurants {
+		if restaurant.IsActive {
+			restaurants = append(restaurants, restaurant)
+		}
+	}
+	slices.SortFunc(restaurants, func(a, b models.Restaurant) int { return cmp.Compare(a.ID, b.ID) })
+	return restaurants
+}
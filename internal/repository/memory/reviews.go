@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/models"
+)
+
+type reviewRepository struct {
+	s *Store
+}
+
+func (r reviewRepository) Create(_ context.Context, review *models.Review) (*models.Review, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, other := range r.s.reviews {
+		if other.UserID == review.UserID && other.TargetType == review.TargetType &&
+			other.TargetID == review.TargetID && other.ReviewedOn == review.ReviewedOn {
+			return nil, apperrors.Conflict("review already exists")
+		}
+	}
+	now := time.Now()
+	review.ID = r.s.nextID("reviews")
+	review.Hidden, review.Flagged = false, false
+	review.CreatedAt, review.UpdatedAt = now, now
+	r.s.reviews[review.ID] = *review
+	return review, nil
+}
+
+func (r reviewRepository) Update(_ context.Context, id uint, input *models.ReviewUpdateInput) (*models.Review, error) {
+	return r.update(id, input.Apply)
+}
+
+func (r reviewRepository) Moderate(_ context.Context, id uint, input *models.ReviewModerationInput) (*models.Review, error) {
+	return r.update(id, input.Apply)
+}
+
+// update applies a change to the current state of the review with the given
+// ID under the lock, so that only the fields of the input change
+func (r reviewRepository) update(id uint, apply func(*models.Review)) (*models.Review, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	review, ok := r.s.reviews[id]
+	if !ok {
+		return nil, apperrors.NotFound("review", id)
+	}
+	apply(&review)
+	review.UpdatedAt = time.Now()
+	r.s.reviews[id] = review
+	return &review, nil
+}
+
+func (r reviewRepository) Delete(_ context.Context, id uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.reviews[id]; !ok {
+		return apperrors.NotFound("review", id)
+	}
+	delete(r.s.reviews, id)
+	return nil
+}
+
+func (r reviewRepository) GetByID(_ context.Context, id uint) (*models.Review, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	review, ok := r.s.reviews[id]
+	if !ok {
+		return nil, apperrors.NotFound("review", id)
+	}
+	return &review, nil
+}
+
+func (r reviewRepository) List(_ context.Context, filter models.ReviewFilter, limit, offset int) ([]models.Review, int64, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	reviews := []models.Review{}
+	for _, review := range r.s.reviews {
+		if filter.Matches(&review) {
+			reviews = append(reviews, review)
+		}
+	}
+	slices.SortFunc(reviews, func(a, b models.Review) int {
+		return cmp.Or(b.CreatedAt.Compare(a.CreatedAt), cmp.Compare(b.ID, a.ID))
+	})
+	start, end := page(len(reviews), limit, offset)
+	return reviews[start:end], int64(len(reviews)), nil
+}
+
+func (r reviewRepository) Ratings(_ context.Context, targetType string, targetIDs []uint) (map[uint]models.RatingSummary, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	sums := make(map[uint][2]int64)
+	for _, review := range r.s.reviews {
+		if review.TargetType == targetType && !review.Hidden && slices.Contains(targetIDs, review.TargetID) {
+			sum := sums[review.TargetID]
+			sums[review.TargetID] = [2]int64{sum[0] + int64(review.Rating), sum[1] + 1}
+		}
+	}
+	ratings := make(map[uint]models.RatingSummary, len(sums))
+	for id, sum := range sums {
+		ratings[id] = models.NewRatingSummary(sum[0], sum[1])
+	}
+	return ratings, nil
+}
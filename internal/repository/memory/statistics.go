@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"lunch_menu/internal/config"
+	"lunch_menu/internal/models"
+)
+
+type statisticsRepository struct {
+	s *Store
+}
+
+// Compute selects the restaurants and menu items like the queries of the
+// GORM store and summarizes them the same way
+func (r statisticsRepository) Compute(_ context.Context, filter models.StatisticsFilter) (*models.BusinessStatistics, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	now := time.Now()
+	stats := models.NewBusinessStatistics(filter, config.AppConfig.Pricing.Currency, now)
+	inRegion := func(restaurant models.Restaurant) bool {
+		return filter.Region == "" || restaurant.Region == filter.Region
+	}
+
+	var restaurants []models.StatisticsRestaurant
+	for _, restaurant := range r.s.restaurants {
+		if !inRegion(restaurant) {
+			continue
+		}
+		stats.TotalRestaurants++
+		if !restaurant.IsActive {
+			stats.InactiveRestaurants++
+			continue
+		}
+		stats.ActiveRestaurants++
+		restaurants = append(restaurants, models.StatisticsRestaurant{
+			ID:             restaurant.ID,
+			Name:           restaurant.Name,
+			Region:         restaurant.Region,
+			LastMenuUpdate: r.s.lastMenuUpdate(restaurant.ID),
+			Views:          r.s.countViews(restaurant.ID, filter.From, filter.To),
+		})
+	}
+	slices.SortFunc(restaurants, func(a, b models.StatisticsRestaurant) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.ID, b.ID))
+	})
+
+	var items []models.StatisticsMenuItem
+	for _, item := range r.s.items(func(item models.MenuItem) bool { return item.IsAvailable }) {
+		restaurant, ok := r.s.restaurants[item.RestaurantID]
+		if !ok || !restaurant.IsActive || !inRegion(restaurant) ||
+			(!filter.From.IsZero() && item.CreatedAt.Before(filter.From)) ||
+			(!filter.To.IsZero() && !item.CreatedAt.Before(filter.To)) {
+			continue
+		}
+		price := item.Price
+		for _, p := range item.Prices {
+			if p.Tier == filter.PriceTier {
+				price = p.Price
+			}
+		}
+		items = append(items, models.StatisticsMenuItem{
+			ID:           item.ID,
+			Name:         item.Name,
+			RestaurantID: item.RestaurantID,
+			Category:     item.Category,
+			Price:        price,
+			Currency:     item.Currency,
+		})
+	}
+
+	stats.Summarize(restaurants, items, filter.BucketWidth, now)
+	return stats, nil
+}
+
+func (r statisticsRepository) Snapshot(_ context.Context, key string) (*models.StatisticsSnapshot, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	snapshot, ok := r.s.snapshots[key]
+	if !ok {
+		return nil, nil
+	}
+	return &snapshot, nil
+}
+
+func (r statisticsRepository) SaveSnapshot(_ context.Context, snapshot *models.StatisticsSnapshot) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.snapshots[snapshot.Key] = *snapshot
+	return nil
+}
+
+// lastMenuUpdate returns when the most recently updated menu item of a
+// restaurant was updated, also a deleted one; s.mu must be held
+func (s *Store) lastMenuUpdate(restaurantID uint) *time.Time {
+	var last *time.Time
+	for _, item := range s.menuItems {
+		if item.RestaurantID == restaurantID && (last == nil || item.UpdatedAt.After(*last)) {
+			updated := item.UpdatedAt
+			last = &updated
+		}
+	}
+	return last
+}
+
+// countViews counts the view events of a restaurant at or after from and
+// before to, zero for no bound; s.mu must be held
+func (s *Store) countViews(restaurantID uint, from, to time.Time) int64 {
+	var n int64
+	for _, v := range s.views {
+		if v.RestaurantID == restaurantID && (from.IsZero() || !v.ViewedAt.Before(from)) && (to.IsZero() || v.ViewedAt.Before(to)) {
+			n++
+		}
+	}
+	return n
+}
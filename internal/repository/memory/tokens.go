@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type tokenRepository struct {
+	s *Store
+}
+
+func (r tokenRepository) SaveRefreshToken(_ context.Context, userID uint, token, userAgent, ip string, expiresAt time.Time) error {
+	if userAgent == "" {
+		userAgent = "unknown"
+	}
+	if ip == "" {
+		ip = "unknown"
+	}
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for i, rt := range r.s.refresh {
+		if rt.UserID == userID && rt.UserAgent == userAgent && rt.IPAddress == ip {
+			r.s.refresh[i].TokenHash = models.HashRefreshToken(token)
+			r.s.refresh[i].ExpiresAt = expiresAt
+			r.s.refresh[i].RevokedAt = nil
+			return nil
+		}
+	}
+	r.s.refresh = append(r.s.refresh, models.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		TokenHash: models.HashRefreshToken(token),
+		UserAgent: userAgent,
+		IPAddress: ip,
+		CreatedAt: time.Now(),
+		ExpiresAt: expiresAt,
+	})
+	return nil
+}
+
+func (r tokenRepository) GetRefreshToken(_ context.Context, userID int, token string) (*models.RefreshToken, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	hash := models.HashRefreshToken(token)
+	for _, rt := range r.s.refresh {
+		if rt.UserID == uint(userID) && rt.TokenHash == hash && rt.RevokedAt == nil && rt.ExpiresAt.After(time.Now()) {
+			return &rt, nil
+		}
+	}
+	return nil, apperrors.NotFound("refresh token", userID)
+}
+
+func (r tokenRepository) DeleteRefreshToken(_ context.Context, userID uint, token string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	hash := models.HashRefreshToken(token)
+	kept := r.s.refresh[:0]
+	for _, rt := range r.s.refresh {
+		if rt.UserID != userID || rt.TokenHash != hash {
+			kept = append(kept, rt)
+		}
+	}
+	r.s.refresh = kept
+	return nil
+}
+
+func (r tokenRepository) BlacklistToken(_ context.Context, token string, expiresAt time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.blacklist[token]; ok {
+		return apperrors.Conflict("blacklisted token already exists")
+	}
+	r.s.blacklist[token] = expiresAt
+	return nil
+}
+
+func (r tokenRepository) IsTokenBlacklisted(_ context.Context, token string) (bool, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	expiresAt, ok := r.s.blacklist[token]
+	return ok && expiresAt.After(time.Now()), nil
+}
@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/models"
+)
+
+type userRepository struct {
+	s *Store
+}
+
+func (r userRepository) Create(_ context.Context, user *models.User) (*models.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, u := range r.s.users {
+		if u.Username == user.Username || strings.EqualFold(u.Email, user.Email) {
+			return nil, apperrors.Conflict("user already exists")
+		}
+	}
+	now := time.Now()
+	user.ID = r.s.nextID("users")
+	user.CreatedAt, user.UpdatedAt = now, now
+	r.s.users[user.ID] = *user
+	return user, nil
+}
+
+func (r userRepository) GetByUsername(_ context.Context, username string) (*models.User, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	for _, user := range r.s.users {
+		if user.Username == username {
+			return &user, nil
+		}
+	}
+	return &models.User{}, apperrors.NotFound("user", username)
+}
+
+func (r userRepository) GetByID(_ context.Context, userID int) (*models.User, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	user, ok := r.s.users[uint(userID)]
+	if !ok {
+		return nil, apperrors.NotFound("user", userID)
+	}
+	return &user, nil
+}
+
+func (r userRepository) Update(_ context.Context, user *models.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if user.ID == 0 {
+		user.ID = r.s.nextID("users")
+		user.CreatedAt = time.Now()
+	}
+	// Like a save with an explicit primary key, which does not advance the
+	// Postgres sequence; IDs are kept unique here instead
+	r.s.lastID["users"] = max(r.s.lastID["users"], user.ID)
+	user.UpdatedAt = time.Now()
+	r.s.users[user.ID] = *user
+	return nil
+}
+
+func (r userRepository) Delete(_ context.Context, userID uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	delete(r.s.users, userID)
+	return nil
+}
@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"lunch_menu/internal/models"
+)
+
+type viewRepository struct {
+	s *Store
+}
+
+func (r viewRepository) Record(_ context.Context, views []models.ViewEvent) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, view := range views {
+		view.ID = r.s.nextID("view_events")
+		r.s.views = append(r.s.views, view)
+	}
+	return nil
+}
+
+func (r viewRepository) Trending(_ context.Context, since time.Time, limit int) ([]models.TrendingRestaurant, []models.TrendingMenuItem, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	restaurantViews := make(map[uint]int64)
+	itemViews := make(map[uint]int64)
+	for _, view := range r.s.views {
+		if view.ViewedAt.Before(since) {
+			continue
+		}
+		restaurantViews[view.RestaurantID]++
+		if view.Kind == models.ViewMenuItem && view.MenuItemID != nil {
+			itemViews[*view.MenuItemID]++
+		}
+	}
+
+	restaurants := []models.TrendingRestaurant{}
+	for id, views := range restaurantViews {
+		if restaurant, ok := r.s.restaurants[id]; ok && restaurant.IsActive {
+			restaurants = append(restaurants, models.TrendingRestaurant{
+				RestaurantID:   id,
+				RestaurantName: restaurant.Name,
+				Region:         restaurant.Region,
+				Views:          views,
+			})
+		}
+	}
+	slices.SortFunc(restaurants, func(a, b models.TrendingRestaurant) int {
+		return cmp.Or(cmp.Compare(b.Views, a.Views), cmp.Compare(a.RestaurantID, b.RestaurantID))
+	})
+
+	items := []models.TrendingMenuItem{}
+	for id, views := range itemViews {
+		item, ok := r.s.menuItems[id]
+		if !ok || !item.IsAvailable {
+			continue
+		}
+		if restaurant, ok := r.s.restaurants[item.RestaurantID]; ok && restaurant.IsActive {
+			items = append(items, models.TrendingMenuItem{
+				MenuItemID:     id,
+				Name:           item.Name,
+				RestaurantID:   item.RestaurantID,
+				RestaurantName: restaurant.Name,
+				Views:          views,
+			})
+		}
+	}
+	slices.SortFunc(items, func(a, b models.TrendingMenuItem) int {
+		return cmp.Or(cmp.Compare(b.Views, a.Views), cmp.Compare(a.MenuItemID, b.MenuItemID))
+	})
+
+	_, restaurantsEnd := page(len(restaurants), limit, 0)
+	_, itemsEnd := page(len(items), limit, 0)
+	return restaurants[:restaurantsEnd], items[:itemsEnd], nil
+}
@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/models"
+)
+
+type webhookRepository struct {
+	s *Store
+}
+
+func (r webhookRepository) Create(_ context.Context, sub *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	now := time.Now()
+	sub.ID = r.s.nextID("webhook_subscriptions")
+	sub.CreatedAt, sub.UpdatedAt = now, now
+	r.s.webhooks[sub.ID] = *sub
+	return sub, nil
+}
+
+func (r webhookRepository) Update(_ context.Context, id uint, input *models.WebhookSubscriptionUpdateInput) (*models.WebhookSubscription, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	sub, ok := r.s.webhooks[id]
+	if !ok {
+		return nil, apperrors.NotFound("webhook subscription", id)
+	}
+	input.Apply(&sub)
+	sub.UpdatedAt = time.Now()
+	r.s.webhooks[id] = sub
+	return &sub, nil
+}
+
+func (r webhookRepository) Delete(_ context.Context, id uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.webhooks[id]; !ok {
+		return apperrors.NotFound("webhook subscription", id)
+	}
+	delete(r.s.webhooks, id)
+	return nil
+}
+
+func (r webhookRepository) GetByID(_ context.Context, id uint) (*models.WebhookSubscription, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	sub, ok := r.s.webhooks[id]
+	if !ok {
+		return nil, apperrors.NotFound("webhook subscription", id)
+	}
+	return &sub, nil
+}
+
+func (r webhookRepository) List(_ context.Context, limit, offset int) ([]models.WebhookSubscription, int64, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	subs := r.s.subscriptions()
+	start, end := page(len(subs), limit, offset)
+	return subs[start:end], int64(len(subs)), nil
+}
+
+func (r webhookRepository) Deliveries(_ context.Context, subscriptionID uint, status string, limit, offset int) ([]models.WebhookDelivery, int64, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	deliveries := []models.WebhookDelivery{}
+	for _, d := range slices.Backward(r.s.deliveries) {
+		if d.SubscriptionID == subscriptionID && (status == "" || d.Status == status) {
+			deliveries = append(deliveries, d)
+		}
+	}
+	start, end := page(len(deliveries), limit, offset)
+	return deliveries[start:end], int64(len(deliveries)), nil
+}
+
+func (r webhookRepository) RetryDelivery(_ context.Context, subscriptionID, deliveryID uint) (*models.WebhookDelivery, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for i, d := range r.s.deliveries {
+		if d.ID == deliveryID && d.SubscriptionID == subscriptionID {
+			r.s.deliveries[i].Retry(time.Now())
+			r.s.deliveries[i].UpdatedAt = time.Now()
+			delivery := r.s.deliveries[i]
+			return &delivery, nil
+		}
+	}
+	return nil, apperrors.NotFound("webhook delivery", deliveryID)
+}
+
+func (r webhookRepository) ClaimDueDeliveries(_ context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	now := time.Now()
+	var due []int
+	for i, d := range r.s.deliveries {
+		if d.Status == models.DeliveryPending && !d.NextAttemptAt.After(now) {
+			due = append(due, i)
+		}
+	}
+	slices.SortStableFunc(due, func(a, b int) int {
+		return r.s.deliveries[a].NextAttemptAt.Compare(r.s.deliveries[b].NextAttemptAt)
+	})
+	start, end := page(len(due), limit, 0)
+	claimed := make([]models.WebhookDelivery, 0, end-start)
+	for _, i := range due[start:end] {
+		claimed = append(claimed, r.s.deliveries[i])
+		r.s.deliveries[i].NextAttemptAt = now.Add(lease)
+	}
+	return claimed, nil
+}
+
+func (r webhookRepository) SaveDelivery(_ context.Context, delivery *models.WebhookDelivery) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for i, d := range r.s.deliveries {
+		if d.ID == delivery.ID {
+			delivery.UpdatedAt = time.Now()
+			r.s.deliveries[i] = *delivery
+			return nil
+		}
+	}
+	return apperrors.NotFound("webhook delivery", delivery.ID)
+}
+
+// subscriptions returns the subscriptions in ID order; s.mu must be held
+func (s *Store) subscriptions() []models.WebhookSubscription {
+	subs := make([]models.WebhookSubscription, 0, len(s.webhooks))
+	for _, sub := range s.webhooks {
+		subs = append(subs, sub)
+	}
+	slices.SortFunc(subs, func(a, b models.WebhookSubscription) int { return cmp.Compare(a.ID, b.ID) })
+	return subs
+}
@@ -0,0 +1,229 @@
+// Package repository defines the storage interfaces of the API. Handlers,
+// middleware and the GraphQL and gRPC servers only use these interfaces, so
+// the GORM implementation in the database package can be replaced by the
+// in-memory one of the memory package, e.g. to test the API without Postgres.
+//
+// Every method takes the context of the request it serves; the GORM
+// implementation runs its queries with it, so they are traced as child spans
+// of the request and stop when it is cancelled.
+//
+// Implementations return apperrors: NotFound for missing or inactive records,
+// Conflict for duplicates and Validation errors for invalid references.
+package repository
+
+import (
+	"context"
+	"time"
+
+	"lunch_menu/internal/models"
+)
+
+// RestaurantRepository stores restaurants. Deleted restaurants are kept with
+// is_active set to false and are hidden from GetByID, GetByIDs, List and Stream.
+type RestaurantRepository interface {
+	// Create inserts a new active restaurant and sets its ID
+	Create(ctx context.Context, r *models.Restaurant) (*models.Restaurant, error)
+	// Update sets the non-nil fields of input on the restaurant with the given ID
+	Update(ctx context.Context, id uint, input *models.RestaurantUpdateInput) (*models.Restaurant, error)
+	// Delete soft deletes a restaurant
+	Delete(ctx context.Context, id uint) error
+	// GetByID returns an active restaurant
+	GetByID(ctx context.Context, id uint) (*models.Restaurant, error)
+	// GetByIDs returns the active restaurants with the given IDs, in ID order
+	GetByIDs(ctx context.Context, ids []uint) ([]models.Restaurant, error)
+	// List returns a page of active restaurants in ID order and their total count
+	List(ctx context.Context, limit, offset int) ([]models.Restaurant, int64, error)
+	// Stream calls fn for each active restaurant in ID order without loading
+	// them all at once. A negative limit means no limit.
+	Stream(ctx context.Context, limit, offset int, fn func(*models.Restaurant) error) error
+}
+
+// MenuItemRepository stores menu items. Deleted items are kept with
+// is_available set to false and are hidden from GetByID and ListByRestaurantIDs.
+type MenuItemRepository interface {
+	// Create inserts a new available menu item and sets its ID
+	Create(ctx context.Context, item *models.MenuItem) (*models.MenuItem, error)
+	// Update sets the non-nil fields of input on the menu item with the given ID.
+	// Moving an item to a restaurant that does not exist is a validation error.
+	Update(ctx context.Context, id uint, input *models.MenuItemUpdateInput) (*models.MenuItem, error)
+	// Delete soft deletes a menu item
+	Delete(ctx context.Context, id uint) error
+	// GetByID returns an available menu item
+	GetByID(ctx context.Context, id uint) (*models.MenuItem, error)
+	// List returns a page of the menu items of a restaurant, or of all
+	// restaurants for restaurantID 0, and their total count
+	List(ctx context.Context, restaurantID uint, limit, offset int) ([]models.MenuItem, int64, error)
+	// ListByRestaurantIDs returns the available menu items of several
+	// restaurants, ordered by restaurant and ID
+	ListByRestaurantIDs(ctx context.Context, restaurantIDs []uint) ([]models.MenuItem, error)
+	// Stream calls fn for each menu item with its restaurant name in ID order,
+	// like List. A negative limit means no limit.
+	Stream(ctx context.Context, restaurantID uint, limit, offset int, fn func(*models.MenuItemWithRestaurant) error) error
+	// Feed returns a page of the available menu items of active restaurants
+	// ranked by q, highest models.FeedScore first and then by restaurant and
+	// ID, with their reasons, and their total count
+	Feed(ctx context.Context, q models.FeedQuery, limit, offset int) ([]models.FeedItem, int64, error)
+}
+
+// PriceHistoryRepository stores the price history of menu items. The menu
+// item repository adds a change whenever it sets the price or currency of an
+// item; changes scheduled here take effect when ApplyDue runs after their
+// effective time.
+type PriceHistoryRepository interface {
+	// History returns the applied and scheduled changes of a menu item,
+	// ordered by effective time
+	History(ctx context.Context, menuItemID uint) ([]models.PriceChange, error)
+	// Schedule stores a future change of a menu item and sets its ID
+	Schedule(ctx context.Context, change *models.PriceChange) (*models.PriceChange, error)
+	// CancelScheduled deletes a scheduled change of a menu item; applied
+	// changes cannot be cancelled
+	CancelScheduled(ctx context.Context, menuItemID, changeID uint) error
+	// ApplyDue sets the price of the menu items whose scheduled changes took
+	// effect at or before now, and returns the number of applied changes.
+	// Each change is applied once, also when several replicas run ApplyDue.
+	// Changes that fail are skipped, retried on the next run and returned as
+	// a joined error; changes of menu items that no longer exist are dropped.
+	ApplyDue(ctx context.Context, now time.Time) (int, error)
+	// Changes returns the applied changes that took effect from from up to
+	// and including to, and the last applied change of each menu item before
+	// from, ordered by effective time. Unavailable menu items and inactive
+	// restaurants keep their history.
+	Changes(ctx context.Context, from, to time.Time) ([]models.PriceChangeWithItem, error)
+}
+
+// ReviewRepository stores the reviews of restaurants and menu items. The
+// callers check that the reviewed target exists.
+type ReviewRepository interface {
+	// Create inserts a new visible review and sets its ID; a second review of
+	// the same target by the same user on the same ReviewedOn date is a conflict
+	Create(ctx context.Context, review *models.Review) (*models.Review, error)
+	// Update sets the non-nil fields of input on the review with the given ID
+	Update(ctx context.Context, id uint, input *models.ReviewUpdateInput) (*models.Review, error)
+	// Moderate sets the non-nil moderation flags of input on the review with
+	// the given ID
+	Moderate(ctx context.Context, id uint, input *models.ReviewModerationInput) (*models.Review, error)
+	// Delete removes a review
+	Delete(ctx context.Context, id uint) error
+	// GetByID returns a review, also a hidden one
+	GetByID(ctx context.Context, id uint) (*models.Review, error)
+	// List returns a page of the reviews selected by filter, newest first,
+	// and their total count
+	List(ctx context.Context, filter models.ReviewFilter, limit, offset int) ([]models.Review, int64, error)
+	// Ratings summarizes the visible reviews of the targets of a type by
+	// target ID; targets without reviews are left out
+	Ratings(ctx context.Context, targetType string, targetIDs []uint) (map[uint]models.RatingSummary, error)
+}
+
+// FavoriteRepository stores the restaurants and menu items starred by users.
+// The callers check that the starred target exists.
+type FavoriteRepository interface {
+	// Add stars a target for a user and sets the ID; starring a target again
+	// returns the existing favorite
+	Add(ctx context.Context, favorite *models.Favorite) (*models.Favorite, error)
+	// Remove unstars a target for a user
+	Remove(ctx context.Context, userID uint, targetType string, targetID uint) error
+	// List returns the favorites of a user, oldest first
+	List(ctx context.Context, userID uint) ([]models.Favorite, error)
+}
+
+// ViewRepository stores the anonymous view events of restaurants, menus and
+// menu items
+type ViewRepository interface {
+	// Record inserts a batch of view events
+	Record(ctx context.Context, views []models.ViewEvent) error
+	// Trending returns the active restaurants and the available menu items
+	// with the most views at or after since, at most limit of each, most
+	// viewed first
+	Trending(ctx context.Context, since time.Time, limit int) ([]models.TrendingRestaurant, []models.TrendingMenuItem, error)
+}
+
+// StatisticsRepository computes the business statistics and stores the
+// precomputed statistics shared by replicas, see the statistics package
+type StatisticsRepository interface {
+	// Compute returns the statistics selected by filter, computed from the
+	// current data
+	Compute(ctx context.Context, filter models.StatisticsFilter) (*models.BusinessStatistics, error)
+	// Snapshot returns the precomputed statistics stored under key, nil if
+	// there are none
+	Snapshot(ctx context.Context, key string) (*models.StatisticsSnapshot, error)
+	// SaveSnapshot stores precomputed statistics, replacing those with the
+	// same key
+	SaveSnapshot(ctx context.Context, snapshot *models.StatisticsSnapshot) error
+}
+
+// ImportRepository applies bulk imports of restaurants and menu items
+type ImportRepository interface {
+	// Import upserts restaurants by name and menu items by restaurant name
+	// and item name, both case-insensitive; matching a deleted restaurant
+	// updates it without restoring it. Invalid rows are reported. If there
+	// are any, or dryRun is set, nothing is applied.
+	Import(ctx context.Context, data *models.CatalogueImport, dryRun bool) (*models.ImportReport, error)
+}
+
+// WebhookRepository stores the webhook subscriptions and their delivery log.
+// Deliveries are created and attempted by the webhooks dispatcher.
+type WebhookRepository interface {
+	// Create inserts a new subscription and sets its ID
+	Create(ctx context.Context, sub *models.WebhookSubscription) (*models.WebhookSubscription, error)
+	// Update sets the non-nil fields of input on the subscription with the
+	// given ID
+	Update(ctx context.Context, id uint, input *models.WebhookSubscriptionUpdateInput) (*models.WebhookSubscription, error)
+	// Delete removes a subscription; its delivery log is kept
+	Delete(ctx context.Context, id uint) error
+	// GetByID returns a subscription, also an inactive one
+	GetByID(ctx context.Context, id uint) (*models.WebhookSubscription, error)
+	// List returns a page of subscriptions in ID order and their total count
+	List(ctx context.Context, limit, offset int) ([]models.WebhookSubscription, int64, error)
+	// Deliveries returns a page of the delivery log of a subscription, newest
+	// first, and its total count. An empty status selects every state.
+	Deliveries(ctx context.Context, subscriptionID uint, status string, limit, offset int) ([]models.WebhookDelivery, int64, error)
+	// RetryDelivery puts a delivery of the given subscription back into the
+	// pending state so that it is attempted again, e.g. after it went dead
+	RetryDelivery(ctx context.Context, subscriptionID, deliveryID uint) (*models.WebhookDelivery, error)
+	// ClaimDueDeliveries returns up to limit pending deliveries that are due,
+	// oldest due first, and pushes their next attempt back by lease, so that
+	// no other dispatcher attempts them in the meantime
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error)
+	// SaveDelivery stores the outcome of a delivery attempt
+	SaveDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+}
+
+// OutboxRepository reads the change events that the restaurant, menu item
+// and import repositories record with every change (transactional outbox)
+type OutboxRepository interface {
+	// Dispatch creates pending webhook deliveries of up to limit undispatched
+	// events for the active subscriptions that want them, marks the events as
+	// dispatched and returns their number
+	Dispatch(ctx context.Context, limit int) (int, error)
+	// GetByID returns an event
+	GetByID(ctx context.Context, id uint) (*models.OutboxEvent, error)
+	// After returns up to limit events with an ID greater than afterID,
+	// oldest first
+	After(ctx context.Context, afterID uint, limit int) ([]models.OutboxEvent, error)
+	// Latest returns the limit most recent events, oldest first
+	Latest(ctx context.Context, limit int) ([]models.OutboxEvent, error)
+}
+
+// UserRepository stores users
+type UserRepository interface {
+	// Create inserts a new user; usernames and emails are unique
+	Create(ctx context.Context, user *models.User) (*models.User, error)
+	GetByUsername(ctx context.Context, username string) (*models.User, error)
+	GetByID(ctx context.Context, userID int) (*models.User, error)
+	Update(ctx context.Context, user *models.User) error
+	Delete(ctx context.Context, userID uint) error
+}
+
+// TokenRepository stores the refresh tokens of logged in users, hashed, and
+// the blacklist of revoked access tokens
+type TokenRepository interface {
+	// SaveRefreshToken stores the refresh token of a user's session,
+	// replacing the previous one of the same user agent and IP
+	SaveRefreshToken(ctx context.Context, userID uint, token, userAgent, ip string, expiresAt time.Time) error
+	// GetRefreshToken returns the unexpired, unrevoked refresh token of a user
+	GetRefreshToken(ctx context.Context, userID int, token string) (*models.RefreshToken, error)
+	DeleteRefreshToken(ctx context.Context, userID uint, token string) error
+	// BlacklistToken revokes an access token until it expires
+	BlacklistToken(ctx context.Context, token string, expiresAt time.Time) error
+	IsTokenBlacklisted(ctx context.Context, token string) (bool, error)
+}
@@ -1,37 +1,104 @@
 package routes
 
 import (
+	"lunch_menu/internal/app"
 	"lunch_menu/internal/handlers"
 	"lunch_menu/internal/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRoutes(r *gin.Engine) {
+// SetupRoutes registers the API routes, served with the repositories of a.
+// limits applies the stricter rate limit tiers of logins and admin writes on
+// top of the global one.
+func SetupRoutes(r *gin.Engine, a *app.App, limits *middleware.RateLimiter) {
+	h := handlers.New(a)
+	auth := middleware.AuthMiddleware(a)
+	optionalAuth := middleware.OptionalAuthMiddleware(a)
+	authLimit := limits.Limit(middleware.TierAuth)
+	writeLimit := limits.Limit(middleware.TierWrite)
+
+	// Kubernetes probes: liveness of the process and readiness to serve
+	r.GET("/healthz", handlers.Healthz)
+	r.GET("/readyz", handlers.Readyz)
+
+	// GraphQL endpoint: public queries, mutations need a Bearer token
+	r.GET("/graphql", optionalAuth, h.GraphQL)
+	r.POST("/graphql", optionalAuth, h.GraphQL)
+
 	api := r.Group("/api")
 	{
-		// Admin authentication endpoints
-		api.POST("/user/register", handlers.UserRegister)
-		api.POST("/user/login", handlers.UserLogin)
-		api.POST("/user/logout", handlers.UserLogout)
-
-		// Restaurant endpoints
-		api.GET("/restaurants", handlers.GetRestaurants)
-		api.GET("/restaurants/:id", handlers.GetRestaurant)
-		api.GET("/restaurants/:id/menu", handlers.GetRestaurantMenu)
-		api.POST("/restaurants", middleware.AuthMiddleware, handlers.CreateRestaurant)
-		api.PUT("/restaurants/:id", middleware.AuthMiddleware, handlers.UpdateRestaurant)
-		api.DELETE("/restaurants/:id", middleware.AuthMiddleware, handlers.DeleteRestaurant)
+		// Authentication endpoints
+		api.POST("/user/register", authLimit, optionalAuth, h.UserRegister)
+		api.POST("/user/login", authLimit, h.UserLogin)
+		api.POST("/user/logout", h.UserLogout)
+
+		// Preferences, favorites and the personal feed of the logged in user
+		me := api.Group("/user/me", auth)
+		me.GET("/preferences", h.GetMyPreferences)
+		me.PUT("/preferences", writeLimit, h.UpdateMyPreferences)
+		me.GET("/favorites", h.GetMyFavorites)
+		me.PUT("/favorites/restaurants/:id", writeLimit, h.AddFavoriteRestaurant)
+		me.DELETE("/favorites/restaurants/:id", writeLimit, h.RemoveFavoriteRestaurant)
+		me.PUT("/favorites/menu-items/:id", writeLimit, h.AddFavoriteMenuItem)
+		me.DELETE("/favorites/menu-items/:id", writeLimit, h.RemoveFavoriteMenuItem)
+		me.GET("/feed", h.GetMyFeed)
+
+		// Restaurant endpoints; customers can log in too, so writes check the admin role
+		api.GET("/restaurants", h.GetRestaurants)
+		api.GET("/restaurants/:id", h.GetRestaurant)
+		api.GET("/restaurants/:id/menu", h.GetRestaurantMenu)
+		api.POST("/restaurants", auth, middleware.AdminMiddleware, writeLimit, h.CreateRestaurant)
+		api.PUT("/restaurants/:id", auth, middleware.AdminMiddleware, writeLimit, h.UpdateRestaurant)
+		api.DELETE("/restaurants/:id", auth, middleware.AdminMiddleware, writeLimit, h.DeleteRestaurant)
 
 		// Menu item endpoints
-		api.GET("/menu-items/:id", handlers.GetMenuItem)
-		api.GET("/menu-items", handlers.GetMenuItems)
-		api.POST("/menu-items", middleware.AuthMiddleware, handlers.CreateMenuItem)
-		api.PUT("/menu-items/:id", middleware.AuthMiddleware, handlers.UpdateMenuItem)
-		api.DELETE("/menu-items/:id", middleware.AuthMiddleware, handlers.DeleteMenuItem)
+		api.GET("/menu-items/:id", h.GetMenuItem)
+		api.GET("/menu-items", h.GetMenuItems)
+		api.POST("/menu-items", auth, middleware.AdminMiddleware, writeLimit, h.CreateMenuItem)
+		api.PUT("/menu-items/:id", auth, middleware.AdminMiddleware, writeLimit, h.UpdateMenuItem)
+		api.DELETE("/menu-items/:id", auth, middleware.AdminMiddleware, writeLimit, h.DeleteMenuItem)
+
+		// Price history and scheduled price changes
+		api.GET("/menu-items/:id/prices", h.GetMenuItemPrices)
+		api.POST("/menu-items/:id/prices", auth, middleware.AdminMiddleware, writeLimit, h.ScheduleMenuItemPrice)
+		api.DELETE("/menu-items/:id/prices/:change_id", auth, middleware.AdminMiddleware, writeLimit, h.CancelMenuItemPrice)
+
+		// Customer reviews; admins list, hide and flag them
+		api.GET("/restaurants/:id/reviews", h.GetRestaurantReviews)
+		api.POST("/restaurants/:id/reviews", auth, writeLimit, h.CreateRestaurantReview)
+		api.GET("/menu-items/:id/reviews", h.GetMenuItemReviews)
+		api.POST("/menu-items/:id/reviews", auth, writeLimit, h.CreateMenuItemReview)
+		api.PUT("/reviews/:id", auth, writeLimit, h.UpdateReview)
+		api.DELETE("/reviews/:id", auth, writeLimit, h.DeleteReview)
+		api.GET("/reviews", auth, middleware.AdminMiddleware, h.GetReviews)
+		api.PUT("/reviews/:id/moderation", auth, middleware.AdminMiddleware, writeLimit, h.ModerateReview)
+
+		// Bulk import endpoint
+		api.POST("/import", auth, middleware.AdminMiddleware, writeLimit, h.ImportCatalogue)
+
+		// Webhook subscription endpoints (admin)
+		webhooks := api.Group("/webhooks", auth, middleware.AdminMiddleware)
+		webhooks.GET("", h.GetWebhookSubscriptions)
+		webhooks.POST("", writeLimit, h.CreateWebhookSubscription)
+		webhooks.GET("/:id", h.GetWebhookSubscription)
+		webhooks.PUT("/:id", writeLimit, h.UpdateWebhookSubscription)
+		webhooks.DELETE("/:id", writeLimit, h.DeleteWebhookSubscription)
+		webhooks.GET("/:id/deliveries", h.GetWebhookDeliveries)
+		webhooks.POST("/:id/deliveries/:delivery_id/retry", writeLimit, h.RetryWebhookDelivery)
+
+		// Live updates (Server-Sent Events)
+		api.GET("/stream", handlers.StreamEvents)
+
+		// Export endpoints (CSV, JSON Lines, XLSX)
+		api.GET("/export/restaurants", h.ExportRestaurants)
+		api.GET("/export/menu-items", h.ExportMenuItems)
+		api.GET("/export/stats", h.ExportStatistics)
 
 		// Stats endpoint
-		api.GET("/stats", handlers.GetBusinessStatistics)
+		api.GET("/stats", h.GetBusinessStatistics)
+		api.GET("/stats/price-trends", h.GetPriceTrends)
+		api.GET("/stats/trending", h.GetTrending)
 
 		// Token refresh endpoint, do not need this endpoint as we are renewing access token in middleware itself
 		// api.POST("/token/refresh", handlers.RefreshAccessToken)
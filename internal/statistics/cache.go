@@ -0,0 +1,252 @@
+// Package statistics serves the business statistics without running their
+// aggregate queries on every request. Statistics are kept in an in-process
+// cache for a TTL and dropped as soon as restaurants or menu items change.
+// The statistics of all data, the ones /api/stats returns without filters,
+// are also precomputed into the statistics_snapshots table, refreshed after
+// changes and on a schedule, so that replicas share them.
+package statistics
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"lunch_menu/internal/events"
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/repository"
+
+	"golang.org/x/sync/singleflight"
+	"gorm.io/gorm"
+)
+
+// Options configures the Cache
+type Options struct {
+	TTL             time.Duration // how long statistics are served from memory
+	RefreshInterval time.Duration // how often the snapshot is recomputed without changes
+}
+
+const (
+	// maxEntries bounds the number of cached filters; statistics of further
+	// filters are computed on every request until entries expire
+	maxEntries = 256
+	// refreshDelay collects the changes of an import or a burst of writes
+	// into one refresh of the snapshot
+	refreshDelay = 2 * time.Second
+)
+
+// watchedTables are the tables whose writes invalidate the statistics
+var watchedTables = map[string]bool{
+	"restaurants":      true,
+	"menu_items":       true,
+	"menu_item_prices": true,
+}
+
+// defaultKey is the key of the statistics of all data
+var defaultKey = models.StatisticsFilter{}.Key()
+
+// Cache serves business statistics from memory
+type Cache struct {
+	repo    repository.StatisticsRepository
+	opts    Options
+	group   singleflight.Group
+	changed chan struct{} // signals Run that the snapshot is outdated
+
+	mu        sync.Mutex
+	entries   map[string]entry
+	changedAt time.Time // of the last invalidation
+}
+
+type entry struct {
+	stats   *models.BusinessStatistics
+	expires time.Time
+}
+
+// NewCache returns a cache of the statistics computed and stored by repo.
+// It has to be told about changes of the data, see Watch and Changed.
+func NewCache(repo repository.StatisticsRepository, opts Options) *Cache {
+	return &Cache{
+		repo:    repo,
+		opts:    opts,
+		changed: make(chan struct{}, 1),
+		entries: make(map[string]entry),
+	}
+}
+
+// Watch invalidates the cache on every create, update and delete of
+// restaurants, menu items and price variants through db
+func (c *Cache) Watch(db *gorm.DB) {
+	invalidate := func(tx *gorm.DB) { c.Changed(tx.Statement.Table) }
+	cb := db.Callback()
+	_ = cb.Create().After("gorm:create").Register("statistics:invalidate", invalidate)
+	_ = cb.Update().After("gorm:update").Register("statistics:invalidate", invalidate)
+	_ = cb.Delete().After("gorm:delete").Register("statistics:invalidate", invalidate)
+}
+
+// Changed invalidates the cache if table is one the statistics are computed on
+func (c *Cache) Changed(table string) {
+	if watchedTables[table] {
+		c.Invalidate()
+	}
+}
+
+// Get returns the statistics selected by filter. They are served from memory
+// if they were computed within the TTL and nothing changed since; otherwise
+// the statistics of all data come from the snapshot, if it is still current,
+// and others are computed. Concurrent requests for the same statistics share
+// one computation.
+func (c *Cache) Get(ctx context.Context, filter models.StatisticsFilter) (*models.BusinessStatistics, error) {
+	key := filter.Key()
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	changedAt := c.changedAt
+	c.mu.Unlock()
+	if ok && time.Now().Before(e.expires) {
+		return e.stats, nil
+	}
+
+	v, err, _ := c.group.Do(key, func() (interface{}, error) {
+		if key == defaultKey {
+			if stats := c.snapshot(ctx, changedAt); stats != nil {
+				c.put(key, stats, changedAt)
+				return stats, nil
+			}
+		}
+		stats, err := c.repo.Compute(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		if key == defaultKey {
+			c.save(ctx, stats)
+		}
+		c.put(key, stats, changedAt)
+		return stats, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*models.BusinessStatistics), nil
+}
+
+// Invalidate drops the cached statistics and marks the snapshot as outdated
+func (c *Cache) Invalidate() {
+	c.mu.Lock()
+	c.entries = make(map[string]entry)
+	c.changedAt = time.Now()
+	c.mu.Unlock()
+	select {
+	case c.changed <- struct{}{}:
+	default:
+	}
+}
+
+// Run keeps the snapshot current until ctx is done: it recomputes it every
+// RefreshInterval and shortly after changes, and invalidates the cache on the
+// change events of broker, which include those made through other replicas.
+// A replica skips the refresh if another one has just stored a current
+// snapshot.
+func (c *Cache) Run(ctx context.Context, broker *events.Broker) {
+	sub, _ := broker.Subscribe(events.Filter{}, 0)
+	defer func() { broker.Unsubscribe(sub) }()
+	ticker := time.NewTicker(c.opts.RefreshInterval)
+	defer ticker.Stop()
+
+	c.refresh(ctx)
+	var delay <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-sub.C:
+			if !ok {
+				// Dropped as too slow or the broker was closed
+				sub, _ = broker.Subscribe(events.Filter{}, 0)
+			}
+			c.Invalidate()
+		case <-c.changed:
+			if delay == nil {
+				delay = time.After(refreshDelay)
+			}
+		case <-delay:
+			delay = nil
+			c.refresh(ctx)
+		case <-ticker.C:
+			c.refresh(ctx)
+		}
+	}
+}
+
+// refresh recomputes the snapshot unless it is current
+func (c *Cache) refresh(ctx context.Context) {
+	c.mu.Lock()
+	changedAt := c.changedAt
+	c.mu.Unlock()
+	if stats := c.snapshot(ctx, changedAt); stats != nil {
+		c.put(defaultKey, stats, changedAt)
+		return
+	}
+	stats, err := c.repo.Compute(ctx, models.StatisticsFilter{})
+	if err != nil {
+		slog.Error("statistics: failed to refresh statistics", slog.Any("error", err))
+		return
+	}
+	c.save(ctx, stats)
+	c.put(defaultKey, stats, changedAt)
+}
+
+// snapshot returns the stored statistics of all data if they were generated
+// after changedAt and within RefreshInterval, nil otherwise
+func (c *Cache) snapshot(ctx context.Context, changedAt time.Time) *models.BusinessStatistics {
+	snapshot, err := c.repo.Snapshot(ctx, defaultKey)
+	if err != nil {
+		slog.Warn("statistics: failed to load snapshot", slog.Any("error", err))
+		return nil
+	}
+	if snapshot == nil || snapshot.GeneratedAt.Before(changedAt) || time.Since(snapshot.GeneratedAt) >= c.opts.RefreshInterval {
+		return nil
+	}
+	var stats models.BusinessStatistics
+	if err := json.Unmarshal([]byte(snapshot.Data), &stats); err != nil {
+		slog.Warn("statistics: invalid snapshot", slog.Any("error", err))
+		return nil
+	}
+	return &stats
+}
+
+// save stores the statistics of all data as the snapshot
+func (c *Cache) save(ctx context.Context, stats *models.BusinessStatistics) {
+	data, err := json.Marshal(stats)
+	if err == nil {
+		err = c.repo.SaveSnapshot(ctx, &models.StatisticsSnapshot{
+			Key:         defaultKey,
+			Data:        string(data),
+			GeneratedAt: stats.GeneratedAt,
+		})
+	}
+	if err != nil {
+		slog.Warn("statistics: failed to store snapshot", slog.Any("error", err))
+	}
+}
+
+// put caches the statistics computed from the data as of changedAt, unless
+// the data changed while they were computed
+func (c *Cache) put(key string, stats *models.BusinessStatistics, changedAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.changedAt.Equal(changedAt) {
+		return
+	}
+	now := time.Now()
+	if len(c.entries) >= maxEntries {
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= maxEntries {
+			return
+		}
+	}
+	c.entries[key] = entry{stats: stats, expires: now.Add(c.opts.TTL)}
+}
@@ -0,0 +1,260 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"lunch_menu/internal/app"
+	"lunch_menu/internal/config"
+	"lunch_menu/internal/middleware"
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/repository/memory"
+	"lunch_menu/internal/routes"
+	"lunch_menu/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// testApp holds the repositories used by the tests, see TestMain
+var testApp *app.App
+
+// newMemoryApp returns an in-memory app with the users of the test database
+func newMemoryApp() *app.App {
+	a := app.NewMemory(memory.NewStore())
+	seedTestUsers(a)
+	return a
+}
+
+// seedTestUsers creates the users of the test database in an empty app:
+// customer1 (ID 1) and admin1 (ID 2), whose passwords are their usernames
+func seedTestUsers(a *app.App) {
+	for _, user := range []models.User{
+		{Username: "customer1", Email: "customer1@example.com", Role: "customer", IsActive: true},
+		{Username: "admin1", Email: "admin1@example.com", Role: "admin", IsActive: true},
+	} {
+		hash, err := utils.HashPassword(user.Username)
+		if err != nil {
+			panic("Failed to hash test password: " + err.Error())
+		}
+		user.PasswordHash = hash
+		if _, err := a.Users.Create(context.Background(), &user); err != nil {
+			panic("Failed to seed test user: " + err.Error())
+		}
+	}
+}
+
+// newAPIRouter serves the whole REST API from testApp
+func newAPIRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	limits, err := middleware.NewRateLimiter(config.Defaults().RateLimit)
+	if err != nil {
+		t.Fatalf("NewRateLimiter failed: %v", err)
+	}
+	t.Cleanup(limits.Close)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandler)
+	routes.SetupRoutes(router, testApp, limits)
+	return router
+}
+
+// doAPI sends body as JSON and decodes the data of the response into out, if set
+func doAPI(t *testing.T, router *gin.Engine, method, path, token string, body, out interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	var reader bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reader).Encode(body); err != nil {
+			t.Fatalf("Failed to encode body: %v", err)
+		}
+	}
+	req := httptest.NewRequest(method, path, &reader)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if out != nil && w.Code < 300 {
+		resp := models.StandardResponse{Data: out}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("%s %s: failed to decode response: %v", method, path, err)
+		}
+	}
+	return w
+}
+
+// adminToken logs in as a new admin user of the test and returns the access
+// token. Tokens issued in the same second are equal, so tests do not share a
+// user whose token another test revokes.
+func adminToken(t *testing.T, router *gin.Engine) string {
+	t.Helper()
+	username := strings.ToLower(t.Name())
+	hash, err := utils.HashPassword(username)
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+	if _, err := testApp.Users.Create(context.Background(), &models.User{
+		Username: username, Email: username + "@example.com", PasswordHash: hash, Role: "admin", IsActive: true,
+	}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	var login struct {
+		Token string `json:"token"`
+	}
+	w := doAPI(t, router, http.MethodPost, "/api/user/login", "",
+		models.UserLoginInput{Username: username, PasswordHash: username}, &login)
+	if w.Code != http.StatusOK || login.Token == "" {
+		t.Fatalf("Expected a token from login, got %d: %s", w.Code, w.Body.String())
+	}
+	return login.Token
+}
+
+func TestAPI_CRUD(t *testing.T) {
+	router := newAPIRouter(t)
+	token := adminToken(t, router)
+
+	w := doAPI(t, router, http.MethodPost, "/api/user/register", "",
+		models.UserInput{Username: "admin1", PasswordHash: "secret", Email: "other@example.com"}, nil)
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected 409 for a taken username, got %d", w.Code)
+	}
+
+	// Restaurants
+	input := models.RestaurantInput{Name: "Noodle Bar", Address: "1 Main St", Email: "noodles@example.com", Coordinate: []float64{59.9, 10.7}}
+	if w := doAPI(t, router, http.MethodPost, "/api/restaurants", "", input, nil); w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without a token, got %d", w.Code)
+	}
+	var created models.RestaurantResponse
+	if w := doAPI(t, router, http.MethodPost, "/api/restaurants", token, input, &created); w.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	restaurantPath := fmt.Sprintf("/api/restaurants/%d", created.Restaurant.ID)
+
+	var list models.RestaurantsResponse
+	doAPI(t, router, http.MethodGet, "/api/restaurants?limit=-1", "", nil, &list)
+	if !slices.ContainsFunc(list.Restaurants, func(r models.Restaurant) bool { return r.ID == created.Restaurant.ID }) {
+		t.Errorf("Expected the created restaurant to be listed, got %+v", list)
+	}
+
+	var updated models.RestaurantResponse
+	doAPI(t, router, http.MethodPut, restaurantPath, token, map[string]string{"region": "Oslo"}, &updated)
+	if updated.Restaurant.Region != "Oslo" || updated.Restaurant.Name != "Noodle Bar" {
+		t.Errorf("Expected only the region to change, got %+v", updated.Restaurant)
+	}
+
+	// Menu items
+	item := models.MenuItemInput{RestaurantID: created.Restaurant.ID, Name: "Ramen", Price: 129_00}
+	var menuItem models.MenuItem
+	if w := doAPI(t, router, http.MethodPost, "/api/menu-items", token, item, &menuItem); w.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	item.RestaurantID = 999
+	if w := doAPI(t, router, http.MethodPost, "/api/menu-items", token, item, nil); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unknown restaurant, got %d", w.Code)
+	}
+	w = doAPI(t, router, http.MethodPut, fmt.Sprintf("/api/menu-items/%d", menuItem.ID), token, map[string]float64{"price": 139}, &menuItem)
+	if w.Code != http.StatusOK || menuItem.Price != 139_00 {
+		t.Errorf("Expected the price to be updated, got %d: %s", w.Code, w.Body.String())
+	}
+	var menu models.MenuItemsResponse
+	doAPI(t, router, http.MethodGet, restaurantPath+"/menu", "", nil, &menu)
+	if menu.Total != 1 || len(menu.MenuItems) != 1 || menu.MenuItems[0].Name != "Ramen" {
+		t.Errorf("Expected the menu item on the restaurant's menu, got %+v", menu)
+	}
+
+	// Soft delete
+	if w := doAPI(t, router, http.MethodDelete, restaurantPath, token, nil, nil); w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := doAPI(t, router, http.MethodGet, restaurantPath, "", nil, nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a deleted restaurant, got %d", w.Code)
+	}
+
+	// Logging out revokes the token
+	if w := doAPI(t, router, http.MethodPost, "/api/user/logout", token, nil, nil); w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", w.Code)
+	}
+	w = doAPI(t, router, http.MethodPost, "/api/restaurants", token, input, nil)
+	var problem models.Problem
+	_ = json.Unmarshal(w.Body.Bytes(), &problem)
+	if w.Code != http.StatusUnauthorized || problem.Code != "TOKEN_REVOKED" {
+		t.Errorf("Expected the revoked token to be rejected, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestAPI_RegisterRoles(t *testing.T) {
+	router := newAPIRouter(t)
+	admin := adminToken(t, router)
+	customer := customerToken(t, router, "carol")
+	// Each registration gets a new router, as the auth tier allows 5 per minute
+	register := func(name, role, token string) (int, models.SafeUser) {
+		var out struct {
+			User models.SafeUser `json:"user"`
+		}
+		w := doAPI(t, newAPIRouter(t), http.MethodPost, "/api/user/register", token, models.UserInput{
+			Username: "register_" + name, PasswordHash: "secret", Email: "register_" + name + "@example.com", Role: role,
+		}, &out)
+		return w.Code, out.User
+	}
+
+	if code, user := register("anon", "", ""); code != http.StatusCreated || user.Role != models.RoleCustomer {
+		t.Errorf("Expected a customer by default, got %d %+v", code, user)
+	}
+	for _, tc := range []struct {
+		name  string
+		role  string
+		token string
+		code  int
+	}{
+		{"anon_admin", models.RoleAdmin, "", http.StatusUnauthorized},
+		{"customer_admin", models.RoleAdmin, customer, http.StatusForbidden},
+		{"superuser", "superuser", "", http.StatusBadRequest},
+	} {
+		if code, _ := register(tc.name, tc.role, tc.token); code != tc.code {
+			t.Errorf("%s: expected %d, got %d", tc.name, tc.code, code)
+		}
+	}
+	if _, err := testApp.Users.GetByUsername(context.Background(), "register_anon_admin"); err == nil {
+		t.Errorf("Expected no user to be created for a refused admin registration")
+	}
+	if code, user := register("by_admin", models.RoleAdmin, admin); code != http.StatusCreated || user.Role != models.RoleAdmin {
+		t.Errorf("Expected an admin to register an admin, got %d %+v", code, user)
+	}
+}
+
+func TestAPI_BusinessStatistics(t *testing.T) {
+	router := newAPIRouter(t)
+	restaurant, err := testApp.Restaurants.Create(context.Background(), &models.Restaurant{Name: "Stats Diner", Address: "2 Main St"})
+	if err != nil {
+		t.Fatalf("Failed to create restaurant: %v", err)
+	}
+	if _, err := testApp.MenuItems.Create(context.Background(), &models.MenuItem{RestaurantID: restaurant.ID, Name: "Soup", Price: 10_00, Category: "Starter"}); err != nil {
+		t.Fatalf("Failed to create menu item: %v", err)
+	}
+
+	var stats models.BusinessStatistics
+	if w := doAPI(t, router, http.MethodGet, "/api/stats", "", nil, &stats); w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if stats.ActiveRestaurants < 1 || stats.TotalMenuItems < 1 || !slices.ContainsFunc(stats.Categories, func(c models.CategoryStatistics) bool {
+		return c.Category == "Starter" && c.MenuItems >= 1 && c.Prices.Min <= 10_00
+	}) {
+		t.Errorf("Expected the new restaurant and item in the statistics, got %+v", stats)
+	}
+	if !slices.ContainsFunc(stats.RestaurantDetails, func(d models.RestaurantBusinessData) bool {
+		return d.RestaurantID == restaurant.ID && d.MenuItemCount == 1 && d.AveragePrice == 10_00 &&
+			d.MedianPrice == 10_00 && d.LastMenuUpdate != nil && !d.Stale
+	}) {
+		t.Errorf("Expected the details of the new restaurant, got %+v", stats.RestaurantDetails)
+	}
+}
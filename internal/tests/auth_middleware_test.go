@@ -1,13 +1,13 @@
 package tests
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
 	"time"
 
-	"lunch_menu/internal/database"
 	"lunch_menu/internal/middleware"
 	"lunch_menu/internal/models"
 	"lunch_menu/internal/utils"
@@ -42,7 +42,7 @@ func TestAuthMiddleware_RenewAccessToken(t *testing.T) {
 	refreshToken := utils.GenerateRandomToken()
 	expiresAt := time.Now().Add(7 * 24 * time.Hour)
 	t.Logf("Generated refresh token: %s (expires at %v)", refreshToken, expiresAt)
-	err := database.SaveRefreshToken(user.ID, refreshToken, "test-agent", "127.0.0.1", expiresAt)
+	err := testApp.Tokens.SaveRefreshToken(context.Background(), user.ID, refreshToken, "test-agent", "127.0.0.1", expiresAt)
 	if err != nil {
 		t.Fatalf("Failed to save refresh token: %v", err)
 	}
@@ -50,7 +50,7 @@ func TestAuthMiddleware_RenewAccessToken(t *testing.T) {
 	// Setup Gin router with AuthMiddleware and a test handler
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
-	router.Use(middleware.AuthMiddleware)
+	router.Use(middleware.AuthMiddleware(testApp))
 	router.GET("/protected", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"message": "success"})
 	})
@@ -0,0 +1,190 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"lunch_menu/internal/config"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestConfigLoad_FileThenEnvironment(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", `
+server:
+  port: 8080
+  shutdown_timeout: 10s
+database:
+  host: filehost
+  name: filedb
+cors:
+  allowed_origins: [https://a.example, https://b.example]
+`)
+	t.Setenv("PORT", "")
+	t.Setenv("DB_HOST", "envhost")
+	t.Setenv("DB_NAME", "")
+	t.Setenv("RATE_LIMIT", "100-M")
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Server.Port != 8080 || cfg.Server.ShutdownTimeout != 10*time.Second {
+		t.Errorf("Expected server settings from the file, got %+v", cfg.Server)
+	}
+	if cfg.Database.Host != "envhost" {
+		t.Errorf("Expected DB_HOST to override the file, got %q", cfg.Database.Host)
+	}
+	if cfg.Database.Name != "filedb" {
+		t.Errorf("Expected an empty DB_NAME to keep the file value, got %q", cfg.Database.Name)
+	}
+	if cfg.RateLimit.Rate != "100-M" {
+		t.Errorf("Expected RATE_LIMIT from the environment, got %q", cfg.RateLimit.Rate)
+	}
+	if got := strings.Join(cfg.CORS.AllowedOrigins, ","); got != "https://a.example,https://b.example" {
+		t.Errorf("Expected allowed origins from the file, got %q", got)
+	}
+	if cfg.Server.GRPCPort != 9090 {
+		t.Errorf("Expected the default gRPC port, got %d", cfg.Server.GRPCPort)
+	}
+}
+
+func TestConfigLoad_TOML(t *testing.T) {
+	path := writeConfigFile(t, "config.toml", `
+[server]
+port = 8081
+
+[log]
+level = "debug"
+`)
+	t.Setenv("PORT", "")
+	t.Setenv("LOG_LEVEL", "")
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Server.Port != 8081 || cfg.Log.Level != "debug" {
+		t.Errorf("Expected settings from the TOML file, got port %d, log level %q", cfg.Server.Port, cfg.Log.Level)
+	}
+}
+
+func TestConfigLoad_SecretFiles(t *testing.T) {
+	secret := writeConfigFile(t, "JWT_SECRET", "from-file-0123456789abcdef0123456789\n")
+	t.Setenv("JWT_SECRET", "")
+	t.Setenv("JWT_SECRET_FILE", secret)
+
+	cfg, err := config.Load("")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Auth.JWTSecret != "from-file-0123456789abcdef0123456789" {
+		t.Errorf("Expected the JWT secret from JWT_SECRET_FILE without the newline, got %q", cfg.Auth.JWTSecret)
+	}
+
+	t.Setenv("JWT_SECRET", "from-env")
+	if _, err := config.Load(""); err == nil || !strings.Contains(err.Error(), "JWT_SECRET and JWT_SECRET_FILE") {
+		t.Errorf("Expected an error when both JWT_SECRET and JWT_SECRET_FILE are set, got %v", err)
+	}
+}
+
+func TestConfigLoad_ReportsAllErrors(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", `
+server:
+  prot: 8080
+  read_timeout: soon
+`)
+	t.Setenv("DB_PORT", "postgres")
+	t.Setenv("HTTP_READ_TIMEOUT", "")
+
+	_, err := config.Load(path)
+	if err == nil {
+		t.Fatal("Expected Load to fail")
+	}
+	for _, want := range []string{
+		"server.prot: unknown setting",
+		`server.read_timeout: invalid duration "soon"`,
+		`DB_PORT (database.port): invalid integer "postgres"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to contain %q, got:\n%v", want, err)
+		}
+	}
+}
+
+func TestConfigValidate_ReportsSettingPaths(t *testing.T) {
+	cfg := config.Defaults()
+	cfg.Database.Password = "pw"
+	cfg.Auth.JWTSecret = "short"
+	cfg.RateLimit.Rate = "ten per second"
+	cfg.Log.Format = "xml"
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Expected validation to fail")
+	}
+	for _, want := range []string{"auth.jwt_secret:", "rate_limit.rate:", "log.format:"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to contain %q, got:\n%v", want, err)
+		}
+	}
+}
+
+func TestConfigValidate_SQLiteNeedsNoServer(t *testing.T) {
+	cfg := config.Defaults()
+	cfg.Auth.JWTSecret = "0123456789abcdef0123456789abcdef"
+	cfg.Database.Driver = "sqlite"
+	cfg.Database.Host = ""
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected SQLite without host and password to be valid, got %v", err)
+	}
+
+	cfg.Database.Path = ""
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "database.path") {
+		t.Errorf("Expected an error for a missing path, got %v", err)
+	}
+	cfg.Database.Driver = "mysql"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "database.driver") {
+		t.Errorf("Expected an error for an unknown driver, got %v", err)
+	}
+}
+
+func TestConfigPrint_RedactsSecrets(t *testing.T) {
+	cfg := config.Defaults()
+	cfg.Database.Password = "db-password"
+	cfg.Auth.JWTSecret = "jwt-secret"
+
+	var out strings.Builder
+	if err := cfg.Print(&out); err != nil {
+		t.Fatalf("Print failed: %v", err)
+	}
+	printed := out.String()
+	for _, secret := range []string{"db-password", "jwt-secret"} {
+		if strings.Contains(printed, secret) {
+			t.Errorf("Expected %q to be redacted, got:\n%s", secret, printed)
+		}
+	}
+	for _, want := range []string{"password: \"" + config.Redacted + "\"", "port: 8000", "shutdown_timeout: 25s"} {
+		if !strings.Contains(printed, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, printed)
+		}
+	}
+
+	// The printed form is a valid config file
+	path := writeConfigFile(t, "printed.yaml", printed)
+	t.Setenv("PORT", "")
+	t.Setenv("DB_PASSWORD", "")
+	if _, err := config.Load(path); err != nil {
+		t.Errorf("Expected the printed configuration to load, got %v", err)
+	}
+}
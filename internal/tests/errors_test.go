@@ -0,0 +1,113 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/middleware"
+	"lunch_menu/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func serveError(t *testing.T, err error) (*httptest.ResponseRecorder, models.Problem) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandler)
+	router.GET("/fail", func(c *gin.Context) {
+		_ = c.Error(err)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/fail", nil)
+	router.ServeHTTP(w, req)
+
+	var problem models.Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("Failed to decode problem: %v. Body: %s", err, w.Body.String())
+	}
+	return w, problem
+}
+
+func TestErrorHandler_ProblemDetails(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"not found", apperrors.NotFound("restaurant", 7), http.StatusNotFound, "NOT_FOUND"},
+		{"validation", apperrors.InvalidFields([]string{"name", "email"}), http.StatusBadRequest, "VALIDATION_FAILED"},
+		{"conflict", apperrors.Conflict("user already exists"), http.StatusConflict, "CONFLICT"},
+		{"unauthorized", apperrors.Unauthorized("TOKEN_REVOKED", "Token has been revoked"), http.StatusUnauthorized, "TOKEN_REVOKED"},
+		{"plain error", errors.New("pq: connection refused"), http.StatusInternalServerError, "INTERNAL_ERROR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, problem := serveError(t, tt.err)
+			if w.Code != tt.wantStatus || problem.Status != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d (problem %d)", tt.wantStatus, w.Code, problem.Status)
+			}
+			if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, middleware.ProblemContentType) {
+				t.Errorf("Expected %s, got %q", middleware.ProblemContentType, ct)
+			}
+			if problem.Code != tt.wantCode {
+				t.Errorf("Expected code %s, got %s", tt.wantCode, problem.Code)
+			}
+			if problem.Instance != "/fail" || problem.Title != http.StatusText(tt.wantStatus) {
+				t.Errorf("Unexpected instance or title: %+v", problem)
+			}
+			if strings.Contains(problem.Detail, "connection refused") {
+				t.Errorf("Expected internal details to be hidden, got %q", problem.Detail)
+			}
+		})
+	}
+}
+
+func TestErrorHandler_FieldErrors(t *testing.T) {
+	_, problem := serveError(t, apperrors.InvalidFields([]string{"name", "email"}))
+	if len(problem.Errors) != 2 {
+		t.Fatalf("Expected 2 field errors, got %+v", problem.Errors)
+	}
+	if problem.Errors[1].Field != "email" || problem.Errors[1].Message != "must be a valid email address" {
+		t.Errorf("Unexpected field error: %+v", problem.Errors[1])
+	}
+}
+
+func TestAppErrors_Is(t *testing.T) {
+	err := error(apperrors.NotFound("menu item", 3))
+	if !errors.Is(err, apperrors.ErrNotFound) {
+		t.Error("Expected not found error to match ErrNotFound")
+	}
+	if errors.Is(err, apperrors.ErrConflict) {
+		t.Error("Expected not found error not to match ErrConflict")
+	}
+	if !errors.Is(apperrors.Unauthorized("INVALID_TOKEN", "bad"), apperrors.ErrUnauthorized) {
+		t.Error("Expected unauthorized errors to match regardless of code")
+	}
+}
+
+func TestFromBinding_UsesJSONFieldNames(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request, _ = http.NewRequest("POST", "/", strings.NewReader(`{"password_hash": "secret"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	var input models.UserLoginInput
+	err := c.ShouldBindJSON(&input)
+	if err == nil {
+		t.Fatal("Expected binding error for missing username")
+	}
+	appErr := apperrors.FromBinding(err)
+	if appErr.Kind != apperrors.KindValidation || len(appErr.Fields) == 0 {
+		t.Fatalf("Expected validation error with fields, got %+v", appErr)
+	}
+	if appErr.Fields[0].Field != "username" || appErr.Fields[0].Message != "is required" {
+		t.Errorf("Unexpected field error: %+v", appErr.Fields[0])
+	}
+}
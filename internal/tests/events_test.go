@@ -0,0 +1,173 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"lunch_menu/internal/app"
+	"lunch_menu/internal/events"
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/repository/memory"
+)
+
+func TestBrokerFilterAndResume(t *testing.T) {
+	broker := events.NewBroker(memory.NewStore().Outbox(), events.Options{HistorySize: 3, SubscriberBuffer: 4})
+	broker.Publish(events.Event{ID: 1, Type: "menu_item.created", RestaurantID: 6, Region: "Solna"})
+	broker.Publish(events.Event{ID: 2, Type: "menu_item.created", RestaurantID: 4, Region: "Uppsala"})
+	broker.Publish(events.Event{ID: 3, Type: "menu_item.updated", RestaurantID: 6, Region: "Solna"})
+
+	// Resume after event 1, only Solna events
+	sub, replay := broker.Subscribe(events.Filter{Region: "Solna"}, 1)
+	defer broker.Unsubscribe(sub)
+	if len(replay) != 1 || replay[0].ID != 3 {
+		t.Fatalf("Expected replay of event 3, got %+v", replay)
+	}
+
+	broker.Publish(events.Event{ID: 4, Type: "restaurant.updated", RestaurantID: 4, Region: "Uppsala"})
+	broker.Publish(events.Event{ID: 5, Type: "restaurant.updated", RestaurantID: 6, Region: "Solna"})
+	broker.Publish(events.Event{ID: 5, Type: "restaurant.updated", RestaurantID: 6, Region: "Solna"}) // duplicate
+
+	select {
+	case e := <-sub.C:
+		if e.ID != 5 {
+			t.Errorf("Expected event 5, got %d", e.ID)
+		}
+	default:
+		t.Fatal("Expected a live event")
+	}
+	select {
+	case e := <-sub.C:
+		t.Errorf("Expected no further events, got %d", e.ID)
+	default:
+	}
+
+	// History is bounded: event 1 and 2 have been dropped
+	sub2, replay := broker.Subscribe(events.Filter{}, 1)
+	defer broker.Unsubscribe(sub2)
+	if len(replay) != 3 || replay[0].ID != 3 {
+		t.Errorf("Expected replay of events 3-5, got %+v", replay)
+	}
+}
+
+func TestBrokerPublishesOutboxEventsOfMemoryStore(t *testing.T) {
+	a := app.NewMemory(memory.NewStore())
+	restaurant, err := a.Restaurants.Create(context.Background(), &models.Restaurant{Name: "Event Deli", Address: "1 Event St", Region: "Solna"})
+	if err != nil {
+		t.Fatalf("Failed to create restaurant: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	broker := events.Start(ctx, a.Outbox, events.Options{HistorySize: 10, SubscriberBuffer: 4, PollInterval: 10 * time.Millisecond})
+	sub, replay := broker.Subscribe(events.Filter{RestaurantID: restaurant.ID}, 0)
+	defer broker.Unsubscribe(sub)
+	if len(replay) != 0 || broker.LastID() == 0 {
+		t.Fatalf("Expected the history to be loaded without replay, got %+v and last ID %d", replay, broker.LastID())
+	}
+
+	if _, err := a.MenuItems.Create(context.Background(), &models.MenuItem{RestaurantID: restaurant.ID, Name: "Soup", Price: 95_00, Category: "Starter"}); err != nil {
+		t.Fatalf("Failed to create menu item: %v", err)
+	}
+	select {
+	case e := <-sub.C:
+		if e.Type != models.EventMenuItemCreated || e.Region != "Solna" {
+			t.Errorf("Expected the menu item event, got %+v", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the new outbox event to be published")
+	}
+}
+
+func TestAPI_StreamEvents(t *testing.T) {
+	server := httptest.NewServer(newAPIRouter(t))
+	t.Cleanup(server.Close) // after the streams are closed
+	previous := events.Default
+	t.Cleanup(func() { events.Default = previous })
+
+	events.Default = nil
+	if resp := openStream(t, server.URL+"/api/stream", ""); resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 without a broker, got %d", resp.StatusCode)
+	}
+
+	broker := events.NewBroker(memory.NewStore().Outbox(), events.Options{HistorySize: 10, SubscriberBuffer: 4, Heartbeat: 50 * time.Millisecond})
+	events.Default = broker
+	for id, region := range []string{"Solna", "Solna", "Uppsala", "Solna"} {
+		broker.Publish(events.Event{ID: uint(id + 1), Type: models.EventMenuItemUpdated, RestaurantID: 6, Region: region})
+	}
+
+	// The Last-Event-ID header wins over the query parameter
+	resp := openStream(t, server.URL+"/api/stream?region=Solna&last_event_id=1", "2")
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" ||
+		resp.Header.Get("Cache-Control") != "no-cache" || resp.Header.Get("X-Accel-Buffering") != "no" {
+		t.Fatalf("Expected an event stream, got %d %v", resp.StatusCode, resp.Header)
+	}
+	stream := bufio.NewReader(resp.Body)
+	if block := readStreamBlock(t, stream); !strings.Contains(block, "id:4\n") || !strings.Contains(block, "event:"+models.EventMenuItemUpdated) {
+		t.Errorf("Expected the replay of event 4 only, got %q", block)
+	}
+	if block := readStreamBlock(t, stream); block != ": heartbeat" {
+		t.Errorf("Expected a heartbeat on the idle stream, got %q", block)
+	}
+	broker.Publish(events.Event{ID: 5, Type: models.EventMenuItemCreated, RestaurantID: 6, Region: "Uppsala"})
+	broker.Publish(events.Event{ID: 6, Type: models.EventMenuItemCreated, RestaurantID: 6, Region: "Solna"})
+	block := readStreamBlock(t, stream)
+	for block == ": heartbeat" {
+		block = readStreamBlock(t, stream)
+	}
+	if !strings.Contains(block, "id:6\n") {
+		t.Errorf("Expected the live Solna event 6, got %q", block)
+	}
+
+	// Without the header, the query parameter is used
+	stream = bufio.NewReader(openStream(t, server.URL+"/api/stream?last_event_id=4", "").Body)
+	for _, id := range []string{"5", "6"} {
+		if block := readStreamBlock(t, stream); !strings.Contains(block, "id:"+id+"\n") {
+			t.Errorf("Expected the replay of event %s, got %q", id, block)
+		}
+	}
+
+	if resp := openStream(t, server.URL+"/api/stream", "abc"); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid Last-Event-ID, got %d", resp.StatusCode)
+	}
+}
+
+// openStream requests an event stream that is closed when the test ends
+func openStream(t *testing.T, url, lastEventID string) *http.Response {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to open stream: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+// readStreamBlock reads the lines of the next event or comment of a stream
+func readStreamBlock(t *testing.T, r *bufio.Reader) string {
+	t.Helper()
+	var lines []string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Failed to read stream after %q: %v", lines, err)
+		}
+		if line = strings.TrimSuffix(line, "\n"); line == "" {
+			return strings.Join(lines, "\n")
+		}
+		lines = append(lines, line)
+	}
+}
@@ -0,0 +1,249 @@
+package tests
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"lunch_menu/internal/export"
+	"lunch_menu/internal/models"
+)
+
+func TestExportCSV_EscapesFormulas(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := export.NewWriter(export.FormatCSV, &buf)
+	if err != nil {
+		t.Fatalf("NewWriter failed: %v", err)
+	}
+	values := []interface{}{"=SUM(A1:A9)", "+46 8 123", "-1", "@cmd", "\tTab", "\rReturn", "Plain", "", 12.5, true}
+	if err := w.WriteHeader([]string{"eq", "plus", "minus", "at", "tab", "cr", "plain", "empty", "number", "bool"}); err != nil {
+		t.Fatalf("WriteHeader failed: %v", err)
+	}
+	if err := w.WriteRow(values); err != nil {
+		t.Fatalf("WriteRow failed: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read CSV: %v", err)
+	}
+	expected := []string{"'=SUM(A1:A9)", "'+46 8 123", "'-1", "'@cmd", "'\tTab", "'\rReturn", "Plain", "", "12.5", "true"}
+	if len(records) != 2 || strings.Join(records[1], "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected row %q, got %q", expected, records)
+	}
+}
+
+func TestExportJSONL_KeepsColumnOrder(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := export.NewWriter(export.FormatJSONL, &buf)
+	if err != nil {
+		t.Fatalf("NewWriter failed: %v", err)
+	}
+	columns := []string{"zeta", "alpha", "middle", "at"}
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+	if err := w.WriteHeader(columns); err != nil {
+		t.Fatalf("WriteHeader failed: %v", err)
+	}
+	for _, row := range [][]interface{}{{1, "=a", nil, at}, {2, "b\nc", true, at}} {
+		if err := w.WriteRow(row); err != nil {
+			t.Fatalf("WriteRow failed: %v", err)
+		}
+	}
+	if err := w.WriteRow([]interface{}{3}); err == nil {
+		t.Error("Expected an error for a row shorter than the header")
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected one line per row, got %q", buf.String())
+	}
+	for _, line := range lines {
+		if keys := jsonKeys(t, line); strings.Join(keys, ",") != strings.Join(columns, ",") {
+			t.Errorf("Expected keys in column order %v, got %v", columns, keys)
+		}
+	}
+	var first map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("Failed to decode line: %v", err)
+	}
+	if first["alpha"] != "=a" || first["middle"] != nil || first["at"] != "2026-03-01T11:00:00Z" {
+		t.Errorf("Expected raw strings, null and UTC times, got %v", first)
+	}
+}
+
+func TestExportXLSX_ContainsHeaderRow(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := export.NewWriter(export.FormatXLSX, &buf)
+	if err != nil {
+		t.Fatalf("NewWriter failed: %v", err)
+	}
+	if err := w.WriteHeader([]string{"id", "name <&>"}); err != nil {
+		t.Fatalf("WriteHeader failed: %v", err)
+	}
+	if err := w.WriteRow([]interface{}{uint(7), "Soup"}); err != nil {
+		t.Fatalf("WriteRow failed: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	sheet := xlsxSheet(t, buf.Bytes())
+	header := `<row r="1"><c t="inlineStr"><is><t xml:space="preserve">id</t></is></c>` +
+		`<c t="inlineStr"><is><t xml:space="preserve">name &lt;&amp;&gt;</t></is></c></row>`
+	if !strings.Contains(sheet, header) {
+		t.Errorf("Expected the header row in the sheet, got %s", sheet)
+	}
+	if !strings.Contains(sheet, `<row r="2"><c><v>7</v></c>`) {
+		t.Errorf("Expected the data row after the header, got %s", sheet)
+	}
+}
+
+func TestExportNegotiateFormat(t *testing.T) {
+	tests := []struct {
+		format, accept, expected string
+	}{
+		{"", "", export.FormatCSV},
+		{"", "text/html, */*", export.FormatCSV},
+		{"", "application/x-ndjson", export.FormatJSONL},
+		{"", "text/html, application/jsonl;q=0.9", export.FormatJSONL},
+		{"", export.ContentTypeXLSX, export.FormatXLSX},
+		{"XLSX", "text/csv", export.FormatXLSX},
+		{"ndjson", "", export.FormatJSONL},
+		{"csv", "application/x-ndjson", export.FormatCSV},
+	}
+	for _, tt := range tests {
+		if format, err := export.NegotiateFormat(tt.format, tt.accept); err != nil || format != tt.expected {
+			t.Errorf("NegotiateFormat(%q, %q) = %q, %v; expected %q", tt.format, tt.accept, format, err, tt.expected)
+		}
+	}
+	if _, err := export.NegotiateFormat("pdf", ""); err == nil {
+		t.Error("Expected an error for an unsupported format")
+	}
+}
+
+func TestAPI_ExportMenuItems(t *testing.T) {
+	router := newAPIRouter(t)
+	restaurant, err := testApp.Restaurants.Create(context.Background(), &models.Restaurant{Name: "Export Bistro", Address: "1 Export St", Region: "Solna"})
+	if err != nil {
+		t.Fatalf("Failed to create restaurant: %v", err)
+	}
+	for _, name := range []string{"=HYPERLINK(\"x\")", "Second", "Third"} {
+		if _, err := testApp.MenuItems.Create(context.Background(), &models.MenuItem{RestaurantID: restaurant.ID, Name: name, Price: 100_00, Category: "Main Course"}); err != nil {
+			t.Fatalf("Failed to create menu item: %v", err)
+		}
+	}
+	path := fmt.Sprintf("/api/export/menu-items?restaurant_id=%d", restaurant.ID)
+
+	w := doExport(router, path, "")
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != export.ContentTypeCSV {
+		t.Fatalf("Expected CSV by default, got %d %q: %s", w.Code, w.Header().Get("Content-Type"), w.Body.String())
+	}
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read CSV: %v", err)
+	}
+	if len(records) != 4 || records[0][0] != "id" || records[1][3] != "'=HYPERLINK(\"x\")" {
+		t.Errorf("Expected the header and 3 escaped rows, got %q", records)
+	}
+
+	w = doExport(router, path+"&limit=1&offset=1", "application/x-ndjson")
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != export.ContentTypeJSONL {
+		t.Fatalf("Expected JSON Lines from the Accept header, got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	lines := strings.Split(strings.TrimSuffix(w.Body.String(), "\n"), "\n")
+	if len(lines) != 1 || !strings.Contains(lines[0], `"name":"Second"`) {
+		t.Errorf("Expected only the second item, got %q", w.Body.String())
+	}
+
+	w = doExport(router, path+"&format=xlsx&offset=2", "application/x-ndjson")
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != export.ContentTypeXLSX {
+		t.Fatalf("Expected ?format= to win over Accept, got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	sheet := xlsxSheet(t, w.Body.Bytes())
+	if !strings.Contains(sheet, "Third") || strings.Contains(sheet, "Second") || strings.Contains(sheet, `<row r="3">`) {
+		t.Errorf("Expected the header and the third item only, got %s", sheet)
+	}
+
+	if w := doExport(router, path+"&format=pdf", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unsupported format, got %d", w.Code)
+	}
+}
+
+func doExport(router http.Handler, path, accept string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// jsonKeys returns the keys of a JSON object in the order they appear
+func jsonKeys(t *testing.T, line string) []string {
+	t.Helper()
+	dec := json.NewDecoder(strings.NewReader(line))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		t.Fatalf("Expected a JSON object, got %q", line)
+	}
+	var keys []string
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			t.Fatalf("Failed to decode %q: %v", line, err)
+		}
+		keys = append(keys, tok.(string))
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			t.Fatalf("Failed to decode %q: %v", line, err)
+		}
+	}
+	return keys
+}
+
+// xlsxSheet opens an XLSX workbook and returns its worksheet
+func xlsxSheet(t *testing.T, data []byte) string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Failed to open workbook: %v", err)
+	}
+	parts := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", f.Name, err)
+		}
+		body, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", f.Name, err)
+		}
+		parts[f.Name] = string(body)
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("Expected part %s in the workbook", name)
+		}
+	}
+	sheet, ok := parts["xl/worksheets/sheet1.xml"]
+	if !ok || !strings.HasSuffix(sheet, "</sheetData></worksheet>") {
+		t.Fatalf("Expected a complete worksheet, got %q", sheet)
+	}
+	return sheet
+}
@@ -0,0 +1,173 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"lunch_menu/internal/models"
+)
+
+func TestAPI_Preferences(t *testing.T) {
+	router := newAPIRouter(t)
+	alice := customerToken(t, router, "alice")
+
+	var prefs models.UserPreferences
+	w := doAPI(t, router, http.MethodGet, "/api/user/me/preferences", alice, nil, &prefs)
+	if w.Code != http.StatusOK || prefs.HomeRegion != "" || len(prefs.Dietary) != 0 {
+		t.Fatalf("Expected empty preferences, got %d: %s", w.Code, w.Body.String())
+	}
+
+	region := " Solna "
+	dietary := []string{"Vegetarian", " Salad", "vegetarian"}
+	w = doAPI(t, router, http.MethodPut, "/api/user/me/preferences", alice,
+		models.UserPreferencesInput{HomeRegion: &region, Dietary: &dietary}, &prefs)
+	if w.Code != http.StatusOK || prefs.HomeRegion != "Solna" || !slices.Equal(prefs.Dietary, []string{"Vegetarian", "Salad"}) {
+		t.Fatalf("Expected trimmed preferences without duplicates, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Only the given fields change
+	dietary = []string{}
+	doAPI(t, router, http.MethodPut, "/api/user/me/preferences", alice, models.UserPreferencesInput{Dietary: &dietary}, nil)
+	doAPI(t, router, http.MethodGet, "/api/user/me/preferences", alice, nil, &prefs)
+	if prefs.HomeRegion != "Solna" || len(prefs.Dietary) != 0 {
+		t.Errorf("Expected the dietary preferences to be cleared only, got %+v", prefs)
+	}
+
+	long := strings.Repeat("a", 101)
+	for _, tc := range []struct {
+		name  string
+		input models.UserPreferencesInput
+	}{
+		{"comma", models.UserPreferencesInput{Dietary: &[]string{"Thai,Sushi"}}},
+		{"blank", models.UserPreferencesInput{Dietary: &[]string{" "}}},
+		{"long region", models.UserPreferencesInput{HomeRegion: &long}},
+	} {
+		if w := doAPI(t, router, http.MethodPut, "/api/user/me/preferences", alice, tc.input, nil); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", tc.name, w.Code)
+		}
+	}
+	if w := doAPI(t, router, http.MethodGet, "/api/user/me/preferences", "", nil, nil); w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without a token, got %d", w.Code)
+	}
+}
+
+func TestAPI_FavoritesAndFeed(t *testing.T) {
+	router := newAPIRouter(t)
+	alice := customerToken(t, router, "alice")
+	bob := customerToken(t, router, "bob")
+
+	home, err := testApp.Restaurants.Create(context.Background(), &models.Restaurant{Name: "Feed Home", Region: "Feedville"})
+	if err != nil {
+		t.Fatalf("Failed to create restaurant: %v", err)
+	}
+	away, _ := testApp.Restaurants.Create(context.Background(), &models.Restaurant{Name: "Feed Away", Region: "Elsewhere"})
+	starred, _ := testApp.Restaurants.Create(context.Background(), &models.Restaurant{Name: "Feed Starred", Region: "Elsewhere"})
+	newItem := func(restaurantID uint, name, category string) *models.MenuItem {
+		item, err := testApp.MenuItems.Create(context.Background(), &models.MenuItem{RestaurantID: restaurantID, Name: name, Category: category, Price: 100_00})
+		if err != nil {
+			t.Fatalf("Failed to create menu item: %v", err)
+		}
+		return item
+	}
+	favorite := newItem(away.ID, "Feed favorite", "Soup")
+	ofStarred := newItem(starred.ID, "Feed of starred", "Soup")
+	dietary := newItem(away.ID, "Feed dietary", "FeedVegan")
+	local := newItem(home.ID, "Feed local", "Soup")
+	localDietary := newItem(home.ID, "Feed local dietary", "feedvegan")
+	deleted := newItem(away.ID, "Feed deleted", "Soup")
+
+	var fav models.Favorite
+	w := doAPI(t, router, http.MethodPut, fmt.Sprintf("/api/user/me/favorites/menu-items/%d", favorite.ID), alice, nil, &fav)
+	if w.Code != http.StatusOK || fav.TargetType != models.FavoriteMenuItem || fav.TargetID != favorite.ID {
+		t.Fatalf("Expected the menu item to be starred, got %d: %s", w.Code, w.Body.String())
+	}
+	var again models.Favorite
+	doAPI(t, router, http.MethodPut, fmt.Sprintf("/api/user/me/favorites/menu-items/%d", favorite.ID), alice, nil, &again)
+	if again.ID != fav.ID {
+		t.Errorf("Expected starring again to return the same favorite, got %+v", again)
+	}
+	doAPI(t, router, http.MethodPut, fmt.Sprintf("/api/user/me/favorites/restaurants/%d", starred.ID), alice, nil, nil)
+	doAPI(t, router, http.MethodPut, fmt.Sprintf("/api/user/me/favorites/menu-items/%d", deleted.ID), alice, nil, nil)
+	doAPI(t, router, http.MethodPut, fmt.Sprintf("/api/user/me/favorites/restaurants/%d", home.ID), bob, nil, nil)
+	if w := doAPI(t, router, http.MethodPut, "/api/user/me/favorites/restaurants/999999", alice, nil, nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown restaurant, got %d", w.Code)
+	}
+	if err := testApp.MenuItems.Delete(context.Background(), deleted.ID); err != nil {
+		t.Fatalf("Failed to delete menu item: %v", err)
+	}
+
+	// Deleted favorites are left out, other users' favorites are not shown
+	var favorites models.Favorites
+	doAPI(t, router, http.MethodGet, "/api/user/me/favorites", alice, nil, &favorites)
+	if len(favorites.Restaurants) != 1 || favorites.Restaurants[0].ID != starred.ID ||
+		len(favorites.MenuItems) != 1 || favorites.MenuItems[0].ID != favorite.ID {
+		t.Errorf("Expected the starred restaurant and menu item, got %+v", favorites)
+	}
+
+	region, categories := "feedville", []string{"FeedVegan"}
+	doAPI(t, router, http.MethodPut, "/api/user/me/preferences", alice,
+		models.UserPreferencesInput{HomeRegion: &region, Dietary: &categories}, nil)
+
+	var feed models.FeedResponse
+	w = doAPI(t, router, http.MethodGet, "/api/user/me/feed?limit=5", alice, nil, &feed)
+	if w.Code != http.StatusOK || len(feed.Items) != 5 {
+		t.Fatalf("Expected a page of 5 feed items, got %d: %s", w.Code, w.Body.String())
+	}
+	want := []struct {
+		id      uint
+		reasons []string
+	}{
+		{favorite.ID, []string{models.FeedFavoriteMenuItem}},
+		{ofStarred.ID, []string{models.FeedFavoriteRestaurant}},
+		{localDietary.ID, []string{models.FeedDietary, models.FeedHomeRegion}},
+		{dietary.ID, []string{models.FeedDietary}},
+		{local.ID, []string{models.FeedHomeRegion}},
+	}
+	for i, item := range feed.Items {
+		if item.ID != want[i].id || !slices.Equal(item.Reasons, want[i].reasons) {
+			t.Errorf("Feed item %d: expected %d %v, got %d %v", i, want[i].id, want[i].reasons, item.ID, item.Reasons)
+		}
+	}
+	if feed.Items[4].RestaurantName != "Feed Home" || feed.Items[4].Region != "Feedville" || feed.Items[4].VAT == nil {
+		t.Errorf("Expected the restaurant and VAT of feed items, got %+v", feed.Items[4])
+	}
+
+	total := feed.Total
+	var seen int64
+	for offset := 0; ; offset += models.MaxFeedLimit {
+		doAPI(t, router, http.MethodGet, fmt.Sprintf("/api/user/me/feed?limit=%d&offset=%d", models.MaxFeedLimit, offset), alice, nil, &feed)
+		if len(feed.Items) == 0 {
+			break
+		}
+		seen += int64(len(feed.Items))
+		for _, item := range feed.Items {
+			if item.ID == deleted.ID {
+				t.Errorf("Expected deleted menu items to be left out of the feed")
+			}
+		}
+	}
+	if seen != total {
+		t.Errorf("Expected %d feed items over all pages, got %d", total, seen)
+	}
+	if w := doAPI(t, router, http.MethodGet, "/api/user/me/feed?limit=1000", alice, nil, nil); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a limit above %d, got %d", models.MaxFeedLimit, w.Code)
+	}
+
+	w = doAPI(t, router, http.MethodDelete, fmt.Sprintf("/api/user/me/favorites/menu-items/%d", favorite.ID), alice, nil, nil)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected the favorite to be removed, got %d", w.Code)
+	}
+	if w := doAPI(t, router, http.MethodDelete, fmt.Sprintf("/api/user/me/favorites/menu-items/%d", favorite.ID), alice, nil, nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a favorite that was removed, got %d", w.Code)
+	}
+	if w := doAPI(t, router, http.MethodDelete, fmt.Sprintf("/api/user/me/favorites/menu-items/%d", deleted.ID), alice, nil, nil); w.Code != http.StatusOK {
+		t.Errorf("Expected deleted menu items to be unstarred, got %d", w.Code)
+	}
+	if w := doAPI(t, router, http.MethodGet, "/api/user/me/feed", "", nil, nil); w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without a token, got %d", w.Code)
+	}
+}
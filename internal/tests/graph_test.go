@@ -0,0 +1,171 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"lunch_menu/internal/graph"
+	"lunch_menu/internal/models"
+
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+func TestGraphLoaderBatchesKeys(t *testing.T) {
+	var batches [][]int
+	loader := graph.NewLoader(func(keys []int) (map[int]string, error) {
+		batches = append(batches, keys)
+		values := make(map[int]string)
+		for _, k := range keys {
+			if k != 3 {
+				values[k] = strings.Repeat("x", k)
+			}
+		}
+		return values, nil
+	})
+
+	first := loader.Load(1)
+	second := loader.Load(2)
+	again := loader.Load(1)
+	missing := loader.Load(3)
+
+	if v, err := second(); err != nil || v != "xx" {
+		t.Errorf("Expected xx, got %q (%v)", v, err)
+	}
+	if v, _ := first(); v != "x" {
+		t.Errorf("Expected x, got %q", v)
+	}
+	if v, _ := again(); v != "x" {
+		t.Errorf("Expected x, got %q", v)
+	}
+	if v, _ := missing(); v != "" {
+		t.Errorf("Expected zero value for missing key, got %q", v)
+	}
+	if len(batches) != 1 || len(batches[0]) != 3 {
+		t.Fatalf("Expected a single batch of 3 keys, got %v", batches)
+	}
+
+	// Keys loaded later form a new batch, cached keys are not fetched again
+	next := loader.Load(4)
+	cached := loader.Load(2)
+	next()
+	cached()
+	if len(batches) != 2 || len(batches[1]) != 1 || batches[1][0] != 4 {
+		t.Errorf("Expected second batch [4], got %v", batches)
+	}
+}
+
+func TestGraphCheckLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantErr string
+	}{
+		{"simple", `{ restaurants { name menuItems { name price } } }`, ""},
+		{"too deep", `{ restaurants { menuItems { restaurant { menuItems { restaurant { menuItems { name } } } } } } }`, "depth"},
+		{"too complex", `{ restaurants(limit: 100) { menuItems { name restaurant { name } } } }`, "complexity"},
+		{"fragment depth", `query { restaurants { ...R } } fragment R on Restaurant { menuItems { restaurant { menuItems { restaurant { menuItems { name } } } } } }`, "depth"},
+		{"introspection", `{ __schema { types { fields { type { ofType { ofType { ofType { name } } } } } } } }`, ""},
+		{"negative limit", `{ restaurants(limit: -1) { name } }`, "at least 1"},
+		{"zero limit", `{ menuItems(restaurantId: "1", limit: 0) { name } }`, "at least 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.Parse(parser.ParseParams{Source: tt.query})
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			err = graph.CheckLimits(doc, "", nil)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("Expected %s error, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	query := `query($limit: Int) { restaurants(limit: $limit) { name menuItems { name } } }`
+	result := graph.Execute(context.Background(), testApp, &graph.Request{Query: query, Variables: map[string]interface{}{"limit": -1.0}}, nil)
+	if len(result.Errors) == 0 || !strings.Contains(result.Errors[0].Message, "at least 1") || result.Data != nil {
+		t.Errorf("Expected a negative limit variable to be rejected, got %+v", result)
+	}
+}
+
+func TestGraphMutationRules(t *testing.T) {
+	mutation := `mutation { deleteMenuItem(id: "1") }`
+
+	result := graph.Execute(context.Background(), testApp, &graph.Request{Query: mutation}, nil)
+	if len(result.Errors) == 0 || result.Errors[0].Message != graph.ErrUnauthenticated.Error() {
+		t.Errorf("Expected authentication error, got %+v", result.Errors)
+	}
+
+	result = graph.Execute(context.Background(), testApp, &graph.Request{Query: mutation}, map[string]interface{}{"user_id": 1.0, "role": "customer"})
+	if len(result.Errors) == 0 || result.Errors[0].Message != graph.ErrForbidden.Error() {
+		t.Errorf("Expected customers to be refused, got %+v", result.Errors)
+	}
+
+	result = graph.Execute(context.Background(), testApp, &graph.Request{Query: mutation, QueriesOnly: true}, map[string]interface{}{"user_id": 2.0, "role": "admin"})
+	if len(result.Errors) == 0 || !strings.Contains(result.Errors[0].Message, "POST") {
+		t.Errorf("Expected mutation over GET to be rejected, got %+v", result.Errors)
+	}
+
+	result = graph.Execute(context.Background(), testApp, &graph.Request{Query: `{ restaurants { unknownField } }`}, nil)
+	if len(result.Errors) == 0 {
+		t.Error("Expected validation error for unknown field")
+	}
+}
+
+func TestGraphPrices(t *testing.T) {
+	restaurant, err := testApp.Restaurants.Create(context.Background(), &models.Restaurant{Name: "Graph Grill", Address: "4 Main St"})
+	if err != nil {
+		t.Fatalf("Failed to create restaurant: %v", err)
+	}
+	claims := map[string]interface{}{"user_id": 2.0, "role": "admin"}
+	query := fmt.Sprintf(`mutation { createMenuItem(input: {restaurantId: "%d", name: "Burger", price: 129}) {
+		price currency vatRate priceExclVat vatAmount } }`, restaurant.ID)
+	result := graph.Execute(context.Background(), testApp, &graph.Request{Query: query}, claims)
+	if len(result.Errors) > 0 {
+		t.Fatalf("Unexpected errors: %+v", result.Errors)
+	}
+	item := result.Data.(map[string]interface{})["createMenuItem"].(map[string]interface{})
+	if item["price"] != 129.0 || item["currency"] != "SEK" || item["vatRate"] != 12.0 ||
+		item["priceExclVat"] != 115.18 || item["vatAmount"] != 13.82 {
+		t.Errorf("Unexpected price fields: %v", item)
+	}
+
+	query = fmt.Sprintf(`mutation { createMenuItem(input: {restaurantId: "%d", name: "Fries", price: 39.999}) { id } }`, restaurant.ID)
+	if result := graph.Execute(context.Background(), testApp, &graph.Request{Query: query}, claims); len(result.Errors) == 0 {
+		t.Error("Expected an error for a price with three decimals")
+	}
+
+	query = fmt.Sprintf(`mutation { createMenuItem(input: {restaurantId: "%d", name: "Wrap", prices: [
+		{tier: "standard", price: 99, isDefault: true}, {tier: "student", price: 79}]}) { id price prices { tier price isDefault } } }`, restaurant.ID)
+	result = graph.Execute(context.Background(), testApp, &graph.Request{Query: query}, claims)
+	if len(result.Errors) > 0 {
+		t.Fatalf("Unexpected errors: %+v", result.Errors)
+	}
+	item = result.Data.(map[string]interface{})["createMenuItem"].(map[string]interface{})
+	prices, _ := item["prices"].([]interface{})
+	if item["price"] != 99.0 || len(prices) != 2 || prices[0].(map[string]interface{})["isDefault"] != true {
+		t.Errorf("Expected the default variant's price and both variants, got %v", item)
+	}
+	query = fmt.Sprintf(`{ menuItem(id: "%s", priceTier: "student") { price priceTier } }`, item["id"])
+	result = graph.Execute(context.Background(), testApp, &graph.Request{Query: query}, nil)
+	if got := result.Data.(map[string]interface{})["menuItem"].(map[string]interface{}); got["price"] != 79.0 || got["priceTier"] != "student" {
+		t.Errorf("Expected the student price, got %v (%+v)", got, result.Errors)
+	}
+}
+
+func TestGraphStatistics(t *testing.T) {
+	query := `{ statistics { currency averagePrice prices { median p90 } regions { region cheapest { name price } } restaurantDetails { averagePrice lastMenuUpdate } } }`
+	result := graph.Execute(context.Background(), testApp, &graph.Request{Query: query}, nil)
+	if len(result.Errors) > 0 {
+		t.Fatalf("Unexpected errors: %+v", result.Errors)
+	}
+	stats := result.Data.(map[string]interface{})["statistics"].(map[string]interface{})
+	if stats["currency"] != "SEK" {
+		t.Errorf("Expected statistics in SEK, got %v", stats["currency"])
+	}
+}
@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"lunch_menu/internal/grpcserver"
+	pb "lunch_menu/internal/pb/lunchmenu/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func dialTestGRPCServer(t *testing.T) *grpc.ClientConn {
+	listener := bufconn.Listen(1 << 20)
+	server := grpcserver.New(testApp)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestGRPCHealth(t *testing.T) {
+	conn := dialTestGRPCServer(t)
+	client := healthpb.NewHealthClient(conn)
+
+	for _, service := range []string{"", "lunchmenu.v1.MenuService"} {
+		resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatalf("health check %q: %v", service, err)
+		}
+		if resp.Status != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("Expected %q to be SERVING, got %v", service, resp.Status)
+		}
+	}
+}
+
+func TestGRPCWritesRequireAuth(t *testing.T) {
+	conn := dialTestGRPCServer(t)
+	client := pb.NewMenuServiceClient(conn)
+
+	_, err := client.DeleteMenuItem(context.Background(), &pb.DeleteMenuItemRequest{Id: 1})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated without token, got %v", err)
+	}
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Basic abc")
+	_, err = client.DeleteMenuItem(ctx, &pb.DeleteMenuItemRequest{Id: 1})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated for non-Bearer token, got %v", err)
+	}
+}
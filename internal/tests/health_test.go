@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"lunch_menu/internal/config"
+	"lunch_menu/internal/handlers"
+	"lunch_menu/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestHealthz_AlwaysOK(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/healthz", handlers.Healthz)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/healthz", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d", w.Code)
+	}
+}
+
+func TestReadyz_ReportsChecks(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/readyz", handlers.Readyz)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/readyz", nil)
+	router.ServeHTTP(w, req)
+
+	var resp models.HealthResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	for _, name := range []string{"database", "migrations", "config"} {
+		if _, ok := resp.Checks[name]; !ok {
+			t.Errorf("Expected %s check in %+v", name, resp.Checks)
+		}
+	}
+	allOK := true
+	for _, check := range resp.Checks {
+		allOK = allOK && check.Status == "ok"
+	}
+	if allOK != (w.Code == http.StatusOK) || allOK != (resp.Status == "ok") {
+		t.Errorf("Status %d/%s does not match checks %+v", w.Code, resp.Status, resp.Checks)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := config.Defaults()
+	cfg.Database.Password = "pw"
+	cfg.Auth.JWTSecret = "0123456789abcdef0123456789abcdef"
+	cfg.Database.MaxOpenConns, cfg.Database.MaxIdleConns = 10, 5
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected valid config, got %v", err)
+	}
+	cfg.Database.MaxIdleConns = 20
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected error for more idle than open connections")
+	}
+	cfg.Database.MaxIdleConns = 5
+	cfg.Database.Port = 70000
+	cfg.Auth.JWTSecret = ""
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected error for invalid port and missing JWT secret")
+	}
+}
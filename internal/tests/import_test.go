@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"lunch_menu/internal/importer"
+	"lunch_menu/internal/models"
+)
+
+func TestImportParseCSV(t *testing.T) {
+	restaurantsCSV := "name,address,latitude,longitude,region,email\n" +
+		"Café Delta,Solna,59.35039,18.02265,Solna,delta@example.com\n" +
+		"No Coordinates,Solna,,,Solna,nocoord@example.com\n"
+	menuItemsCSV := "restaurant_name,name,price,category,is_available\n" +
+		"Café Delta,Dagens lunch,119.00,Main Course,true\n" +
+		"Café Delta,Free lunch,abc,Main Course,\n"
+
+	var input models.CatalogueImport
+	if err := importer.Parse(importer.KindRestaurants, importer.FormatCSV, strings.NewReader(restaurantsCSV), &input); err != nil {
+		t.Fatalf("Failed to parse restaurants CSV: %v", err)
+	}
+	if err := importer.Parse(importer.KindMenuItems, importer.FormatCSV, strings.NewReader(menuItemsCSV), &input); err != nil {
+		t.Fatalf("Failed to parse menu items CSV: %v", err)
+	}
+
+	if len(input.Restaurants) != 2 || len(input.MenuItems) != 2 {
+		t.Fatalf("Expected 2 restaurants and 2 menu items, got %d and %d", len(input.Restaurants), len(input.MenuItems))
+	}
+	if ok, invalid := input.Restaurants[0].Validate(); !ok {
+		t.Errorf("Expected first restaurant to be valid, invalid fields: %v", invalid)
+	}
+	if ok, invalid := input.Restaurants[1].Validate(); ok || invalid[0] != "coordinate" {
+		t.Errorf("Expected coordinate to be invalid, got %v", invalid)
+	}
+	if ok, invalid := input.MenuItems[1].Validate(); ok || invalid[0] != "price" {
+		t.Errorf("Expected price to be invalid, got %v", invalid)
+	}
+}
+
+func TestImport_InvalidAvailabilityIsARowError(t *testing.T) {
+	menuItemsCSV := "restaurant_name,name,price,category,is_available\n" +
+		"Café Epsilon,Soup,89.00,Starter,true\n" +
+		"Café Epsilon,Stew,129.00,Main Course,maybe\n" +
+		"Café Epsilon,Cake,59.00,Dessert,\n"
+
+	input := models.CatalogueImport{Restaurants: []models.RestaurantInput{
+		{Name: "Café Epsilon", Address: "5 Main St", Region: "Solna", Email: "epsilon@example.com", Coordinate: []float64{59.3, 18.0}},
+	}}
+	if err := importer.Parse(importer.KindMenuItems, importer.FormatCSV, strings.NewReader(menuItemsCSV), &input); err != nil {
+		t.Fatalf("Expected the file to be parsed despite the invalid row, got %v", err)
+	}
+	if len(input.MenuItems) != 3 || input.MenuItems[2].IsAvailable != nil {
+		t.Fatalf("Expected 3 menu items, got %+v", input.MenuItems)
+	}
+
+	report, err := testApp.Imports.Import(context.Background(), &input, true)
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if report.MenuItems.Created != 2 || len(report.Errors) != 1 {
+		t.Fatalf("Expected 2 valid menu items and 1 row error, got %+v", report)
+	}
+	if e := report.Errors[0]; e.Kind != "menu_items" || e.Row != 2 || strings.Join(e.Fields, ",") != "is_available" {
+		t.Errorf("Expected is_available of menu item row 2 to be invalid, got %+v", e)
+	}
+}
+
+func TestImportDetectFormat(t *testing.T) {
+	if format, err := importer.DetectFormat("", "menu.JSON"); err != nil || format != importer.FormatJSON {
+		t.Errorf("Expected json, got %q (%v)", format, err)
+	}
+	if _, err := importer.DetectFormat("", "menu.xlsx"); err == nil {
+		t.Error("Expected error for unsupported format, got none")
+	}
+}
+
+func TestAPI_ImportKeepsDeletedRestaurants(t *testing.T) {
+	router := newAPIRouter(t)
+	token := adminToken(t, router)
+	restaurant, err := testApp.Restaurants.Create(context.Background(), &models.Restaurant{Name: "Import Closed", Address: "1 Old St", Region: "Solna"})
+	if err != nil {
+		t.Fatalf("Failed to create restaurant: %v", err)
+	}
+	if err := testApp.Restaurants.Delete(context.Background(), restaurant.ID); err != nil {
+		t.Fatalf("Failed to delete restaurant: %v", err)
+	}
+
+	var report models.ImportReport
+	w := doAPI(t, router, http.MethodPost, "/api/import", token, models.CatalogueImport{
+		Restaurants: []models.RestaurantInput{
+			{Name: "import closed", Address: "2 New St", Region: "Solna", Email: "closed@example.com", Coordinate: []float64{59.3, 18.0}},
+			{Name: "Import Opened", Address: "3 New St", Region: "Solna", Email: "opened@example.com", Coordinate: []float64{59.3, 18.0}},
+		},
+	}, &report)
+	if w.Code != http.StatusOK || report.Restaurants.Updated != 1 || report.Restaurants.Created != 1 {
+		t.Fatalf("Expected 1 updated and 1 created restaurant, got %d: %s", w.Code, w.Body.String())
+	}
+	if _, err := testApp.Restaurants.GetByID(context.Background(), restaurant.ID); err == nil {
+		t.Errorf("Expected the deleted restaurant to stay deleted")
+	}
+}
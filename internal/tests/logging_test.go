@@ -0,0 +1,164 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"lunch_menu/internal/database"
+	"lunch_menu/internal/logging"
+	"lunch_menu/internal/middleware"
+	"lunch_menu/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+func TestLogging_RedactsSensitiveAttributes(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(&buf, logging.Options{Level: "debug"})
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer secret-token")
+	header.Set("Cookie", "refresh_token=abc")
+	header.Set("Accept", "application/json")
+	logger.Info("test",
+		slog.String("password_hash", "hunter2"),
+		slog.String("username", "admin"),
+		slog.Any("headers", logging.Headers(header)),
+	)
+
+	out := buf.String()
+	for _, secret := range []string{"hunter2", "secret-token", "refresh_token=abc"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("Expected %q to be redacted, got %s", secret, out)
+		}
+	}
+	if !strings.Contains(out, `"username":"admin"`) || !strings.Contains(out, `"Accept":"application/json"`) {
+		t.Errorf("Expected non-sensitive attributes to be kept, got %s", out)
+	}
+}
+
+func TestRequestID_AcceptsOrGenerates(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var buf bytes.Buffer
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		ctx := logging.WithLogger(c.Request.Context(), logging.New(&buf, logging.Options{}))
+		c.Request = c.Request.WithContext(ctx)
+	}, middleware.RequestID, middleware.RequestLogger)
+	router.GET("/items/:id", func(c *gin.Context) {
+		logging.FromContext(c.Request.Context()).Info("handled")
+		c.Status(http.StatusNoContent)
+	})
+
+	req, _ := http.NewRequest("GET", "/items/1", nil)
+	req.Header.Set(middleware.RequestIDHeader, "abc-123")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if got := w.Header().Get(middleware.RequestIDHeader); got != "abc-123" {
+		t.Errorf("Expected client request ID to be echoed, got %q", got)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected handler and request log lines, got %q", buf.String())
+	}
+	for _, line := range lines {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Expected JSON log line, got %q", line)
+		}
+		if entry["request_id"] != "abc-123" || entry["route"] != "/items/:id" {
+			t.Errorf("Expected request ID and route on every line, got %v", entry)
+		}
+	}
+
+	req, _ = http.NewRequest("GET", "/items/1", nil)
+	req.Header.Set(middleware.RequestIDHeader, "bad id\nwith newline")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if got := w.Header().Get(middleware.RequestIDHeader); got == "" || strings.Contains(got, " ") {
+		t.Errorf("Expected a generated request ID for an invalid header, got %q", got)
+	}
+}
+
+func TestGormLogger_LogsPlaceholdersAndSlowQueries(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := logging.WithLogger(context.Background(), logging.New(&buf, logging.Options{}))
+	l := logging.NewGormLogger("warn", 10*time.Millisecond)
+
+	sql, params := l.ParamsFilter(ctx, "SELECT * FROM users WHERE password_hash = $1", "hunter2")
+	if params != nil || strings.Contains(sql, "hunter2") {
+		t.Errorf("Expected bound values to be dropped, got %q %v", sql, params)
+	}
+
+	query := func() (string, int64) { return "SELECT 1", 1 }
+	l.Trace(ctx, time.Now(), query, nil)
+	if buf.Len() != 0 {
+		t.Errorf("Expected fast queries not to be logged at warn, got %s", buf.String())
+	}
+	l.Trace(ctx, time.Now().Add(-time.Second), query, nil)
+	if !strings.Contains(buf.String(), "slow query") {
+		t.Errorf("Expected slow query to be logged, got %s", buf.String())
+	}
+	if logging.ParseGormLevel("silent") != gormlogger.Silent {
+		t.Error("Expected silent level to parse")
+	}
+}
+
+func TestGormLogger_LogsQueriesWithRequestID(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(t.TempDir()+"/logged.db"), &gorm.Config{Logger: logging.NewGormLogger("silent", 0)})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := db.AutoMigrate(&models.Restaurant{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	db.Logger = db.Logger.LogMode(gormlogger.Info)
+	restaurants := database.NewRestaurantRepository(db)
+
+	gin.SetMode(gin.TestMode)
+	var buf bytes.Buffer
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		ctx := logging.WithLogger(c.Request.Context(), logging.New(&buf, logging.Options{}))
+		c.Request = c.Request.WithContext(ctx)
+	}, middleware.RequestID)
+	router.GET("/restaurants", func(c *gin.Context) {
+		if _, _, err := restaurants.List(c.Request.Context(), 10, 0); err != nil {
+			t.Errorf("List failed: %v", err)
+		}
+		c.Status(http.StatusNoContent)
+	})
+
+	req, _ := http.NewRequest("GET", "/restaurants", nil)
+	req.Header.Set(middleware.RequestIDHeader, "query-123")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	queries := 0
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Expected JSON log line, got %q", line)
+		}
+		if entry["msg"] != "query" {
+			continue
+		}
+		queries++
+		if entry["request_id"] != "query-123" {
+			t.Errorf("Expected the request ID on the query log line, got %v", entry)
+		}
+	}
+	if queries == 0 {
+		t.Errorf("Expected the queries to be logged, got %q", buf.String())
+	}
+}
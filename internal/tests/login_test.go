@@ -6,8 +6,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"lunch_menu/internal/app"
 	"lunch_menu/internal/config"
 	"lunch_menu/internal/database"
 	"lunch_menu/internal/handlers"
@@ -16,27 +18,57 @@ import (
 	"github.com/joho/godotenv"
 )
 
-// TestMain sets up the config and database connection for all tests in this package.
+// TestMain sets up the config and testApp for all tests in this package.
+// TEST_DATABASE selects the backend: postgres runs the tests against the
+// database of .env.test, sqlite against a fresh SQLite file, and by default
+// they run against the in-memory repositories.
 func TestMain(m *testing.M) {
 
 	_ = godotenv.Load("../../.env.test") // 1. Load env vars from file
 	config.MustLoadConfig()              // 2. Populate config.AppConfig
 
-	// Initialize database connection
-	if err := database.InitDatabase(); err != nil {
-		panic("Failed to initialize database: " + err.Error())
+	var tempDir string
+	switch os.Getenv("TEST_DATABASE") {
+	case "postgres":
+		// Initialize database connection
+		if err := database.InitDatabase(); err != nil {
+			panic("Failed to initialize database: " + err.Error())
+		}
+		testApp = app.NewGorm(database.DB)
+	case "sqlite":
+		var err error
+		if tempDir, err = os.MkdirTemp("", "lunch_menu_test"); err != nil {
+			panic("Failed to create database directory: " + err.Error())
+		}
+		config.AppConfig.Database.Driver = "sqlite"
+		config.AppConfig.Database.Path = filepath.Join(tempDir, "test.db")
+		if err := database.InitDatabase(); err != nil {
+			panic("Failed to initialize database: " + err.Error())
+		}
+		if err := database.Migrate(); err != nil {
+			panic("Failed to migrate database: " + err.Error())
+		}
+		testApp = app.NewGorm(database.DB)
+		seedTestUsers(testApp)
+	default:
+		testApp = newMemoryApp()
 	}
 	// Run tests
 	code := m.Run()
 	// Cleanup
-	_ = database.CloseDatabase()
+	if database.DB != nil {
+		_ = database.CloseDatabase()
+	}
+	if tempDir != "" {
+		_ = os.RemoveAll(tempDir)
+	}
 	os.Exit(code)
 }
 
 func TestAdminLogin(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
-	router.POST("/api/user/login", handlers.UserLogin)
+	router.POST("/api/user/login", handlers.New(testApp).UserLogin)
 
 	// Prepare request body
 	body := map[string]string{
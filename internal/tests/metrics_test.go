@@ -0,0 +1,65 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"lunch_menu/internal/metrics"
+	"lunch_menu/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics_RecordsRouteTemplate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.Metrics)
+	router.GET("/api/restaurants/:id", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	counter := metrics.HTTPRequests.WithLabelValues("GET", "/api/restaurants/:id", "200")
+	before := testutil.ToFloat64(counter)
+	for _, path := range []string{"/api/restaurants/1", "/api/restaurants/2"} {
+		req, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	if got := testutil.ToFloat64(counter) - before; got != 2 {
+		t.Errorf("Expected 2 requests for the route template, got %v", got)
+	}
+
+	unmatched := metrics.HTTPRequests.WithLabelValues("GET", "unmatched", "404")
+	before = testutil.ToFloat64(unmatched)
+	req, _ := http.NewRequest("GET", "/no/such/path", nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	if got := testutil.ToFloat64(unmatched) - before; got != 1 {
+		t.Errorf("Expected unmatched path to be counted as unmatched, got %v", got)
+	}
+}
+
+func TestMetrics_EndpointProtectedByToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/metrics", middleware.MetricsAuth("s3cret"), gin.WrapH(metrics.Handler()))
+
+	req, _ := http.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without token, got %d", w.Code)
+	}
+
+	metrics.Logins.WithLabelValues(metrics.ResultSuccess).Add(0)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 with token, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "lunch_menu_auth_logins_total") {
+		t.Error("Expected login counter in metrics output")
+	}
+}
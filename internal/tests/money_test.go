@@ -0,0 +1,275 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"lunch_menu/internal/config"
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/money"
+)
+
+func TestMoney_Parse(t *testing.T) {
+	for input, want := range map[string]money.Amount{
+		"129":     129_00,
+		"129.5":   129_50,
+		"129.50":  129_50,
+		"0.05":    5,
+		"12.300":  12_30,
+		"-4.20":   -4_20,
+		"1000000": 1_000_000_00,
+	} {
+		got, err := money.Parse(input)
+		if err != nil || got != want {
+			t.Errorf("Parse(%q) = %v, %v; want %v", input, got, err, want)
+		}
+	}
+	if _, err := money.Parse("12.345"); !errors.Is(err, money.ErrScale) {
+		t.Errorf("Expected ErrScale for three decimals, got %v", err)
+	}
+	for _, input := range []string{"", "abc", "1.2.3", "1e3", "."} {
+		if _, err := money.Parse(input); err == nil {
+			t.Errorf("Expected an error for %q", input)
+		}
+	}
+	if _, err := money.FromFloat(19.999); !errors.Is(err, money.ErrScale) {
+		t.Errorf("Expected ErrScale for three decimals, got %v", err)
+	}
+	// Float artefacts are not extra decimals
+	if got, err := money.FromFloat(0.1 + 0.2); err != nil || got != 30 {
+		t.Errorf("FromFloat(0.1 + 0.2) = %v, %v; want 0.30", got, err)
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	var item models.MenuItemInput
+	if err := json.Unmarshal([]byte(`{"price": 89.9}`), &item); err != nil || item.Price != 89_90 {
+		t.Errorf("Expected 89.90, got %v (%v)", item.Price, err)
+	}
+	if err := json.Unmarshal([]byte(`{"price": "89.90"}`), &item); err != nil || item.Price != 89_90 {
+		t.Errorf("Expected a string amount to be accepted, got %v (%v)", item.Price, err)
+	}
+	if err := json.Unmarshal([]byte(`{"price": 89.999}`), &item); !errors.Is(err, money.ErrScale) {
+		t.Errorf("Expected ErrScale, got %v", err)
+	}
+
+	out, _ := json.Marshal(models.MenuItem{Price: 129_00, Currency: "SEK"})
+	if !strings.Contains(string(out), `"price":129.00`) {
+		t.Errorf("Expected the price with two decimals, got %s", out)
+	}
+}
+
+func TestMoney_SplitVATAddsUp(t *testing.T) {
+	vat := money.Amount(129_00).SplitVAT(12_00)
+	if vat.PriceExclVAT != 115_18 || vat.Amount != 13_82 {
+		t.Errorf("Expected 115.18 + 13.82, got %+v", vat)
+	}
+	for price := money.Amount(1); price < 200_00; price += 7 {
+		for _, rate := range []money.Rate{0, 6_00, 12_00, 25_00} {
+			if vat := price.SplitVAT(rate); vat.PriceExclVAT+vat.Amount != price {
+				t.Fatalf("%v at %v%%: %+v does not add up", price, rate, vat)
+			}
+		}
+	}
+}
+
+func TestMoney_Average(t *testing.T) {
+	if got := money.Average(10_01, 2); got != 5_01 {
+		t.Errorf("Expected 5.005 to round up to 5.01, got %v", got)
+	}
+	if got := money.Average(10_00, 3); got != 3_33 {
+		t.Errorf("Expected 3.33, got %v", got)
+	}
+	if got := money.Average(0, 0); got != 0 {
+		t.Errorf("Expected 0 for no items, got %v", got)
+	}
+}
+
+func TestPricingConfig_VATRates(t *testing.T) {
+	cfg := config.Defaults()
+	cfg.Auth.JWTSecret = "0123456789abcdef0123456789abcdef"
+	cfg.Database.Password = "secret"
+	cfg.Pricing.CategoryVATRates = []string{"Beverages=25", "Groceries = 6"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Expected valid pricing settings, got %v", err)
+	}
+	for category, want := range map[string]money.Rate{"Main": 12_00, "beverages": 25_00, "Groceries": 6_00} {
+		if got := cfg.Pricing.VATRateFor(category); got != want {
+			t.Errorf("VATRateFor(%q) = %v, want %v", category, got, want)
+		}
+	}
+
+	cfg.Pricing.Currency = "kr"
+	cfg.Pricing.VATRate = "12.345"
+	cfg.Pricing.CategoryVATRates = []string{"Beverages"}
+	err := cfg.Validate()
+	for _, path := range []string{"pricing.currency", "pricing.vat_rate", "pricing.category_vat_rates"} {
+		if err == nil || !strings.Contains(err.Error(), path) {
+			t.Errorf("Expected an error for %s, got %v", path, err)
+		}
+	}
+}
+
+func TestAPI_MenuItemPrices(t *testing.T) {
+	router := newAPIRouter(t)
+	token := adminToken(t, router)
+	restaurant, err := testApp.Restaurants.Create(context.Background(), &models.Restaurant{Name: "Price Bistro", Address: "3 Main St"})
+	if err != nil {
+		t.Fatalf("Failed to create restaurant: %v", err)
+	}
+
+	w := doAPI(t, router, http.MethodPost, "/api/menu-items", token,
+		map[string]interface{}{"restaurant_id": restaurant.ID, "name": "Soup", "price": 89.999}, nil)
+	var problem models.Problem
+	_ = json.Unmarshal(w.Body.Bytes(), &problem)
+	if w.Code != http.StatusBadRequest || !strings.Contains(problem.Detail, "at most 2 decimals") {
+		t.Errorf("Expected a validation error on the price, got %d: %s", w.Code, w.Body.String())
+	}
+	w = doAPI(t, router, http.MethodPost, "/api/menu-items", token,
+		map[string]interface{}{"restaurant_id": restaurant.ID, "name": "Soup", "price": 89, "currency": "sek"}, nil)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid currency, got %d", w.Code)
+	}
+
+	var item models.MenuItem
+	w = doAPI(t, router, http.MethodPost, "/api/menu-items", token,
+		map[string]interface{}{"restaurant_id": restaurant.ID, "name": "Lasagne", "price": "129.00"}, &item)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if item.Price != 129_00 || item.Currency != config.AppConfig.Pricing.Currency {
+		t.Errorf("Expected 129.00 in the default currency, got %v %s", item.Price, item.Currency)
+	}
+	if item.VAT == nil || item.VAT.Rate != 12_00 || item.VAT.PriceExclVAT != 115_18 || item.VAT.Amount != 13_82 {
+		t.Errorf("Expected the 12%% VAT breakdown, got %+v", item.VAT)
+	}
+
+	var menu models.MenuItemsResponse
+	doAPI(t, router, http.MethodGet, fmt.Sprintf("/api/restaurants/%d/menu", restaurant.ID), "", nil, &menu)
+	if len(menu.MenuItems) != 1 || menu.MenuItems[0].VAT == nil {
+		t.Errorf("Expected the VAT breakdown in menu listings, got %+v", menu.MenuItems)
+	}
+}
+
+func TestAPI_PriceVariants(t *testing.T) {
+	router := newAPIRouter(t)
+	token := adminToken(t, router)
+	restaurant, err := testApp.Restaurants.Create(context.Background(), &models.Restaurant{Name: "Campus Café", Address: "4 Main St", Region: "Solna"})
+	if err != nil {
+		t.Fatalf("Failed to create restaurant: %v", err)
+	}
+
+	for name, prices := range map[string]interface{}{
+		"no default":    []map[string]interface{}{{"tier": "student", "price": 95}},
+		"two defaults":  []map[string]interface{}{{"tier": "standard", "price": 125, "is_default": true}, {"tier": "student", "price": 95, "is_default": true}},
+		"same tier":     []map[string]interface{}{{"tier": "student", "price": 125, "is_default": true}, {"tier": "student", "price": 95}},
+		"invalid tier":  []map[string]interface{}{{"tier": "Student!", "price": 125, "is_default": true}},
+		"invalid price": []map[string]interface{}{{"tier": "standard", "price": 125, "is_default": true}, {"tier": "student", "price": 0}},
+	} {
+		w := doAPI(t, router, http.MethodPost, "/api/menu-items", token,
+			map[string]interface{}{"restaurant_id": restaurant.ID, "name": "Soup", "prices": prices}, nil)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d: %s", name, w.Code, w.Body.String())
+		}
+	}
+
+	// The price defaults to the default variant's
+	var item models.MenuItem
+	w := doAPI(t, router, http.MethodPost, "/api/menu-items", token, map[string]interface{}{
+		"restaurant_id": restaurant.ID, "name": "Fish of the day", "prices": []map[string]interface{}{
+			{"tier": "standard", "price": 125, "is_default": true},
+			{"tier": "student", "price": 95},
+			{"tier": "employee", "price": "105.50"},
+		},
+	}, &item)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if item.Price != 125_00 || len(item.Prices) != 3 || item.Prices[2].Price != 105_50 {
+		t.Errorf("Expected the default price and three variants, got %v %+v", item.Price, item.Prices)
+	}
+	w = doAPI(t, router, http.MethodPost, "/api/menu-items", token, map[string]interface{}{
+		"restaurant_id": restaurant.ID, "name": "Salad", "price": 110,
+		"prices": []map[string]interface{}{{"tier": "standard", "price": 100, "is_default": true}},
+	}, nil)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a price that is not the default variant's, got %d", w.Code)
+	}
+	if _, err := testApp.MenuItems.Create(context.Background(), &models.MenuItem{RestaurantID: restaurant.ID, Name: "Bun", Price: 30_00}); err != nil {
+		t.Fatalf("Failed to create menu item: %v", err)
+	}
+
+	// Items without the requested tier keep their default price
+	var menu models.MenuItemsResponse
+	doAPI(t, router, http.MethodGet, fmt.Sprintf("/api/restaurants/%d/menu?price_tier=student", restaurant.ID), "", nil, &menu)
+	if len(menu.MenuItems) != 2 {
+		t.Fatalf("Expected two menu items, got %+v", menu)
+	}
+	if got := menu.MenuItems[0]; got.Price != 95_00 || got.PriceTier != "student" || got.VAT.PriceExclVAT+got.VAT.Amount != 95_00 {
+		t.Errorf("Expected the student price with its VAT, got %+v", got)
+	}
+	if got := menu.MenuItems[1]; got.Price != 30_00 || got.PriceTier != "" {
+		t.Errorf("Expected the default price for an item without the tier, got %+v", got)
+	}
+	if w := doAPI(t, router, http.MethodGet, "/api/menu-items?price_tier=STUDENT", "", nil, nil); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid price tier, got %d", w.Code)
+	}
+
+	// Updating the price changes the default variant; new variants replace the old ones
+	path := fmt.Sprintf("/api/menu-items/%d", item.ID)
+	doAPI(t, router, http.MethodPut, path, token, map[string]interface{}{"price": 129}, &item)
+	if item.Price != 129_00 || item.Prices[0].Price != 129_00 || item.Prices[1].Price != 95_00 {
+		t.Errorf("Expected the default variant to follow the price, got %v %+v", item.Price, item.Prices)
+	}
+	doAPI(t, router, http.MethodPut, path, token, map[string]interface{}{"prices": []map[string]interface{}{
+		{"tier": "takeaway", "price": 115, "is_default": true},
+	}}, &item)
+	var fetched models.MenuItem
+	doAPI(t, router, http.MethodGet, path+"?price_tier=student", "", nil, &fetched)
+	if fetched.Price != 115_00 || len(fetched.Prices) != 1 || fetched.Prices[0].Tier != "takeaway" {
+		t.Errorf("Expected the variants to be replaced, got %v %+v", fetched.Price, fetched.Prices)
+	}
+}
+
+func TestAPI_StatisticsPriceTier(t *testing.T) {
+	router := newAPIRouter(t)
+	restaurant, err := testApp.Restaurants.Create(context.Background(), &models.Restaurant{Name: "Tier Diner", Address: "5 Main St"})
+	if err != nil {
+		t.Fatalf("Failed to create restaurant: %v", err)
+	}
+	for _, item := range []models.MenuItem{
+		{RestaurantID: restaurant.ID, Name: "Stew", Price: 120_00, Prices: []models.MenuItemPrice{
+			{Tier: "standard", Price: 120_00, IsDefault: true}, {Tier: "student", Price: 80_00},
+		}},
+		{RestaurantID: restaurant.ID, Name: "Pie", Price: 100_00},
+	} {
+		if _, err := testApp.MenuItems.Create(context.Background(), &item); err != nil {
+			t.Fatalf("Failed to create menu item: %v", err)
+		}
+	}
+
+	averages := func(path string) (*models.BusinessStatistics, money.Amount) {
+		var stats models.BusinessStatistics
+		if w := doAPI(t, router, http.MethodGet, path, "", nil, &stats); w.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		for _, d := range stats.RestaurantDetails {
+			if d.RestaurantID == restaurant.ID {
+				return &stats, d.AveragePrice
+			}
+		}
+		t.Fatalf("Expected the details of the new restaurant, got %+v", stats.RestaurantDetails)
+		return nil, 0
+	}
+	if _, avg := averages("/api/stats"); avg != 110_00 {
+		t.Errorf("Expected the average of the default prices, got %v", avg)
+	}
+	if stats, avg := averages("/api/stats?price_tier=student"); avg != 90_00 || stats.PriceTier != "student" {
+		t.Errorf("Expected the average of the student prices, got %v (%q)", avg, stats.PriceTier)
+	}
+}
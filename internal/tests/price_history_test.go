@@ -0,0 +1,207 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/money"
+)
+
+func TestAPI_PriceHistory(t *testing.T) {
+	router := newAPIRouter(t)
+	token := adminToken(t, router)
+	restaurant, err := testApp.Restaurants.Create(context.Background(), &models.Restaurant{Name: "History Hall", Address: "6 Main St"})
+	if err != nil {
+		t.Fatalf("Failed to create restaurant: %v", err)
+	}
+	item, err := testApp.MenuItems.Create(context.Background(), &models.MenuItem{RestaurantID: restaurant.ID, Name: "Meatballs", Price: 115_00, Currency: "SEK"})
+	if err != nil {
+		t.Fatalf("Failed to create menu item: %v", err)
+	}
+	path := fmt.Sprintf("/api/menu-items/%d", item.ID)
+	doAPI(t, router, http.MethodPut, path, token, map[string]interface{}{"price": 119}, nil)
+	doAPI(t, router, http.MethodPut, path, token, map[string]interface{}{"name": "Swedish meatballs"}, nil)
+	doAPI(t, router, http.MethodPut, path, token, map[string]interface{}{"price": 125}, nil)
+
+	var history models.PriceHistoryResponse
+	if w := doAPI(t, router, http.MethodGet, path+"/prices", "", nil, &history); w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var prices []int64
+	for _, c := range history.History {
+		prices = append(prices, int64(c.Price))
+	}
+	if !slices.Equal(prices, []int64{115_00, 119_00, 125_00}) || history.Current == nil || history.Current.Price != 125_00 {
+		t.Errorf("Expected the three prices oldest first, got %+v", history)
+	}
+
+	// Scheduled changes take effect when they are due
+	if w := doAPI(t, router, http.MethodPost, path+"/prices", token,
+		map[string]interface{}{"price": 129, "effective_from": time.Now().Add(-time.Minute)}, nil); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a change in the past, got %d", w.Code)
+	}
+	effective := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	var scheduled models.PriceChange
+	w := doAPI(t, router, http.MethodPost, path+"/prices", token,
+		map[string]interface{}{"price": 129, "effective_from": effective}, &scheduled)
+	if w.Code != http.StatusCreated || !scheduled.Scheduled() || scheduled.Currency != "SEK" {
+		t.Fatalf("Expected a scheduled change, got %d: %s", w.Code, w.Body.String())
+	}
+	var later models.PriceChange
+	doAPI(t, router, http.MethodPost, path+"/prices", token,
+		map[string]interface{}{"price": 139, "effective_from": effective.Add(24 * time.Hour)}, &later)
+
+	if n, err := testApp.PriceHistory.ApplyDue(context.Background(), time.Now()); err != nil || n != 0 {
+		t.Errorf("Expected no due changes yet, got %d (%v)", n, err)
+	}
+	if n, err := testApp.PriceHistory.ApplyDue(context.Background(), effective); err != nil || n != 1 {
+		t.Fatalf("Expected the first change to be applied, got %d (%v)", n, err)
+	}
+	var fetched models.MenuItem
+	doAPI(t, router, http.MethodGet, path, "", nil, &fetched)
+	if fetched.Price != 129_00 {
+		t.Errorf("Expected the scheduled price, got %v", fetched.Price)
+	}
+
+	w = doAPI(t, router, http.MethodDelete, fmt.Sprintf("%s/prices/%d", path, scheduled.ID), token, nil, nil)
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected 409 for cancelling an applied change, got %d", w.Code)
+	}
+	w = doAPI(t, router, http.MethodDelete, fmt.Sprintf("%s/prices/%d", path, later.ID), token, nil, nil)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected the later change to be cancelled, got %d: %s", w.Code, w.Body.String())
+	}
+	doAPI(t, router, http.MethodGet, path+"/prices", "", nil, &history)
+	if len(history.History) != 4 || history.Current.Price != 129_00 || len(history.Scheduled) != 0 {
+		t.Errorf("Expected the applied change in the history and nothing scheduled, got %+v", history)
+	}
+}
+
+func TestBuildPriceTrends(t *testing.T) {
+	day := func(month time.Month, d int) time.Time { return time.Date(2026, month, d, 12, 0, 0, 0, time.UTC) }
+	change := func(id uint, region string, price money.Amount, at time.Time) models.PriceChangeWithItem {
+		return models.PriceChangeWithItem{
+			PriceChange: models.PriceChange{MenuItemID: id, Price: price, Currency: "SEK", EffectiveFrom: at},
+			Region:      region,
+		}
+	}
+	changes := []models.PriceChangeWithItem{
+		change(1, "Solna", 100_00, day(1, 5)),
+		change(2, "Solna", 120_00, day(1, 20)),
+		change(3, "Uppsala", 90_00, day(2, 1)),
+		change(1, "Solna", 110_00, day(3, 10)),
+	}
+
+	trends := models.BuildPriceTrends(models.PriceTrendQuery{
+		GroupBy: models.TrendByRegion, Interval: models.TrendMonthly, Currency: "SEK",
+		From: day(1, 1), To: day(3, 31),
+	}, changes)
+	if len(trends.Series) != 2 || trends.Series[0].Key != "Solna" || trends.Series[1].Key != "Uppsala" {
+		t.Fatalf("Expected a series per region, got %+v", trends.Series)
+	}
+	var averages []int64
+	for _, p := range trends.Series[0].Points {
+		averages = append(averages, int64(p.AveragePrice))
+	}
+	if !slices.Equal(averages, []int64{110_00, 110_00, 115_00}) {
+		t.Errorf("Expected the Solna averages at the end of each month, got %v", averages)
+	}
+	if last := trends.Series[0].Points[2]; last.ChangePercent != 4.55 || last.MenuItems != 2 {
+		t.Errorf("Expected +4.55%% over two items, got %+v", last)
+	}
+	if points := trends.Series[1].Points; len(points) != 2 || !points[0].Period.Equal(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected Uppsala from February on, got %+v", points)
+	}
+
+	// Restaurants of the same name get a series each
+	for i, restaurantID := range []uint{7, 7, 3, 7} {
+		changes[i].RestaurantID = restaurantID
+		changes[i].RestaurantName = "Café"
+	}
+	trends = models.BuildPriceTrends(models.PriceTrendQuery{
+		GroupBy: models.TrendByRestaurant, Interval: models.TrendMonthly, Currency: "SEK",
+		From: day(1, 1), To: day(3, 31),
+	}, changes)
+	if len(trends.Series) != 2 || trends.Series[0].RestaurantID != 3 || trends.Series[1].RestaurantID != 7 ||
+		trends.Series[0].Key != "Café" || trends.Series[1].Key != "Café" {
+		t.Fatalf("Expected a series per restaurant ID, got %+v", trends.Series)
+	}
+	if last := trends.Series[1].Points[2]; last.AveragePrice != 115_00 || last.MenuItems != 2 {
+		t.Errorf("Expected the items of restaurant 7 only, got %+v", last)
+	}
+}
+
+func TestPriceHistory_Changes(t *testing.T) {
+	ctx := context.Background()
+	restaurant, err := testApp.Restaurants.Create(ctx, &models.Restaurant{Name: "Closed Canteen", Address: "8 Main St", Region: "Pastby"})
+	if err != nil {
+		t.Fatalf("Failed to create restaurant: %v", err)
+	}
+	item, err := testApp.MenuItems.Create(ctx, &models.MenuItem{RestaurantID: restaurant.ID, Name: "Stew", Price: 80_00, Currency: "SEK"})
+	if err != nil {
+		t.Fatalf("Failed to create menu item: %v", err)
+	}
+	for i, price := range []money.Amount{90_00, 95_00, 100_00, 105_00} {
+		change := &models.PriceChange{MenuItemID: item.ID, Price: price, Currency: "SEK", EffectiveFrom: time.Date(2020, time.Month(i+1), 5, 0, 0, 0, 0, time.UTC)}
+		if _, err := testApp.PriceHistory.Schedule(ctx, change); err != nil {
+			t.Fatalf("Failed to schedule change: %v", err)
+		}
+	}
+	if _, err := testApp.PriceHistory.ApplyDue(ctx, time.Now()); err != nil {
+		t.Fatalf("ApplyDue failed: %v", err)
+	}
+	unavailable, inactive := false, false
+	if _, err := testApp.MenuItems.Update(ctx, item.ID, &models.MenuItemUpdateInput{IsAvailable: &unavailable}); err != nil {
+		t.Fatalf("Failed to update menu item: %v", err)
+	}
+	if _, err := testApp.Restaurants.Update(ctx, restaurant.ID, &models.RestaurantUpdateInput{IsActive: &inactive}); err != nil {
+		t.Fatalf("Failed to update restaurant: %v", err)
+	}
+
+	// The last change before March and the changes in March, kept after the
+	// item became unavailable and its restaurant inactive
+	changes, err := testApp.PriceHistory.Changes(ctx, time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Changes failed: %v", err)
+	}
+	var prices []money.Amount
+	for _, c := range changes {
+		if c.MenuItemID == item.ID {
+			prices = append(prices, c.Price)
+		}
+	}
+	if !slices.Equal(prices, []money.Amount{95_00, 100_00}) {
+		t.Errorf("Expected the February and March prices, got %v", prices)
+	}
+}
+
+func TestAPI_PriceTrends(t *testing.T) {
+	router := newAPIRouter(t)
+	restaurant, err := testApp.Restaurants.Create(context.Background(), &models.Restaurant{Name: "Trend Tavern", Address: "7 Main St", Region: "Trendby"})
+	if err != nil {
+		t.Fatalf("Failed to create restaurant: %v", err)
+	}
+	if _, err := testApp.MenuItems.Create(context.Background(), &models.MenuItem{RestaurantID: restaurant.ID, Name: "Pasta", Price: 99_00, Currency: "SEK"}); err != nil {
+		t.Fatalf("Failed to create menu item: %v", err)
+	}
+
+	var trends models.PriceTrends
+	if w := doAPI(t, router, http.MethodGet, "/api/stats/price-trends?interval=day", "", nil, &trends); w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	i := slices.IndexFunc(trends.Series, func(s models.PriceTrendSeries) bool { return s.Key == "Trendby" })
+	if i < 0 || trends.Series[i].Points[len(trends.Series[i].Points)-1].AveragePrice != 99_00 {
+		t.Errorf("Expected today's price of the new region, got %+v", trends.Series)
+	}
+
+	for _, query := range []string{"group_by=city", "interval=hour", "from=yesterday", "from=2000-01-01&interval=day"} {
+		if w := doAPI(t, router, http.MethodGet, "/api/stats/price-trends?"+query, "", nil, nil); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, w.Code)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"lunch_menu/internal/config"
+	"lunch_menu/internal/middleware"
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/utils"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+)
+
+func newRateLimitConfig(t *testing.T) config.RateLimitConfig {
+	t.Helper()
+	redis := miniredis.RunT(t)
+	cfg := config.Defaults().RateLimit
+	cfg.Store = "redis"
+	cfg.RedisURL = "redis://" + redis.Addr()
+	return cfg
+}
+
+// newRateLimitedRouter is a replica of the API with the global tier and a
+// login route with the auth tier
+func newRateLimitedRouter(t *testing.T, cfg config.RateLimitConfig) *gin.Engine {
+	t.Helper()
+	limits, err := middleware.NewRateLimiter(cfg)
+	if err != nil {
+		t.Fatalf("NewRateLimiter failed: %v", err)
+	}
+	t.Cleanup(limits.Close)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(limits.Limit(middleware.TierAPI))
+	router.GET("/api/restaurants", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.POST("/api/user/login", limits.Limit(middleware.TierAuth), func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router
+}
+
+func doRateLimited(router *gin.Engine, method, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.RemoteAddr = "203.0.113.7:4711"
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimit_SharedAcrossReplicas(t *testing.T) {
+	cfg := newRateLimitConfig(t)
+	cfg.Rate = "3-M"
+	replicaA := newRateLimitedRouter(t, cfg)
+	replicaB := newRateLimitedRouter(t, cfg)
+
+	for i, router := range []*gin.Engine{replicaA, replicaB, replicaA} {
+		w := doRateLimited(router, http.MethodGet, "/api/restaurants", "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("Request %d: expected 200, got %d", i+1, w.Code)
+		}
+		if got, want := w.Header().Get(middleware.RateLimitRemainingHeader), strconv.Itoa(2-i); got != want {
+			t.Errorf("Request %d: expected %s remaining, got %q", i+1, want, got)
+		}
+	}
+
+	w := doRateLimited(replicaB, http.MethodGet, "/api/restaurants", "")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected 429 once the shared budget is spent, got %d", w.Code)
+	}
+	if w.Header().Get(middleware.RateLimitLimitHeader) != "3" || w.Header().Get(middleware.RateLimitPolicyHeader) != "3;w=60" {
+		t.Errorf("Unexpected RateLimit headers: %v", w.Header())
+	}
+	if reset, err := strconv.Atoi(w.Header().Get("Retry-After")); err != nil || reset < 1 || reset > 60 {
+		t.Errorf("Expected Retry-After within the window, got %q", w.Header().Get("Retry-After"))
+	}
+	var problem models.Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil || problem.Code != "RATE_LIMITED" {
+		t.Errorf("Expected a RATE_LIMITED problem, got %s", w.Body.String())
+	}
+}
+
+func TestRateLimit_StricterLoginTier(t *testing.T) {
+	cfg := newRateLimitConfig(t)
+	cfg.Auth = "2-M"
+	router := newRateLimitedRouter(t, cfg)
+
+	for i := 0; i < 2; i++ {
+		if w := doRateLimited(router, http.MethodPost, "/api/user/login", ""); w.Code != http.StatusOK {
+			t.Fatalf("Login %d: expected 200, got %d", i+1, w.Code)
+		}
+	}
+	w := doRateLimited(router, http.MethodPost, "/api/user/login", "")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected the third login to be limited, got %d", w.Code)
+	}
+	if got := w.Header().Get(middleware.RateLimitLimitHeader); got != "2" {
+		t.Errorf("Expected the headers of the login tier, got limit %q", got)
+	}
+	if w := doRateLimited(router, http.MethodGet, "/api/restaurants", ""); w.Code != http.StatusOK {
+		t.Errorf("Expected reads to keep their own budget, got %d", w.Code)
+	}
+}
+
+func TestRateLimit_PerUser(t *testing.T) {
+	cfg := newRateLimitConfig(t)
+	cfg.Rate = "1-M"
+	cfg.User = "2-M"
+	router := newRateLimitedRouter(t, cfg)
+
+	alice, err := utils.GenerateJWT(&models.User{ID: 101, Username: "alice", Role: "admin"})
+	if err != nil {
+		t.Fatalf("GenerateJWT failed: %v", err)
+	}
+	bob, err := utils.GenerateJWT(&models.User{ID: 102, Username: "bob", Role: "admin"})
+	if err != nil {
+		t.Fatalf("GenerateJWT failed: %v", err)
+	}
+
+	// Same IP: the anonymous budget is spent, each user has their own
+	if w := doRateLimited(router, http.MethodGet, "/api/restaurants", ""); w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 for the anonymous request, got %d", w.Code)
+	}
+	for _, token := range []string{alice, alice, bob} {
+		if w := doRateLimited(router, http.MethodGet, "/api/restaurants", token); w.Code != http.StatusOK {
+			t.Fatalf("Expected 200 within the user budget, got %d", w.Code)
+		}
+	}
+	if w := doRateLimited(router, http.MethodGet, "/api/restaurants", alice); w.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected 429 once the user budget is spent, got %d", w.Code)
+	}
+	if w := doRateLimited(router, http.MethodGet, "/api/restaurants", "not-a-token"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected invalid tokens to count against the IP, got %d", w.Code)
+	}
+}
+
+func TestRateLimit_FailsOpenWithoutRedis(t *testing.T) {
+	redis := miniredis.RunT(t)
+	cfg := config.Defaults().RateLimit
+	cfg.Store = "redis"
+	cfg.RedisURL = "redis://" + redis.Addr()
+	router := newRateLimitedRouter(t, cfg)
+
+	redis.Close()
+	w := doRateLimited(router, http.MethodGet, "/api/restaurants", "")
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected requests to pass while Redis is down, got %d", w.Code)
+	}
+}
+
+func TestRateLimit_MemoryStore(t *testing.T) {
+	cfg := config.Defaults().RateLimit
+	cfg.Rate = "1-M"
+	router := newRateLimitedRouter(t, cfg)
+
+	if w := doRateLimited(router, http.MethodGet, "/api/restaurants", ""); w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", w.Code)
+	}
+	if w := doRateLimited(router, http.MethodGet, "/api/restaurants", ""); w.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected 429, got %d", w.Code)
+	}
+}
@@ -0,0 +1,167 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/utils"
+)
+
+// customerToken logs in as a new customer of the test and returns the access
+// token; name tells several customers of a test apart
+func customerToken(t *testing.T, router *gin.Engine, name string) string {
+	t.Helper()
+	username := strings.ToLower(t.Name()) + "_" + name
+	hash, err := utils.HashPassword(username)
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+	if _, err := testApp.Users.Create(context.Background(), &models.User{
+		Username: username, Email: username + "@example.com", PasswordHash: hash, Role: "customer", IsActive: true,
+	}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	var login struct {
+		Token string `json:"token"`
+	}
+	w := doAPI(t, router, http.MethodPost, "/api/user/login", "",
+		models.UserLoginInput{Username: username, PasswordHash: username}, &login)
+	if w.Code != http.StatusOK || login.Token == "" {
+		t.Fatalf("Expected a token from login, got %d: %s", w.Code, w.Body.String())
+	}
+	return login.Token
+}
+
+func TestAPI_Reviews(t *testing.T) {
+	router := newAPIRouter(t)
+	admin := adminToken(t, router)
+	alice := customerToken(t, router, "alice")
+	bob := customerToken(t, router, "bob")
+	restaurant, err := testApp.Restaurants.Create(context.Background(), &models.Restaurant{Name: "Review Room", Address: "4 Main St"})
+	if err != nil {
+		t.Fatalf("Failed to create restaurant: %v", err)
+	}
+	item, err := testApp.MenuItems.Create(context.Background(), &models.MenuItem{RestaurantID: restaurant.ID, Name: "Herring", Price: 95_00})
+	if err != nil {
+		t.Fatalf("Failed to create menu item: %v", err)
+	}
+	restaurantReviews := fmt.Sprintf("/api/restaurants/%d/reviews", restaurant.ID)
+	itemReviews := fmt.Sprintf("/api/menu-items/%d/reviews", item.ID)
+
+	var review models.Review
+	w := doAPI(t, router, http.MethodPost, restaurantReviews, alice, models.ReviewInput{Rating: 4, Comment: "Cosy"}, &review)
+	if w.Code != http.StatusCreated || review.TargetType != models.ReviewRestaurant || review.TargetID != restaurant.ID {
+		t.Fatalf("Expected the review to be created, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := doAPI(t, router, http.MethodPost, restaurantReviews, alice, models.ReviewInput{Rating: 5}, nil); w.Code != http.StatusConflict {
+		t.Errorf("Expected 409 for a second review on the same day, got %d", w.Code)
+	}
+	doAPI(t, router, http.MethodPost, restaurantReviews, bob, models.ReviewInput{Rating: 5}, nil)
+	doAPI(t, router, http.MethodPost, itemReviews, bob, models.ReviewInput{Rating: 3, Comment: "Salty"}, nil)
+
+	for _, tc := range []struct {
+		name  string
+		path  string
+		token string
+		body  interface{}
+		code  int
+	}{
+		{"anonymous", restaurantReviews, "", models.ReviewInput{Rating: 3}, http.StatusUnauthorized},
+		{"admin", restaurantReviews, admin, models.ReviewInput{Rating: 3}, http.StatusForbidden},
+		{"rating too high", itemReviews, alice, models.ReviewInput{Rating: 6}, http.StatusBadRequest},
+		{"comment too long", itemReviews, alice, models.ReviewInput{Rating: 3, Comment: strings.Repeat("a", 2001)}, http.StatusBadRequest},
+		{"unknown restaurant", "/api/restaurants/999999/reviews", alice, models.ReviewInput{Rating: 3}, http.StatusNotFound},
+	} {
+		if w := doAPI(t, router, http.MethodPost, tc.path, tc.token, tc.body, nil); w.Code != tc.code {
+			t.Errorf("%s: expected %d, got %d: %s", tc.name, tc.code, w.Code, w.Body.String())
+		}
+	}
+
+	// Ratings are part of the restaurant and menu item responses
+	var fetched models.RestaurantResponse
+	doAPI(t, router, http.MethodGet, fmt.Sprintf("/api/restaurants/%d", restaurant.ID), "", nil, &fetched)
+	if fetched.Rating != (models.RatingSummary{Average: 4.5, Count: 2}) {
+		t.Errorf("Expected an average of 4.5 from 2 reviews, got %+v", fetched.Rating)
+	}
+	var fetchedItem models.MenuItem
+	doAPI(t, router, http.MethodGet, fmt.Sprintf("/api/menu-items/%d", item.ID), "", nil, &fetchedItem)
+	if fetchedItem.Rating == nil || *fetchedItem.Rating != (models.RatingSummary{Average: 3, Count: 1}) {
+		t.Errorf("Expected an average of 3 from 1 review, got %+v", fetchedItem.Rating)
+	}
+	var menu models.MenuItemsResponse
+	doAPI(t, router, http.MethodGet, fmt.Sprintf("/api/restaurants/%d/menu", restaurant.ID), "", nil, &menu)
+	if len(menu.MenuItems) != 1 || menu.MenuItems[0].Rating == nil || menu.MenuItems[0].Rating.Count != 1 {
+		t.Errorf("Expected the rating in the menu, got %+v", menu.MenuItems)
+	}
+
+	// Only the author edits and deletes a review
+	reviewPath := fmt.Sprintf("/api/reviews/%d", review.ID)
+	rating := 2
+	if w := doAPI(t, router, http.MethodPut, reviewPath, bob, models.ReviewUpdateInput{Rating: &rating}, nil); w.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 for editing another user's review, got %d", w.Code)
+	}
+	if w := doAPI(t, router, http.MethodPut, reviewPath, alice, models.ReviewUpdateInput{Rating: &rating}, &review); w.Code != http.StatusOK || review.Rating != 2 || review.Comment != "Cosy" {
+		t.Errorf("Expected the rating to change, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := doAPI(t, router, http.MethodDelete, reviewPath, bob, nil, nil); w.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 for deleting another user's review, got %d", w.Code)
+	}
+
+	// Hidden reviews are left out of the listing and the rating
+	var reviews models.ReviewsResponse
+	doAPI(t, router, http.MethodGet, restaurantReviews, "", nil, &reviews)
+	if reviews.Total != 2 || reviews.Rating.Average != 3.5 {
+		t.Errorf("Expected 2 reviews averaging 3.5, got %+v", reviews)
+	}
+	hidden, flagged := true, true
+	moderation := reviewPath + "/moderation"
+	if w := doAPI(t, router, http.MethodPut, moderation, alice, models.ReviewModerationInput{Hidden: &hidden}, nil); w.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 for moderation by a customer, got %d", w.Code)
+	}
+	w = doAPI(t, router, http.MethodPut, moderation, admin, models.ReviewModerationInput{Hidden: &hidden, Flagged: &flagged}, &review)
+	if w.Code != http.StatusOK || !review.Hidden || !review.Flagged {
+		t.Fatalf("Expected the review to be hidden and flagged, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Editing a review only changes its rating and comment, not its moderation
+	comment := "Cosy, but slow"
+	w = doAPI(t, router, http.MethodPut, reviewPath, alice, models.ReviewUpdateInput{Comment: &comment}, &review)
+	if w.Code != http.StatusOK || !review.Hidden || !review.Flagged || review.Comment != comment || review.Rating != 2 {
+		t.Errorf("Expected the edit to keep the moderation, got %d: %s", w.Code, w.Body.String())
+	}
+	doAPI(t, router, http.MethodGet, restaurantReviews, "", nil, &reviews)
+	if reviews.Total != 1 || reviews.Rating != (models.RatingSummary{Average: 5, Count: 1}) {
+		t.Errorf("Expected only the visible review, got %+v", reviews)
+	}
+	var flaggedReviews models.ModerationReviewsResponse
+	if w := doAPI(t, router, http.MethodGet, "/api/reviews?flagged=true", admin, nil, &flaggedReviews); w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	found := false
+	for _, r := range flaggedReviews.Reviews {
+		found = found || r.ID == review.ID
+		if !r.Flagged {
+			t.Errorf("Expected only flagged reviews, got %+v", r)
+		}
+	}
+	if !found {
+		t.Errorf("Expected the flagged review to be listed, got %+v", flaggedReviews)
+	}
+	if w := doAPI(t, router, http.MethodGet, "/api/reviews?hidden=maybe", admin, nil, nil); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid filter, got %d", w.Code)
+	}
+
+	if w := doAPI(t, router, http.MethodDelete, reviewPath, alice, nil, nil); w.Code != http.StatusOK {
+		t.Errorf("Expected the author to delete the review, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := doAPI(t, router, http.MethodDelete, reviewPath, alice, nil, nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a deleted review, got %d", w.Code)
+	}
+}
@@ -0,0 +1,191 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"lunch_menu/internal/config"
+	"lunch_menu/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newCORSRouter(cfg config.CORSConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.CORS(cfg))
+	router.GET("/api/restaurants", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router
+}
+
+func TestCORS_AllowList(t *testing.T) {
+	cfg := config.Defaults().CORS
+	cfg.AllowedOrigins = []string{"https://lunch.example.com", "https://*.staging.example.com"}
+	router := newCORSRouter(cfg)
+
+	tests := []struct {
+		origin  string
+		allowed bool
+	}{
+		{"https://lunch.example.com", true},
+		{"https://LUNCH.example.com", true},
+		{"https://pr-42.staging.example.com", true},
+		{"https://a.b.staging.example.com", true},
+		{"https://staging.example.com", false},
+		{"http://pr-42.staging.example.com", false},
+		{"https://evil-staging.example.com", false},
+		{"https://lunch.example.com.evil.com", false},
+		{"https://evil.com", false},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/restaurants", nil)
+		req.Header.Set("Origin", tt.origin)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		got := w.Header().Get("Access-Control-Allow-Origin")
+		if tt.allowed && (w.Code != http.StatusOK || got != tt.origin) {
+			t.Errorf("%s: expected 200 with the origin allowed, got %d and %q", tt.origin, w.Code, got)
+		}
+		if !tt.allowed && (w.Code != http.StatusForbidden || got != "") {
+			t.Errorf("%s: expected 403 without CORS headers, got %d and %q", tt.origin, w.Code, got)
+		}
+	}
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	cfg := config.Defaults().CORS
+	cfg.AllowedOrigins = []string{"https://lunch.example.com"}
+	router := newCORSRouter(cfg)
+
+	req := httptest.NewRequest(http.MethodOptions, "/api/restaurants", nil)
+	req.Header.Set("Origin", "https://lunch.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	req.Header.Set("Access-Control-Request-Headers", "Content-Type, X-CSRF-Token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected 204 for the preflight, got %d", w.Code)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "true" {
+		t.Errorf("Expected credentials to be allowed, got %q", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Headers"); strings.Contains(got, "*") || !strings.Contains(got, "X-Csrf-Token") {
+		t.Errorf("Expected the explicit header list, got %q", got)
+	}
+	if got := w.Header().Get("Access-Control-Max-Age"); got != "43200" {
+		t.Errorf("Expected preflight max age 43200, got %q", got)
+	}
+}
+
+func TestCORS_SameOriginOnlyByDefault(t *testing.T) {
+	router := newCORSRouter(config.Defaults().CORS)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/restaurants", nil)
+	req.Header.Set("Origin", "https://lunch.example.com")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected cross-origin requests to be rejected by default, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/restaurants", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected requests without Origin to pass, got %d", w.Code)
+	}
+}
+
+func TestCORS_RateLimitedResponsesAllowOrigin(t *testing.T) {
+	cfg := config.Defaults()
+	cfg.CORS.AllowedOrigins = []string{"https://lunch.example.com"}
+	cfg.RateLimit.Rate = "1-M"
+	limits, err := middleware.NewRateLimiter(cfg.RateLimit)
+	if err != nil {
+		t.Fatalf("NewRateLimiter failed: %v", err)
+	}
+	t.Cleanup(limits.Close)
+
+	// In the order of main.go
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandler, middleware.CORS(cfg.CORS), limits.Limit(middleware.TierAPI))
+	router.GET("/api/restaurants", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for _, expected := range []int{http.StatusOK, http.StatusTooManyRequests} {
+		req := httptest.NewRequest(http.MethodGet, "/api/restaurants", nil)
+		req.Header.Set("Origin", "https://lunch.example.com")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if got := w.Header().Get("Access-Control-Allow-Origin"); w.Code != expected || got != "https://lunch.example.com" {
+			t.Errorf("Expected %d with the origin allowed, got %d and %q", expected, w.Code, got)
+		}
+	}
+}
+
+func TestConfigValidate_CORSOrigins(t *testing.T) {
+	cfg := config.Defaults()
+	cfg.Database.Password = "pw"
+	cfg.Auth.JWTSecret = "0123456789abcdef0123456789abcdef"
+
+	cfg.CORS.AllowedOrigins = []string{"https://lunch.example.com", "https://*.example.com", "http://localhost:3000"}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected valid origins, got %v", err)
+	}
+	cfg.CORS.AllowedOrigins = []string{"*"}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "cors.allowed_origins") {
+		t.Errorf("Expected an error for \"*\" with credentials, got %v", err)
+	}
+	cfg.CORS.AllowCredentials = false
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected \"*\" without credentials to be valid, got %v", err)
+	}
+	cfg.CORS.AllowedOrigins = []string{"https://lunch.example.com/app"}
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected an error for an origin with a path")
+	}
+}
+
+func TestSecurityHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := config.Defaults().Security
+	cfg.HSTSMaxAge = 24 * time.Hour
+	cfg.HSTSIncludeSubdomains = true
+	router := gin.New()
+	router.Use(middleware.SecurityHeaders(cfg))
+	router.GET("/api", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/swagger/*any",
+		middleware.OverrideHeaders(map[string]string{"Content-Security-Policy": middleware.SwaggerCSP, "Referrer-Policy": ""}),
+		func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api", nil))
+	for name, want := range map[string]string{
+		"Strict-Transport-Security": "max-age=86400; includeSubDomains",
+		"Content-Security-Policy":   "default-src 'none'; frame-ancestors 'none'",
+		"X-Content-Type-Options":    "nosniff",
+		"X-Frame-Options":           "DENY",
+		"Referrer-Policy":           "no-referrer",
+	} {
+		if got := w.Header().Get(name); got != want {
+			t.Errorf("%s: expected %q, got %q", name, want, got)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger/index.html", nil))
+	if got := w.Header().Get("Content-Security-Policy"); got != middleware.SwaggerCSP {
+		t.Errorf("Expected the Swagger CSP, got %q", got)
+	}
+	if got := w.Header().Get("Referrer-Policy"); got != "" {
+		t.Errorf("Expected Referrer-Policy to be removed, got %q", got)
+	}
+	if got := w.Header().Get("X-Content-Type-Options"); got != "nosniff" {
+		t.Errorf("Expected other headers to be kept, got %q", got)
+	}
+}
@@ -0,0 +1,150 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/money"
+)
+
+func TestSummarizeStatistics(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	updated := now.Add(-time.Hour)
+	old := now.Add(-30 * 24 * time.Hour)
+	restaurants := []models.StatisticsRestaurant{
+		{ID: 1, Name: "Alpha", Region: "Solna", LastMenuUpdate: &updated},
+		{ID: 2, Name: "Beta", Region: "Solna", LastMenuUpdate: &old},
+		{ID: 3, Name: "Gamma", Region: "Uppsala"},
+	}
+	item := func(id, restaurantID uint, category string, price money.Amount, currency string) models.StatisticsMenuItem {
+		return models.StatisticsMenuItem{ID: id, Name: category, RestaurantID: restaurantID, Category: category, Price: price, Currency: currency}
+	}
+	items := []models.StatisticsMenuItem{
+		item(1, 1, "Main", 100_00, "SEK"),
+		item(2, 1, "Main", 120_00, "SEK"),
+		item(3, 2, "Main", 130_00, "SEK"),
+		item(4, 2, "Starter", 60_00, "SEK"),
+		item(5, 2, "Starter", 9_50, "EUR"),
+	}
+
+	stats := &models.BusinessStatistics{Currency: "SEK"}
+	stats.Summarize(restaurants, items, 0, now)
+
+	want := models.PriceSummary{PricedItems: 4, Average: 102_50, Min: 60_00, P25: 90_00, Median: 110_00, P75: 122_50, P90: 127_00, Max: 130_00}
+	if stats.TotalMenuItems != 5 || stats.Prices != want || stats.AveragePrice != want.Average {
+		t.Errorf("Expected five items and %+v, got %d and %+v", want, stats.TotalMenuItems, stats.Prices)
+	}
+	var counts []int64
+	for _, b := range stats.PriceHistogram {
+		counts = append(counts, b.Count)
+	}
+	if len(counts) != 4 || stats.PriceHistogram[0].From != 50_00 || counts[0] != 1 || counts[2] != 2 || counts[3] != 1 {
+		t.Errorf("Expected 25.00 buckets from 50.00, got %+v", stats.PriceHistogram)
+	}
+	if len(stats.Categories) != 2 || stats.Categories[1].Category != "Starter" || stats.Categories[1].MenuItems != 2 || stats.Categories[1].Prices.PricedItems != 1 {
+		t.Errorf("Expected Main and Starter, got %+v", stats.Categories)
+	}
+	if len(stats.Regions) != 2 {
+		t.Fatalf("Expected Solna and Uppsala, got %+v", stats.Regions)
+	}
+	solna, uppsala := stats.Regions[0], stats.Regions[1]
+	if solna.Restaurants != 2 || solna.Cheapest.MenuItemID != 4 || solna.MostExpensive.RestaurantName != "Beta" || solna.Prices.Median != 110_00 {
+		t.Errorf("Expected the Solna figures, got %+v", solna)
+	}
+	if uppsala.Restaurants != 1 || uppsala.MenuItems != 0 || uppsala.Cheapest != nil {
+		t.Errorf("Expected Uppsala without items, got %+v", uppsala)
+	}
+	if stats.StaleRestaurants != 2 || !stats.LastMenuUpdate.Equal(updated) || stats.RestaurantDetails[0].Stale || !stats.RestaurantDetails[1].Stale {
+		t.Errorf("Expected Beta and Gamma to be stale, got %d %+v", stats.StaleRestaurants, stats.RestaurantDetails)
+	}
+
+	// The bucket width is widened to keep the histogram small
+	stats.Summarize(restaurants, items, 1_00, now)
+	if n := len(stats.PriceHistogram); n > models.MaxHistogramBuckets || stats.PriceHistogram[n-1].To <= 130_00 {
+		t.Errorf("Expected at most %d buckets covering all prices, got %+v", models.MaxHistogramBuckets, stats.PriceHistogram)
+	}
+}
+
+func TestAPI_StatisticsFilters(t *testing.T) {
+	router := newAPIRouter(t)
+	restaurant, err := testApp.Restaurants.Create(context.Background(), &models.Restaurant{Name: "Filter Bistro", Address: "8 Main St", Region: "Filterby"})
+	if err != nil {
+		t.Fatalf("Failed to create restaurant: %v", err)
+	}
+	for _, price := range []money.Amount{80_00, 95_00, 140_00} {
+		if _, err := testApp.MenuItems.Create(context.Background(), &models.MenuItem{RestaurantID: restaurant.ID, Name: "Dish", Price: price, Category: "Main"}); err != nil {
+			t.Fatalf("Failed to create menu item: %v", err)
+		}
+	}
+
+	var stats models.BusinessStatistics
+	if w := doAPI(t, router, http.MethodGet, "/api/stats?region=Filterby&bucket_width=50", "", nil, &stats); w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if stats.ActiveRestaurants != 1 || len(stats.RestaurantDetails) != 1 || stats.Prices.Median != 95_00 || stats.Region != "Filterby" {
+		t.Errorf("Expected only the new restaurant, got %+v", stats)
+	}
+	if len(stats.Regions) != 1 || stats.Regions[0].Cheapest == nil || stats.Regions[0].Cheapest.Price != 80_00 || stats.Regions[0].MostExpensive.Price != 140_00 {
+		t.Errorf("Expected the cheapest and most expensive items of the region, got %+v", stats.Regions)
+	}
+	if len(stats.PriceHistogram) != 2 || stats.PriceHistogram[0].From != 50_00 || stats.PriceHistogram[0].Count != 2 {
+		t.Errorf("Expected 50.00 buckets, got %+v", stats.PriceHistogram)
+	}
+
+	today := time.Now().UTC().Format(time.DateOnly)
+	doAPI(t, router, http.MethodGet, "/api/stats?region=Filterby&from="+today+"&to="+today, "", nil, &stats)
+	if stats.TotalMenuItems != 3 {
+		t.Errorf("Expected today's items, got %d", stats.TotalMenuItems)
+	}
+	doAPI(t, router, http.MethodGet, "/api/stats?region=Filterby&to=2000-01-01", "", nil, &stats)
+	if stats.TotalMenuItems != 0 || len(stats.RestaurantDetails) != 1 || stats.RestaurantDetails[0].LastMenuUpdate == nil {
+		t.Errorf("Expected no items before 2000 but the restaurant's freshness, got %+v", stats)
+	}
+
+	for _, query := range []string{"from=yesterday", "from=2026-02-01&to=2026-01-01", "bucket_width=0", "bucket_width=1.005"} {
+		if w := doAPI(t, router, http.MethodGet, "/api/stats?"+query, "", nil, nil); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, w.Code)
+		}
+	}
+}
+
+func TestStatisticsCache(t *testing.T) {
+	ctx := context.Background()
+	cache := testApp.Statistics
+	first, err := cache.Get(ctx, models.StatisticsFilter{})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if again, _ := cache.Get(ctx, models.StatisticsFilter{}); again != first {
+		t.Errorf("Expected the cached statistics, got ones generated at %v", again.GeneratedAt)
+	}
+	snapshot, err := testApp.StatisticsStore.Snapshot(ctx, models.StatisticsFilter{}.Key())
+	if err != nil || snapshot == nil || !snapshot.GeneratedAt.Equal(first.GeneratedAt) {
+		t.Errorf("Expected the statistics of all data to be stored, got %+v (%v)", snapshot, err)
+	}
+
+	// Writes invalidate the cache and the snapshot
+	restaurant, err := testApp.Restaurants.Create(context.Background(), &models.Restaurant{Name: "Cache Cafe", Address: "9 Main St", Region: "Cacheby"})
+	if err != nil {
+		t.Fatalf("Failed to create restaurant: %v", err)
+	}
+	if _, err := testApp.MenuItems.Create(context.Background(), &models.MenuItem{RestaurantID: restaurant.ID, Name: "Toast", Price: 45_00}); err != nil {
+		t.Fatalf("Failed to create menu item: %v", err)
+	}
+	updated, err := cache.Get(ctx, models.StatisticsFilter{})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if updated == first || updated.TotalMenuItems != first.TotalMenuItems+1 || updated.GeneratedAt.Before(first.GeneratedAt) {
+		t.Errorf("Expected statistics with the new item, got %d items generated at %v", updated.TotalMenuItems, updated.GeneratedAt)
+	}
+
+	filter := models.StatisticsFilter{Region: "Cacheby"}
+	regional, _ := cache.Get(ctx, filter)
+	if again, _ := cache.Get(ctx, filter); again != regional || regional.TotalMenuItems != 1 {
+		t.Errorf("Expected the cached statistics of the region, got %+v", again)
+	}
+}
@@ -0,0 +1,237 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"lunch_menu/internal/app"
+	"lunch_menu/internal/config"
+	"lunch_menu/internal/database"
+	"lunch_menu/internal/middleware"
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/routes"
+	"lunch_menu/internal/tracing"
+	"lunch_menu/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"gorm.io/gorm"
+)
+
+func TestTracing_PropagatesTraceContextToFile(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "stats.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := db.AutoMigrate(&models.Restaurant{}, &models.MenuItem{}, &models.MenuItemPrice{}, &models.PriceChange{}, &models.StatisticsSnapshot{}, &models.ViewEvent{}, &models.Review{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	router, spans := newTracedRouter(t, app.NewGorm(db))
+
+	// A filtered request is computed rather than served from the snapshot
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	w := doTraced(router, http.MethodGet, "/api/stats?region=Tracing", traceID, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	traced := spans()
+	for _, span := range traced {
+		if span.SpanContext.TraceID != traceID {
+			t.Errorf("Expected span %q to continue trace %s, got %s", span.Name, traceID, span.SpanContext.TraceID)
+		}
+	}
+	server := findSpan(t, traced, "GET /api/stats", traceID)
+	compute := findSpan(t, traced, "database.ComputeStatistics", traceID)
+	counts := findSpan(t, traced, "statistics.restaurant_counts", traceID)
+	if compute.Parent.SpanID != server.SpanContext.SpanID || counts.Parent.SpanID != compute.SpanContext.SpanID {
+		t.Errorf("Expected server, compute and counts spans nested, got %+v", traced)
+	}
+}
+
+func TestTracing_AuthSpans(t *testing.T) {
+	router, spans := newTracedRouter(t, testApp)
+	username := strings.ToLower(t.Name())
+	input := models.UserInput{Username: username, PasswordHash: "secret", Email: username + "@example.com"}
+	if w := doTraced(router, http.MethodPost, "/api/user/register", "11111111111111111111111111111111", input); w.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := doTraced(router, http.MethodPost, "/api/user/login", "22222222222222222222222222222222", input); w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// An expired access token is renewed with the refresh token cookie
+	user, err := testApp.Users.GetByUsername(context.Background(), username)
+	if err != nil {
+		t.Fatalf("Failed to get user: %v", err)
+	}
+	refreshToken := utils.GenerateRandomToken()
+	if err := testApp.Tokens.SaveRefreshToken(context.Background(), user.ID, refreshToken, "test-agent", "127.0.0.1", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Failed to save refresh token: %v", err)
+	}
+	expired := GenerateTestJWT(map[string]interface{}{
+		"user_id": user.ID, "username": username, "role": models.RoleCustomer, "exp": time.Now().Add(-time.Hour).Unix(),
+	}, t)
+	renew := func(traceID, refreshToken string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/user/me/preferences", nil)
+		req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+		req.Header.Set("Authorization", "Bearer "+expired)
+		req.Header.Set("User-Agent", "test-agent")
+		req.RemoteAddr = "127.0.0.1:12345"
+		req.AddCookie(&http.Cookie{Name: "refresh_token", Value: refreshToken})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	if code := renew("33333333333333333333333333333333", refreshToken); code != http.StatusOK {
+		t.Fatalf("Expected the token to be renewed, got %d", code)
+	}
+	if code := renew("44444444444444444444444444444444", "unknown"); code != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 for an unknown refresh token, got %d", code)
+	}
+
+	traced := spans()
+	for _, tt := range []struct {
+		name, parent, traceID, status string
+	}{
+		{"auth.hash_password", "POST /api/user/register", "11111111111111111111111111111111", "Unset"},
+		{"auth.check_password", "POST /api/user/login", "22222222222222222222222222222222", "Unset"},
+		{"auth.renew_access_token", "GET /api/user/me/preferences", "33333333333333333333333333333333", "Unset"},
+		{"auth.renew_access_token", "GET /api/user/me/preferences", "44444444444444444444444444444444", "Error"},
+	} {
+		span, parent := findSpan(t, traced, tt.name, tt.traceID), findSpan(t, traced, tt.parent, tt.traceID)
+		if span.Parent.SpanID != parent.SpanContext.SpanID || span.Status.Code != tt.status {
+			t.Errorf("Expected %s to be a child of %s with status %s, got %+v", tt.name, tt.parent, tt.status, span)
+		}
+	}
+}
+
+func TestTracing_RejectsUnknownExporter(t *testing.T) {
+	if _, err := tracing.Setup(context.Background(), tracing.Options{Exporter: "zipkin"}); err == nil {
+		t.Error("Expected error for unknown exporter")
+	}
+}
+
+func TestTracing_RepositoryQueriesAreChildSpans(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	shutdown, err := tracing.Setup(context.Background(), tracing.Options{Exporter: "file", FilePath: path})
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "traced.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := db.Use(tracing.Plugin{}); err != nil {
+		t.Fatalf("Failed to register the tracing plugin: %v", err)
+	}
+	if err := db.AutoMigrate(&models.Restaurant{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	ctx, span := tracing.Start(context.Background(), "request")
+	if _, _, err := database.NewRestaurantRepository(db).List(ctx, 10, 0); err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	span.End()
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	parents := map[string]string{} // span name to parent span ID
+	ids := map[string]string{}
+	for _, s := range readSpans(t, path) {
+		ids[s.Name], parents[s.Name] = s.SpanContext.SpanID, s.Parent.SpanID
+	}
+	if parents["gorm.query"] == "" || parents["gorm.query"] != ids["request"] {
+		t.Errorf("Expected the query to be a child of the request span, got spans %v with parents %v", ids, parents)
+	}
+}
+
+// tracedSpan is a span as written by the file exporter
+type tracedSpan struct {
+	Name        string
+	SpanContext struct{ TraceID, SpanID string }
+	Parent      struct{ SpanID string }
+	Status      struct{ Code string }
+}
+
+// newTracedRouter returns the API router of a with the server spans of
+// otelgin, and a function that flushes the spans and returns them
+func newTracedRouter(t *testing.T, a *app.App) (*gin.Engine, func() []tracedSpan) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	shutdown, err := tracing.Setup(context.Background(), tracing.Options{Exporter: "file", FilePath: path})
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	limits, err := middleware.NewRateLimiter(config.Defaults().RateLimit)
+	if err != nil {
+		t.Fatalf("NewRateLimiter failed: %v", err)
+	}
+	t.Cleanup(limits.Close)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(otelgin.Middleware(tracing.ServiceName), middleware.ErrorHandler)
+	routes.SetupRoutes(router, a, limits)
+	return router, func() []tracedSpan {
+		t.Helper()
+		if err := shutdown(context.Background()); err != nil {
+			t.Fatalf("Shutdown failed: %v", err)
+		}
+		return readSpans(t, path)
+	}
+}
+
+// doTraced sends body as JSON in the trace traceID
+func doTraced(router *gin.Engine, method, path, traceID string, body interface{}) *httptest.ResponseRecorder {
+	var reader bytes.Buffer
+	if body != nil {
+		_ = json.NewEncoder(&reader).Encode(body)
+	}
+	req := httptest.NewRequest(method, path, &reader)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// readSpans reads the spans written by the file exporter to path
+func readSpans(t *testing.T, path string) []tracedSpan {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read trace file: %v", err)
+	}
+	var spans []tracedSpan
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var span tracedSpan
+		if err := json.Unmarshal([]byte(line), &span); err != nil {
+			t.Fatalf("Invalid span line: %v", err)
+		}
+		spans = append(spans, span)
+	}
+	return spans
+}
+
+// findSpan returns the span named name of the trace traceID
+func findSpan(t *testing.T, spans []tracedSpan, name, traceID string) tracedSpan {
+	t.Helper()
+	i := slices.IndexFunc(spans, func(s tracedSpan) bool { return s.Name == name && s.SpanContext.TraceID == traceID })
+	if i < 0 {
+		t.Fatalf("Expected a span %q in trace %s, got %+v", name, traceID, spans)
+	}
+	return spans[i]
+}
@@ -0,0 +1,135 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/repository/memory"
+	"lunch_menu/internal/views"
+)
+
+func TestAPI_Trending(t *testing.T) {
+	router := newAPIRouter(t)
+	restaurant, err := testApp.Restaurants.Create(context.Background(), &models.Restaurant{Name: "Trend Tavern", Address: "8 Main St", Region: "Viewby"})
+	if err != nil {
+		t.Fatalf("Failed to create restaurant: %v", err)
+	}
+	var items []*models.MenuItem
+	for _, name := range []string{"Pancakes", "Waffles"} {
+		item, err := testApp.MenuItems.Create(context.Background(), &models.MenuItem{RestaurantID: restaurant.ID, Name: name, Price: 60_00})
+		if err != nil {
+			t.Fatalf("Failed to create menu item: %v", err)
+		}
+		items = append(items, item)
+	}
+
+	doAPI(t, router, http.MethodGet, fmt.Sprintf("/api/restaurants/%d", restaurant.ID), "", nil, nil)
+	doAPI(t, router, http.MethodGet, fmt.Sprintf("/api/restaurants/%d/menu", restaurant.ID), "", nil, nil)
+	for i := 0; i < 3; i++ {
+		doAPI(t, router, http.MethodGet, fmt.Sprintf("/api/menu-items/%d", items[1].ID), "", nil, nil)
+	}
+	doAPI(t, router, http.MethodGet, fmt.Sprintf("/api/menu-items/%d", items[0].ID), "", nil, nil)
+	// Failed requests are not views
+	doAPI(t, router, http.MethodGet, "/api/restaurants/999999", "", nil, nil)
+	if err := testApp.ViewRecorder.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	var trending models.Trending
+	if w := doAPI(t, router, http.MethodGet, "/api/stats/trending?period=week&limit=50", "", nil, &trending); w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if trending.Period != models.TrendingWeek || time.Since(trending.Since) < 7*24*time.Hour-time.Minute {
+		t.Errorf("Expected the last week, got %s since %v", trending.Period, trending.Since)
+	}
+	var restaurantViews int64
+	for _, r := range trending.Restaurants {
+		if r.RestaurantID == restaurant.ID {
+			restaurantViews = r.Views
+		}
+	}
+	if restaurantViews != 6 {
+		t.Errorf("Expected 6 views of the restaurant, its menu and items, got %d", restaurantViews)
+	}
+	itemViews := make(map[uint]int64)
+	position := make(map[uint]int)
+	for i, item := range trending.MenuItems {
+		itemViews[item.MenuItemID] = item.Views
+		position[item.MenuItemID] = i
+	}
+	if itemViews[items[1].ID] != 3 || itemViews[items[0].ID] != 1 || position[items[1].ID] > position[items[0].ID] {
+		t.Errorf("Expected Waffles (3 views) before Pancakes (1 view), got %+v", trending.MenuItems)
+	}
+
+	// Views of a deleted item no longer trend
+	if err := testApp.MenuItems.Delete(context.Background(), items[1].ID); err != nil {
+		t.Fatalf("Failed to delete menu item: %v", err)
+	}
+	doAPI(t, router, http.MethodGet, "/api/stats/trending?limit=50", "", nil, &trending)
+	for _, item := range trending.MenuItems {
+		if item.MenuItemID == items[1].ID {
+			t.Errorf("Expected the deleted item not to trend, got %+v", item)
+		}
+	}
+
+	for _, query := range []string{"period=month", "limit=0", "limit=51", "limit=ten"} {
+		if w := doAPI(t, router, http.MethodGet, "/api/stats/trending?"+query, "", nil, nil); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %s, got %d", query, w.Code)
+		}
+	}
+}
+
+func TestViewRecorder(t *testing.T) {
+	store := memory.NewStore()
+	restaurant, _ := store.Restaurants().Create(context.Background(), &models.Restaurant{Name: "Queue Cafe", Address: "1 Main St"})
+	recorder := views.NewRecorder(store.Views(), views.Options{BufferSize: 3, BatchSize: 2, FlushInterval: time.Hour})
+	for i := 0; i < 5; i++ {
+		recorder.Record(models.ViewEvent{Kind: models.ViewRestaurant, RestaurantID: restaurant.ID})
+	}
+	trending, _, _ := store.Views().Trending(context.Background(), time.Now().Add(-time.Hour), 10)
+	if len(trending) != 0 {
+		t.Errorf("Expected no views before they are written, got %+v", trending)
+	}
+
+	// Run writes the queued events when it stops; those beyond the buffer are dropped
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	recorder.Run(ctx)
+	trending, _, _ = store.Views().Trending(context.Background(), time.Now().Add(-time.Hour), 10)
+	if len(trending) != 1 || trending[0].Views != 3 {
+		t.Errorf("Expected 3 views, got %+v", trending)
+	}
+}
+
+func TestStatisticsViews(t *testing.T) {
+	restaurant, err := testApp.Restaurants.Create(context.Background(), &models.Restaurant{Name: "Counted Corner", Address: "3 Main St", Region: "Countby"})
+	if err != nil {
+		t.Fatalf("Failed to create restaurant: %v", err)
+	}
+	testApp.ViewRecorder.Record(models.ViewEvent{Kind: models.ViewRestaurant, RestaurantID: restaurant.ID})
+	testApp.ViewRecorder.Record(models.ViewEvent{Kind: models.ViewMenu, RestaurantID: restaurant.ID})
+	testApp.ViewRecorder.Record(models.ViewEvent{
+		Kind: models.ViewRestaurant, RestaurantID: restaurant.ID, ViewedAt: time.Now().AddDate(0, 0, -30),
+	})
+	if err := testApp.ViewRecorder.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	stats, err := testApp.Statistics.Get(context.Background(), models.StatisticsFilter{Region: "Countby"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if stats.TotalViews != 3 || len(stats.RestaurantDetails) != 1 || stats.RestaurantDetails[0].Views != 3 {
+		t.Errorf("Expected 3 views, got %d: %+v", stats.TotalViews, stats.RestaurantDetails)
+	}
+	stats, _ = testApp.Statistics.Get(context.Background(), models.StatisticsFilter{
+		Region: "Countby", From: time.Now().AddDate(0, 0, -7),
+	})
+	if stats.TotalViews != 2 {
+		t.Errorf("Expected the 2 views of the last week, got %d", stats.TotalViews)
+	}
+}
@@ -0,0 +1,424 @@
+package tests
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"lunch_menu/internal/app"
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/repository"
+	"lunch_menu/internal/repository/memory"
+	"lunch_menu/internal/webhooks"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestWebhookSignature(t *testing.T) {
+	body := []byte(`{"id":1,"type":"menu_item.created"}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("1700000000." + string(body)))
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	if got := webhooks.Sign("secret", "1700000000", body); got != expected {
+		t.Errorf("Expected signature %s, got %s", expected, got)
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	base, max := 30*time.Second, 5*time.Minute
+	cases := map[int]time.Duration{1: 30 * time.Second, 2: time.Minute, 3: 2 * time.Minute, 5: max, 20: max}
+	for attempts, expected := range cases {
+		if got := webhooks.Backoff(attempts, base, max); got != expected {
+			t.Errorf("Backoff(%d): expected %v, got %v", attempts, expected, got)
+		}
+	}
+}
+
+func TestWebhookSubscriptionMatches(t *testing.T) {
+	sub := models.WebhookSubscription{EventTypes: "menu_item.created, menu_item.updated"}
+	if !sub.Matches(models.EventMenuItemUpdated) {
+		t.Error("Expected subscription to match menu_item.updated")
+	}
+	if sub.Matches(models.EventRestaurantDeleted) {
+		t.Error("Expected subscription not to match restaurant.deleted")
+	}
+	all := models.WebhookSubscription{EventTypes: models.EventAll}
+	if !all.Matches(models.EventRestaurantDeleted) {
+		t.Error("Expected wildcard subscription to match every event")
+	}
+}
+
+func TestAPI_WebhookSubscriptions(t *testing.T) {
+	router := newAPIRouter(t)
+	token := adminToken(t, router)
+
+	var created models.WebhookSubscription
+	w := doAPI(t, router, http.MethodPost, "/api/webhooks", token, models.WebhookSubscriptionInput{
+		URL: "https://example.com/hook", EventTypes: []string{models.EventMenuItemCreated},
+	}, &created)
+	if w.Code != http.StatusCreated || created.ID == 0 || created.Secret == "" || !created.IsActive {
+		t.Fatalf("Expected an active subscription with a generated secret, got %d: %s", w.Code, w.Body.String())
+	}
+	path := fmt.Sprintf("/api/webhooks/%d", created.ID)
+
+	inactive := false
+	var updated models.WebhookSubscription
+	w = doAPI(t, router, http.MethodPut, path, token, models.WebhookSubscriptionUpdateInput{IsActive: &inactive}, &updated)
+	if w.Code != http.StatusOK || updated.IsActive || updated.URL != created.URL || updated.Secret != "" {
+		t.Errorf("Expected only the active flag to change and no secret, got %d: %s", w.Code, w.Body.String())
+	}
+	var list models.WebhookSubscriptionsResponse
+	doAPI(t, router, http.MethodGet, "/api/webhooks?limit=100", token, nil, &list)
+	if !slices.ContainsFunc(list.Subscriptions, func(s models.WebhookSubscription) bool {
+		return s.ID == created.ID && s.Secret == "" && !s.IsActive
+	}) {
+		t.Errorf("Expected the subscription to be listed without its secret, got %+v", list.Subscriptions)
+	}
+	var deliveries models.WebhookDeliveriesResponse
+	if w := doAPI(t, router, http.MethodGet, path+"/deliveries", token, nil, &deliveries); w.Code != http.StatusOK || deliveries.Total != 0 {
+		t.Errorf("Expected no deliveries, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := doAPI(t, router, http.MethodPost, path+"/deliveries/999999/retry", token, nil, nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 retrying an unknown delivery, got %d", w.Code)
+	}
+
+	if w := doAPI(t, router, http.MethodDelete, path, token, nil, nil); w.Code != http.StatusOK {
+		t.Fatalf("Expected the subscription to be deleted, got %d", w.Code)
+	}
+	if w := doAPI(t, router, http.MethodGet, path, token, nil, nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 after deletion, got %d", w.Code)
+	}
+	if w := doAPI(t, router, http.MethodGet, "/api/webhooks", customerToken(t, router, "hooked"), nil, nil); w.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 for a customer, got %d", w.Code)
+	}
+}
+
+// webhookBackends are the stores the dispatcher tests run against
+var webhookBackends = []string{"memory", "sqlite"}
+
+func TestWebhookDispatcher_RetriesUntilDead(t *testing.T) {
+	for _, backend := range webhookBackends {
+		t.Run(backend, func(t *testing.T) {
+			ctx := context.Background()
+			a := newWebhookApp(t, backend)
+			receiver := newWebhookReceiver(t, http.StatusInternalServerError, 0)
+			sub, err := a.Webhooks.Create(ctx, &models.WebhookSubscription{URL: receiver.URL, EventTypes: models.EventRestaurantCreated, Secret: "s3cret", IsActive: true})
+			if err != nil {
+				t.Fatalf("Failed to create subscription: %v", err)
+			}
+			other, err := a.Webhooks.Create(ctx, &models.WebhookSubscription{URL: receiver.URL, EventTypes: models.EventRestaurantDeleted, Secret: "other", IsActive: true})
+			if err != nil {
+				t.Fatalf("Failed to create subscription: %v", err)
+			}
+			restaurant, err := a.Restaurants.Create(ctx, &models.Restaurant{Name: "Hook House", Address: "1 Hook St", Region: "Solna"})
+			if err != nil {
+				t.Fatalf("Failed to create restaurant: %v", err)
+			}
+
+			const backoff = 300 * time.Millisecond
+			dispatcher := webhooks.NewDispatcher(a.Outbox, a.Webhooks, webhooks.Options{BatchSize: 10, Timeout: 5 * time.Second, MaxAttempts: 2, BaseBackoff: backoff, MaxBackoff: time.Hour})
+			before := time.Now()
+			dispatcher.Poll(ctx)
+			after := time.Now()
+
+			requests := receiver.Requests()
+			if len(requests) != 1 {
+				t.Fatalf("Expected one request for the matching subscription, got %d", len(requests))
+			}
+			req := requests[0]
+			if expected := webhooks.Sign("s3cret", req.Header.Get(webhooks.HeaderTimestamp), req.Body); req.Header.Get(webhooks.HeaderSignature) != expected {
+				t.Errorf("Expected signature %s, got %s", expected, req.Header.Get(webhooks.HeaderSignature))
+			}
+			if timestamp, err := strconv.ParseInt(req.Header.Get(webhooks.HeaderTimestamp), 10, 64); err != nil || timestamp < before.Unix() || timestamp > after.Unix() {
+				t.Errorf("Expected the current timestamp, got %q", req.Header.Get(webhooks.HeaderTimestamp))
+			}
+			var envelope webhooks.Envelope
+			var data models.Restaurant
+			if err := json.Unmarshal(req.Body, &envelope); err != nil || envelope.Type != models.EventRestaurantCreated {
+				t.Fatalf("Expected the event envelope, got %s (%v)", req.Body, err)
+			}
+			if err := json.Unmarshal(envelope.Data, &data); err != nil || data.ID != restaurant.ID || data.Name != "Hook House" {
+				t.Errorf("Expected the restaurant as event data, got %s (%v)", envelope.Data, err)
+			}
+			if req.Header.Get(webhooks.HeaderEvent) != models.EventRestaurantCreated {
+				t.Errorf("Expected the event header, got %q", req.Header.Get(webhooks.HeaderEvent))
+			}
+
+			delivery := onlyDelivery(t, a.Webhooks, sub.ID)
+			if delivery.Status != models.DeliveryPending || delivery.Attempts != 1 || delivery.ResponseStatus != http.StatusInternalServerError || delivery.LastError == "" {
+				t.Errorf("Expected a failed attempt to stay pending, got %+v", delivery)
+			}
+			if delivery.EventID != envelope.ID {
+				t.Errorf("Expected the envelope ID to be the event ID %d, got %d", delivery.EventID, envelope.ID)
+			}
+			if delivery.NextAttemptAt.Before(before.Add(backoff)) || delivery.NextAttemptAt.After(after.Add(backoff)) {
+				t.Errorf("Expected the next attempt after the base backoff, got %v", delivery.NextAttemptAt)
+			}
+			if req.Header.Get(webhooks.HeaderDelivery) != strconv.FormatUint(uint64(delivery.ID), 10) {
+				t.Errorf("Expected the delivery header %d, got %q", delivery.ID, req.Header.Get(webhooks.HeaderDelivery))
+			}
+			if _, total, _ := a.Webhooks.Deliveries(ctx, other.ID, "", 10, 0); total != 0 {
+				t.Errorf("Expected no delivery for a subscription to other events, got %d", total)
+			}
+
+			dispatcher.Poll(ctx)
+			if n := len(receiver.Requests()); n != 1 {
+				t.Fatalf("Expected no attempt before the backoff has passed, got %d requests", n)
+			}
+
+			time.Sleep(time.Until(delivery.NextAttemptAt))
+			dispatcher.Poll(ctx)
+			if n := len(receiver.Requests()); n != 2 {
+				t.Fatalf("Expected a second attempt once due, got %d requests", n)
+			}
+			delivery = onlyDelivery(t, a.Webhooks, sub.ID)
+			if delivery.Status != models.DeliveryDead || delivery.Attempts != 2 {
+				t.Errorf("Expected the delivery to go dead after MaxAttempts, got %+v", delivery)
+			}
+
+			time.Sleep(2 * backoff)
+			dispatcher.Poll(ctx)
+			if n := len(receiver.Requests()); n != 2 {
+				t.Errorf("Expected no attempt at a dead delivery, got %d requests", n)
+			}
+		})
+	}
+}
+
+func TestWebhookDispatcher_ClaimsAndDeliversRetriedDelivery(t *testing.T) {
+	for _, backend := range webhookBackends {
+		t.Run(backend, func(t *testing.T) {
+			ctx := context.Background()
+			a := newWebhookApp(t, backend)
+			failing := newWebhookReceiver(t, http.StatusBadGateway, 0)
+			sub, err := a.Webhooks.Create(ctx, &models.WebhookSubscription{URL: failing.URL, EventTypes: models.EventAll, Secret: "s3cret", IsActive: true})
+			if err != nil {
+				t.Fatalf("Failed to create subscription: %v", err)
+			}
+			if _, err := a.Restaurants.Create(ctx, &models.Restaurant{Name: "Retry Room", Address: "1 Retry St", Region: "Solna"}); err != nil {
+				t.Fatalf("Failed to create restaurant: %v", err)
+			}
+			options := webhooks.Options{BatchSize: 10, Timeout: 5 * time.Second, MaxAttempts: 1, BaseBackoff: time.Hour, MaxBackoff: time.Hour}
+			webhooks.NewDispatcher(a.Outbox, a.Webhooks, options).Poll(ctx)
+			delivery := onlyDelivery(t, a.Webhooks, sub.ID)
+			if delivery.Status != models.DeliveryDead {
+				t.Fatalf("Expected the delivery to go dead, got %+v", delivery)
+			}
+
+			receiver := newWebhookReceiver(t, http.StatusNoContent, 0)
+			url := receiver.URL
+			if _, err := a.Webhooks.Update(ctx, sub.ID, &models.WebhookSubscriptionUpdateInput{URL: &url}); err != nil {
+				t.Fatalf("Failed to update subscription: %v", err)
+			}
+			if _, err := a.Webhooks.RetryDelivery(ctx, sub.ID, delivery.ID); err != nil {
+				t.Fatalf("RetryDelivery failed: %v", err)
+			}
+
+			const lease = 300 * time.Millisecond
+			claimed, err := a.Webhooks.ClaimDueDeliveries(ctx, 10, lease)
+			if err != nil || len(claimed) != 1 || claimed[0].ID != delivery.ID {
+				t.Fatalf("Expected to claim the retried delivery, got %v (%v)", claimed, err)
+			}
+			if claimed, err := a.Webhooks.ClaimDueDeliveries(ctx, 10, lease); err != nil || len(claimed) != 0 {
+				t.Fatalf("Expected a claimed delivery not to be claimed again, got %v (%v)", claimed, err)
+			}
+			dispatcher := webhooks.NewDispatcher(a.Outbox, a.Webhooks, options)
+			dispatcher.Poll(ctx)
+			if n := len(receiver.Requests()); n != 0 {
+				t.Fatalf("Expected no attempt at a claimed delivery, got %d requests", n)
+			}
+
+			time.Sleep(lease)
+			dispatcher.Poll(ctx)
+			if n := len(receiver.Requests()); n != 1 {
+				t.Fatalf("Expected one attempt once the claim expired, got %d requests", n)
+			}
+			got := onlyDelivery(t, a.Webhooks, sub.ID)
+			if got.Status != models.DeliverySucceeded || got.Attempts != 1 || got.ResponseStatus != http.StatusNoContent || got.DeliveredAt == nil {
+				t.Errorf("Expected the delivery to succeed, got %+v", got)
+			}
+		})
+	}
+}
+
+func TestWebhookDispatcher_SkipsDeletedAndInactiveSubscriptions(t *testing.T) {
+	for _, backend := range webhookBackends {
+		t.Run(backend, func(t *testing.T) {
+			ctx := context.Background()
+			a := newWebhookApp(t, backend)
+			receiver := newWebhookReceiver(t, http.StatusOK, 0)
+			var subs []*models.WebhookSubscription
+			for range 2 {
+				sub, err := a.Webhooks.Create(ctx, &models.WebhookSubscription{URL: receiver.URL, EventTypes: models.EventAll, Secret: "s3cret", IsActive: true})
+				if err != nil {
+					t.Fatalf("Failed to create subscription: %v", err)
+				}
+				subs = append(subs, sub)
+			}
+			if _, err := a.Restaurants.Create(ctx, &models.Restaurant{Name: "Skip Shack", Address: "1 Skip St", Region: "Solna"}); err != nil {
+				t.Fatalf("Failed to create restaurant: %v", err)
+			}
+			if n, err := a.Outbox.Dispatch(ctx, 10); err != nil || n != 1 {
+				t.Fatalf("Expected one event to be dispatched, got %d (%v)", n, err)
+			}
+
+			inactive := false
+			if _, err := a.Webhooks.Update(ctx, subs[0].ID, &models.WebhookSubscriptionUpdateInput{IsActive: &inactive}); err != nil {
+				t.Fatalf("Failed to deactivate subscription: %v", err)
+			}
+			if err := a.Webhooks.Delete(ctx, subs[1].ID); err != nil {
+				t.Fatalf("Failed to delete subscription: %v", err)
+			}
+
+			dispatcher := webhooks.NewDispatcher(a.Outbox, a.Webhooks, webhooks.Options{BatchSize: 10, Timeout: 5 * time.Second, MaxAttempts: 8, BaseBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+			dispatcher.Poll(ctx)
+			for _, sub := range subs {
+				delivery := onlyDelivery(t, a.Webhooks, sub.ID)
+				if delivery.Status != models.DeliverySkipped || delivery.Attempts != 0 || delivery.LastError == "" {
+					t.Errorf("Expected the delivery to subscription %d to be skipped, got %+v", sub.ID, delivery)
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			dispatcher.Poll(ctx)
+			if n := len(receiver.Requests()); n != 0 {
+				t.Errorf("Expected no requests to deleted or inactive subscriptions, got %d", n)
+			}
+		})
+	}
+}
+
+func TestWebhookDispatcher_DeliversOnceWithSlowReceiverAndTwoDispatchers(t *testing.T) {
+	for _, backend := range webhookBackends {
+		t.Run(backend, func(t *testing.T) {
+			ctx := context.Background()
+			a := newWebhookApp(t, backend)
+			// Each attempt takes most of the timeout, so a lease of twice the
+			// timeout covers two attempts but not the whole batch
+			const timeout = 200 * time.Millisecond
+			receiver := newWebhookReceiver(t, http.StatusOK, 150*time.Millisecond)
+			sub, err := a.Webhooks.Create(ctx, &models.WebhookSubscription{URL: receiver.URL, EventTypes: models.EventAll, Secret: "s3cret", IsActive: true})
+			if err != nil {
+				t.Fatalf("Failed to create subscription: %v", err)
+			}
+			const deliveries = 5
+			for i := range deliveries {
+				if _, err := a.Restaurants.Create(ctx, &models.Restaurant{Name: fmt.Sprintf("Slow Spot %d", i), Address: "1 Slow St", Region: "Solna"}); err != nil {
+					t.Fatalf("Failed to create restaurant: %v", err)
+				}
+			}
+			if n, err := a.Outbox.Dispatch(ctx, deliveries); err != nil || n != deliveries {
+				t.Fatalf("Expected %d events to be dispatched, got %d (%v)", deliveries, n, err)
+			}
+
+			runCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+			defer cancel()
+			options := webhooks.Options{PollInterval: 20 * time.Millisecond, BatchSize: deliveries, Timeout: timeout, MaxAttempts: 3, BaseBackoff: time.Hour, MaxBackoff: time.Hour}
+			var wg sync.WaitGroup
+			for range 2 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					webhooks.NewDispatcher(a.Outbox, a.Webhooks, options).Run(runCtx)
+				}()
+			}
+			wg.Wait()
+
+			attempts := map[string]int{}
+			for _, req := range receiver.Requests() {
+				attempts[req.Header.Get(webhooks.HeaderDelivery)]++
+			}
+			if len(attempts) != deliveries {
+				t.Errorf("Expected %d deliveries to be attempted, got %v", deliveries, attempts)
+			}
+			for id, n := range attempts {
+				if n != 1 {
+					t.Errorf("Expected delivery %s to be sent once, got %d times", id, n)
+				}
+			}
+			if _, total, err := a.Webhooks.Deliveries(ctx, sub.ID, models.DeliverySucceeded, 10, 0); err != nil || total != deliveries {
+				t.Errorf("Expected %d succeeded deliveries, got %d (%v)", deliveries, total, err)
+			}
+		})
+	}
+}
+
+// newWebhookApp returns an empty app of the backend, so that the dispatcher
+// delivers no subscription or event of other tests
+func newWebhookApp(t *testing.T, backend string) *app.App {
+	t.Helper()
+	if backend == "memory" {
+		return app.NewMemory(memory.NewStore())
+	}
+	dsn := filepath.Join(t.TempDir(), "webhooks.db") + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_txlock=immediate"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := db.AutoMigrate(&models.Restaurant{}, &models.OutboxEvent{}, &models.WebhookSubscription{}, &models.WebhookDelivery{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+	return app.NewGorm(db)
+}
+
+type webhookRequest struct {
+	Header http.Header
+	Body   []byte
+}
+
+// webhookReceiver records the requests it receives and answers them with a
+// fixed status after a fixed delay
+type webhookReceiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []webhookRequest
+}
+
+func newWebhookReceiver(t *testing.T, status int, delay time.Duration) *webhookReceiver {
+	t.Helper()
+	receiver := &webhookReceiver{}
+	receiver.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		receiver.mu.Lock()
+		receiver.requests = append(receiver.requests, webhookRequest{Header: r.Header.Clone(), Body: body})
+		receiver.mu.Unlock()
+		time.Sleep(delay)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(receiver.Close)
+	return receiver
+}
+
+func (r *webhookReceiver) Requests() []webhookRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.requests)
+}
+
+// onlyDelivery returns the single delivery of a subscription
+func onlyDelivery(t *testing.T, repo repository.WebhookRepository, subscriptionID uint) models.WebhookDelivery {
+	t.Helper()
+	deliveries, _, err := repo.Deliveries(context.Background(), subscriptionID, "", 10, 0)
+	if err != nil || len(deliveries) != 1 {
+		t.Fatalf("Expected one delivery for subscription %d, got %v (%v)", subscriptionID, deliveries, err)
+	}
+	return deliveries[0]
+}
@@ -1,22 +1,31 @@
 package utils
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"os"
 	"time"
 
-	"lunch_menu/internal/database"
+	"lunch_menu/internal/config"
 	"lunch_menu/internal/models"
+	"lunch_menu/internal/repository"
 
 	"github.com/golang-jwt/jwt/v4"
 )
 
+// jwtSecret returns the HMAC signing key from the configuration.
+func jwtSecret() (string, error) {
+	if config.AppConfig == nil || config.AppConfig.Auth.JWTSecret == "" {
+		return "", errors.New("JWT_SECRET not set")
+	}
+	return config.AppConfig.Auth.JWTSecret, nil
+}
+
 // ParseJWT parses and validates a JWT token string and returns the claims.
 func ParseJWT(tokenString string) (map[string]interface{}, error) {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		return nil, errors.New("JWT_SECRET not set")
+	secret, err := jwtSecret()
+	if err != nil {
+		return nil, err
 	}
 
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
@@ -69,9 +78,9 @@ func ParseJWT(tokenString string) (map[string]interface{}, error) {
 
 // GenerateJWT generates a JWT token for the given user.
 func GenerateJWT(user *models.User) (string, error) {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		secret = "default_secret" // For development only; use env in production!
+	secret, err := jwtSecret()
+	if err != nil {
+		return "", err
 	}
 
 	claims := jwt.MapClaims{
@@ -85,9 +94,10 @@ func GenerateJWT(user *models.User) (string, error) {
 	return token.SignedString([]byte(secret))
 }
 
-// RenewAccessToken tries to renew an access token using a valid refresh token.
-// Returns new access token string, or error if not possible.
-func RenewAccessToken(refreshToken string, expiredToken string) (string, error) {
+// RenewAccessToken tries to renew an access token using a valid refresh token
+// stored in tokens for a user of users; their queries are traced as children
+// of ctx. Returns new access token string, or error if not possible.
+func RenewAccessToken(ctx context.Context, tokens repository.TokenRepository, users repository.UserRepository, refreshToken string, expiredToken string) (string, error) {
 	if refreshToken == "" || expiredToken == "" {
 		return "", errors.New("missing refresh or access token")
 	}
@@ -111,11 +121,11 @@ func RenewAccessToken(refreshToken string, expiredToken string) (string, error)
 		return "", errors.New("invalid user_id type in token")
 	}
 
-	rt, err := database.GetRefreshToken(userID, refreshToken)
+	rt, err := tokens.GetRefreshToken(ctx, userID, refreshToken)
 	if err != nil || rt == nil {
 		return "", errors.New("invalid or expired refresh token")
 	}
-	user, err := database.GetUserByID(userID)
+	user, err := users.GetByID(ctx, userID)
 	if err != nil {
 		return "", errors.New("user not found")
 	}
@@ -124,9 +134,8 @@ func RenewAccessToken(refreshToken string, expiredToken string) (string, error)
 
 // ParseJWTAllowExpired parses a JWT and returns claims even if the token is expired.
 func ParseJWTAllowExpired(tokenString string) (map[string]interface{}, error) {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		return nil, errors.New("JWT_SECRET not set")
+	if _, err := jwtSecret(); err != nil {
+		return nil, err
 	}
 
 	parser := jwt.Parser{
@@ -3,7 +3,6 @@ package utils
 import (
 	"lunch_menu/internal/config"
 	"lunch_menu/internal/models"
-	"os"
 
 	"github.com/gin-gonic/gin"
 )
@@ -17,9 +16,9 @@ func Respond(c *gin.Context, status int, message string, data interface{}, err *
 	})
 }
 
-// SetAuthCookies sets access and refresh token cookies using config from .env
+// SetAuthCookies sets access and refresh token cookies using the cookie configuration
 func SetAuthCookies(c *gin.Context, accessToken, refreshToken, csrfToken string) {
-	cookieCfg := config.GetCookieConfig()
+	cookieCfg := config.AppConfig.Cookie
 
 	accessAge := cookieCfg.AccessCookieAge
 	if accessAge == 0 {
@@ -71,8 +70,8 @@ func SetAuthCookies(c *gin.Context, accessToken, refreshToken, csrfToken string)
 
 // ExpireAuthCookies expires access and refresh token cookies.
 func ExpireAuthCookies(c *gin.Context) {
-	cookieCfg := config.GetCookieConfig()
-	c.SetCookie(os.Getenv("ACCESS_COOKIE_NAME"), "", -1, cookieCfg.Path, cookieCfg.Domain, cookieCfg.Secure, cookieCfg.HTTPOnly)
-	c.SetCookie(os.Getenv("REFRESH_COOKIE_NAME"), "", -1, cookieCfg.Path, cookieCfg.Domain, cookieCfg.Secure, cookieCfg.HTTPOnly)
-	c.SetCookie(os.Getenv("CSRF_COOKIE_NAME"), "", -1, cookieCfg.Path, cookieCfg.Domain, cookieCfg.Secure, false) // Not HttpOnly
+	cookieCfg := config.AppConfig.Cookie
+	c.SetCookie(cookieCfg.AccessCookieName, "", -1, cookieCfg.Path, cookieCfg.Domain, cookieCfg.Secure, cookieCfg.HTTPOnly)
+	c.SetCookie(cookieCfg.RefreshCookieName, "", -1, cookieCfg.Path, cookieCfg.Domain, cookieCfg.Secure, cookieCfg.HTTPOnly)
+	c.SetCookie(cookieCfg.CSRFCookieName, "", -1, cookieCfg.Path, cookieCfg.Domain, cookieCfg.Secure, false) // Not HttpOnly
 }
@@ -0,0 +1,120 @@
+// Package views records anonymous view events of restaurants, menus and menu
+// items without slowing down the requests that view them: events are queued
+// in memory and written in batches by a background worker. When the queue is
+// full, e.g. while the database is slow, further events are dropped and
+// counted in the lunch_menu_views_dropped_total metric.
+package views
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"lunch_menu/internal/metrics"
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/repository"
+)
+
+// Options configures the Recorder
+type Options struct {
+	BufferSize    int           // events queued before further ones are dropped
+	BatchSize     int           // events written per insert; a full batch is written right away
+	FlushInterval time.Duration // how often queued events are written
+}
+
+// DefaultOptions returns the default recorder settings
+func DefaultOptions() Options {
+	return Options{
+		BufferSize:    10000,
+		BatchSize:     500,
+		FlushInterval: 5 * time.Second,
+	}
+}
+
+// Recorder queues view events and writes them to a ViewRepository
+type Recorder struct {
+	repo  repository.ViewRepository
+	opts  Options
+	queue chan models.ViewEvent
+	full  chan struct{} // signals Run that a batch is ready
+	mu    sync.Mutex    // serializes Flush
+}
+
+// NewRecorder returns a recorder writing to repo
+func NewRecorder(repo repository.ViewRepository, opts Options) *Recorder {
+	return &Recorder{
+		repo:  repo,
+		opts:  opts,
+		queue: make(chan models.ViewEvent, opts.BufferSize),
+		full:  make(chan struct{}, 1),
+	}
+}
+
+// Record queues a view event, viewed now unless ViewedAt is set. It never
+// blocks; the event is dropped if the queue is full.
+func (r *Recorder) Record(view models.ViewEvent) {
+	if view.ViewedAt.IsZero() {
+		view.ViewedAt = time.Now()
+	}
+	view.ViewedAt = view.ViewedAt.UTC().Truncate(time.Minute)
+	select {
+	case r.queue <- view:
+	default:
+		metrics.ViewsDropped.Inc()
+		return
+	}
+	if len(r.queue) >= r.opts.BatchSize {
+		select {
+		case r.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Run writes the queued events every FlushInterval and whenever a batch is
+// full, until ctx is done; the events queued by then are written before it
+// returns.
+func (r *Recorder) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.opts.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			r.flush(context.WithoutCancel(ctx))
+			return
+		case <-ticker.C:
+			r.flush(ctx)
+		case <-r.full:
+			r.flush(ctx)
+		}
+	}
+}
+
+// Flush writes the queued events in batches and returns the first error;
+// the events of a failed batch are lost
+func (r *Recorder) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var firstErr error
+	for {
+		n := min(len(r.queue), r.opts.BatchSize)
+		if n == 0 {
+			return firstErr
+		}
+		batch := make([]models.ViewEvent, n)
+		for i := range batch {
+			batch[i] = <-r.queue
+		}
+		if err := r.repo.Record(ctx, batch); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+}
+
+// flush writes the queued events and logs a failure
+func (r *Recorder) flush(ctx context.Context) {
+	if err := r.Flush(ctx); err != nil {
+		slog.Error("views: failed to write view events", slog.Any("error", err))
+	}
+}
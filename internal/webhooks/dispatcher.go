@@ -0,0 +1,235 @@
+// Package webhooks delivers outbox events to webhook subscriptions
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"lunch_menu/internal/apperrors"
+	"lunch_menu/internal/models"
+	"lunch_menu/internal/repository"
+)
+
+// Headers sent with every webhook request
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Options configures the Dispatcher
+type Options struct {
+	PollInterval time.Duration // how often the outbox and due deliveries are checked
+	BatchSize    int           // events or deliveries handled per poll
+	Timeout      time.Duration // HTTP timeout of a single delivery attempt
+	MaxAttempts  int           // attempts before a delivery goes dead
+	BaseBackoff  time.Duration // delay after the first failure, doubled after each further one
+	MaxBackoff   time.Duration // upper bound of the retry delay
+}
+
+// DefaultOptions returns the default dispatcher settings
+func DefaultOptions() Options {
+	return Options{
+		PollInterval: 2 * time.Second,
+		BatchSize:    50,
+		Timeout:      10 * time.Second,
+		MaxAttempts:  8,
+		BaseBackoff:  30 * time.Second,
+		MaxBackoff:   time.Hour,
+	}
+}
+
+// Envelope is the JSON body posted to webhook receivers
+type Envelope struct {
+	ID         uint            `json:"id"` // outbox event ID, stable across retries
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// Dispatcher turns outbox events into deliveries and delivers them
+// asynchronously with HMAC-SHA256 signatures and exponential-backoff retries.
+type Dispatcher struct {
+	outbox   repository.OutboxRepository
+	webhooks repository.WebhookRepository
+	opts     Options
+	client   *http.Client
+}
+
+// NewDispatcher creates a Dispatcher that delivers the events of outbox to
+// the subscriptions of webhooks
+func NewDispatcher(outbox repository.OutboxRepository, webhooks repository.WebhookRepository, opts Options) *Dispatcher {
+	return &Dispatcher{
+		outbox:   outbox,
+		webhooks: webhooks,
+		opts:     opts,
+		client:   &http.Client{Timeout: opts.Timeout},
+	}
+}
+
+// Run polls the outbox and due deliveries until ctx is cancelled
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		d.Poll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll turns undispatched outbox events into deliveries and makes one attempt
+// at up to BatchSize due deliveries
+func (d *Dispatcher) Poll(ctx context.Context) {
+	if _, err := d.outbox.Dispatch(ctx, d.opts.BatchSize); err != nil {
+		slog.Error("webhooks: failed to dispatch outbox events", slog.Any("error", err))
+	}
+
+	// Claim one delivery at a time, right before attempting it, for longer
+	// than an attempt can take, so that no other replica picks it up in the
+	// meantime; a whole batch would need a lease covering all its attempts.
+	for range d.opts.BatchSize {
+		if ctx.Err() != nil {
+			return
+		}
+		deliveries, err := d.webhooks.ClaimDueDeliveries(ctx, 1, 2*d.opts.Timeout)
+		if err != nil {
+			slog.Error("webhooks: failed to claim deliveries", slog.Any("error", err))
+			return
+		}
+		if len(deliveries) == 0 {
+			return
+		}
+		d.deliver(ctx, &deliveries[0])
+	}
+}
+
+// deliver makes one attempt and records its outcome. Deliveries to deleted
+// or inactive subscriptions are skipped instead, as retrying cannot succeed.
+func (d *Dispatcher) deliver(ctx context.Context, delivery *models.WebhookDelivery) {
+	var status int
+	sub, err := d.webhooks.GetByID(ctx, delivery.SubscriptionID)
+	switch {
+	case errors.Is(err, apperrors.ErrNotFound):
+		d.skip(ctx, delivery, fmt.Sprintf("subscription %d was deleted", delivery.SubscriptionID))
+		return
+	case err != nil:
+		err = fmt.Errorf("subscription: %w", err)
+	case !sub.IsActive:
+		d.skip(ctx, delivery, fmt.Sprintf("subscription %d is inactive", sub.ID))
+		return
+	default:
+		status, err = d.attempt(ctx, sub, delivery)
+	}
+	delivery.Attempts++
+	delivery.ResponseStatus = status
+
+	now := time.Now()
+	switch {
+	case err == nil:
+		delivery.Status = models.DeliverySucceeded
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= d.opts.MaxAttempts:
+		delivery.Status = models.DeliveryDead
+		delivery.LastError = err.Error()
+	default:
+		delivery.Status = models.DeliveryPending
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = now.Add(Backoff(delivery.Attempts, d.opts.BaseBackoff, d.opts.MaxBackoff))
+	}
+	d.save(ctx, delivery)
+}
+
+// skip records that a delivery was not attempted and will not be retried
+func (d *Dispatcher) skip(ctx context.Context, delivery *models.WebhookDelivery, reason string) {
+	delivery.Status = models.DeliverySkipped
+	delivery.LastError = reason
+	d.save(ctx, delivery)
+}
+
+func (d *Dispatcher) save(ctx context.Context, delivery *models.WebhookDelivery) {
+	if err := d.webhooks.SaveDelivery(context.WithoutCancel(ctx), delivery); err != nil {
+		slog.Error("webhooks: failed to save delivery", slog.Uint64("delivery_id", uint64(delivery.ID)), slog.Any("error", err))
+	}
+}
+
+func (d *Dispatcher) attempt(ctx context.Context, sub *models.WebhookSubscription, delivery *models.WebhookDelivery) (int, error) {
+	event, err := d.outbox.GetByID(ctx, delivery.EventID)
+	if err != nil {
+		return 0, fmt.Errorf("event: %w", err)
+	}
+
+	body, err := json.Marshal(Envelope{
+		ID:         event.ID,
+		Type:       event.EventType,
+		OccurredAt: event.CreatedAt,
+		Data:       json.RawMessage(event.Payload),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "lunch-menu-webhooks/1.0")
+	req.Header.Set(HeaderEvent, event.EventType)
+	req.Header.Set(HeaderDelivery, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(sub.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("receiver responded with %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the signature header value for a webhook body:
+// "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + body)).
+// Receivers should recompute it and compare in constant time, and reject old timestamps.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff returns the delay before the next attempt after the given number
+// of failed attempts: base, 2*base, 4*base, ... capped at max.
+func Backoff(attempts int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	return delay
+}
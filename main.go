@@ -1,66 +1,192 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"lunch_menu/internal/app"
 	"lunch_menu/internal/config"
 	"lunch_menu/internal/database"
+	"lunch_menu/internal/events"
+	"lunch_menu/internal/grpcserver"
+	"lunch_menu/internal/logging"
+	"lunch_menu/internal/metrics"
 	"lunch_menu/internal/middleware"
 	"lunch_menu/internal/routes"
+	"lunch_menu/internal/tracing"
+	"lunch_menu/internal/webhooks"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
 
 	_ "lunch_menu/docs" // docs is generated by Swag CLI, you have to import it.
 
-	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"google.golang.org/grpc"
 )
 
 func main() {
-	// Load configuration
+	// Subcommands: "import" loads restaurants and menus from CSV/JSON files,
+	// "config print" shows the effective configuration
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		os.Exit(runImport(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		os.Exit(runConfig(os.Args[2:]))
+	}
+
+	// Load configuration from CONFIG_FILE, the environment and *_FILE secrets
 	config.MustLoadConfig()
+	cfg := config.AppConfig
+
+	// Structured JSON logs; the standard log package is routed through slog too
+	logging.Setup(logging.Options{Level: cfg.Log.Level, Format: cfg.Log.Format})
+
+	// OpenTelemetry tracing of requests and queries
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		Exporter: cfg.Tracing.Exporter,
+		FilePath: cfg.Tracing.File,
+	})
+	if err != nil {
+		logging.Fatal("Tracing setup failed", slog.Any("error", err))
+	}
 
 	// Initialize database
 	if err := database.InitDatabase(); err != nil {
-		log.Fatalf("DB init failed: %v", err)
+		logging.Fatal("DB init failed", slog.Any("error", err))
 	}
 	if err := database.Migrate(); err != nil {
-		log.Fatalf("DB migration failed: %v", err)
+		logging.Fatal("DB migration failed", slog.Any("error", err))
+	}
+	if sqlDB, err := database.DB.DB(); err == nil {
+		if err := metrics.RegisterDB(sqlDB, cfg.Database.Name); err != nil {
+			slog.Warn("Failed to register database metrics", slog.Any("error", err))
+		}
 	}
-	defer database.CloseDatabase()
+	// Repositories shared by the REST, GraphQL and gRPC APIs
+	application := app.NewGorm(database.DB)
+
+	// Background workers run until shutdown, after the servers are drained
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+
+	// Deliver outbox events to webhook subscribers in the background
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		webhooks.NewDispatcher(application.Outbox, application.Webhooks, webhooks.DefaultOptions()).Run(workersCtx)
+	}()
+
+	// Apply scheduled price changes once they take effect
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		application.RunPriceScheduler(workersCtx, cfg.Pricing.ScheduleInterval)
+	}()
+
+	// Write the queued view events of restaurants, menus and menu items in batches
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		application.ViewRecorder.Run(workersCtx)
+	}()
+
+	// Fan out change events to SSE clients, across replicas via LISTEN/NOTIFY
+	brokerOptions := events.DefaultOptions()
+	if database.IsPostgres(database.DB) {
+		brokerOptions.ListenDSN = database.DSN()
+	}
+	broker := events.Start(workersCtx, application.Outbox, brokerOptions)
+
+	// Keep the precomputed statistics current and drop cached ones on changes
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		application.Statistics.Run(workersCtx, broker)
+	}()
+
+	// Create Gin router; requests are logged as JSON with their request ID
+	// instead of gin's default text logger
+	router := gin.New()
+	// Spans for every request, continuing the caller's W3C traceparent
+	router.Use(otelgin.Middleware(tracing.ServiceName))
+	router.Use(middleware.RequestID, middleware.RequestLogger, gin.CustomRecoveryWithWriter(io.Discard, middleware.Recovery))
+
+	// HSTS, CSP, nosniff, Referrer-Policy and framing protection on every response
+	router.Use(middleware.SecurityHeaders(cfg.Security))
+
+	// Request counts and latencies for Prometheus
+	router.Use(middleware.Metrics)
 
-	// Create Gin router
-	router := gin.Default()
+	// Render errors added with c.Error as RFC 7807 problem details
+	router.Use(middleware.ErrorHandler)
 
-	// Clean: Use rate limiting middleware from your package
-	router.Use(middleware.RateLimitMiddleware("10-S"))
+	// Browser origins allowed to call the API, from cors.allowed_origins; before
+	// the rate limiter so that browsers can read its 429 responses
+	router.Use(middleware.CORS(cfg.CORS))
+
+	// Request budgets per client IP or user, shared by the replicas with the redis store;
+	// login, registration and admin writes have their own tiers, see routes
+	limits, err := middleware.NewRateLimiter(cfg.RateLimit)
+	if err != nil {
+		logging.Fatal("Rate limiter setup failed", slog.Any("error", err))
+	}
+	router.Use(limits.Limit(middleware.TierAPI))
 
 	//will apply CSRF checks to all requests that match the HTTP methods you specify in the middleware (usually POST, PUT, PATCH, DELETE).
 	// router.Use(middleware.CSRFProtection())
 
-	// Use gin-contrib/cors middleware
-	corsConfig := cors.DefaultConfig()
-	corsConfig.AllowAllOrigins = true //  should restrict AllowAllOrigins for security in production
-	corsConfig.AllowCredentials = true
-	corsConfig.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-	corsConfig.AllowHeaders = []string{"*"}
-	router.Use(cors.New(corsConfig))
+	// Setup all routes and middleware
+	routes.SetupRoutes(router, application, limits)
+	router.GET("/swagger/*any",
+		middleware.OverrideHeaders(map[string]string{"Content-Security-Policy": middleware.SwaggerCSP}),
+		ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	// CORS does not affect Postman or server-to-server requests. It is safe to enable for APIs, but you should restrict Access-Control-Allow-Origin in production.
-	// router.Use(middleware.CORSMiddleware())
+	// Prometheus metrics, on a separate admin port if metrics.port is set
+	var adminServer *http.Server
+	metricsHandlers := []gin.HandlerFunc{middleware.MetricsAuth(cfg.Metrics.Token), gin.WrapH(metrics.Handler())}
+	if cfg.Metrics.Port != 0 {
+		metricsPort := strconv.Itoa(cfg.Metrics.Port)
+		admin := gin.New()
+		admin.Use(gin.CustomRecoveryWithWriter(io.Discard, middleware.Recovery))
+		admin.GET("/metrics", metricsHandlers...)
+		adminServer = newHTTPServer(":"+metricsPort, admin, cfg)
+		go func() {
+			slog.Info("Serving metrics on admin port", slog.String("port", metricsPort))
+			if err := adminServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logging.Fatal("Failed to start metrics server", slog.Any("error", err))
+			}
+		}()
+	} else {
+		router.GET("/metrics", metricsHandlers...)
+	}
 
-	// Setup all routes and middleware
-	routes.SetupRoutes(router)
-	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	port := strconv.Itoa(cfg.Server.Port)
 
-	// Get port from environment or default to 8000
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8000"
+	// gRPC API on its own port, sharing the repositories and JWT auth
+	grpcPort := strconv.Itoa(cfg.Server.GRPCPort)
+	grpcListener, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		logging.Fatal("Failed to listen on gRPC port", slog.String("port", grpcPort), slog.Any("error", err))
 	}
+	grpcServer := grpcserver.New(application)
+	go func() {
+		slog.Info("Starting gRPC API", slog.String("port", grpcPort))
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			logging.Fatal("Failed to start gRPC server", slog.Any("error", err))
+		}
+	}()
 
-	log.Printf("Starting Restaurant Management API on port %s", port)
-	log.Printf("Swagger documentation available at: http://localhost:%s/swagger/index.html#/", port)
+	slog.Info("Starting Restaurant Management API", slog.String("port", port))
+	slog.Info("Swagger documentation available at: http://localhost:" + port + "/swagger/index.html#/")
 	// log.Printf("API endpoints:")
 	// log.Printf("  GET    /api")
 	// log.Printf("  GET    /api/restaurants")
@@ -69,7 +195,69 @@ func main() {
 	// log.Printf("  GET    /api/menu-items/:id")
 	// log.Printf("  GET    /api/stats")
 
-	if err := router.Run(":" + port); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	server := newHTTPServer(":"+port, router, cfg)
+	// End SSE streams when shutdown starts; they never become idle, so they
+	// would otherwise hold the drain until the deadline. Clients resume on
+	// another replica with Last-Event-ID.
+	server.RegisterOnShutdown(broker.Close)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logging.Fatal("Failed to start server", slog.Any("error", err))
+		}
+	}()
+
+	// Wait for SIGINT or SIGTERM (sent by Kubernetes during rollouts)
+	signals, stopSignals := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stopSignals()
+	<-signals.Done()
+	slog.Info("Shutting down, draining in-flight requests", slog.Duration("timeout", cfg.Server.ShutdownTimeout))
+
+	// Stop accepting connections and wait for in-flight requests until the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		slog.Error("HTTP server did not drain in time", slog.Any("error", err))
+	}
+	if adminServer != nil {
+		_ = adminServer.Shutdown(ctx)
+	}
+	stopGRPC(ctx, grpcServer)
+
+	// Then the background workers, tracing and finally the database
+	stopWorkers()
+	workers.Wait()
+	limits.Close()
+	if err := shutdownTracing(ctx); err != nil {
+		slog.Error("Failed to flush traces", slog.Any("error", err))
+	}
+	if err := database.CloseDatabase(); err != nil {
+		slog.Error("Failed to close database", slog.Any("error", err))
+	}
+	slog.Info("Shutdown complete")
+}
+
+// newHTTPServer returns a server for handler with the configured timeouts
+func newHTTPServer(addr string, handler http.Handler, cfg *config.Config) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+	}
+}
+
+// stopGRPC waits for in-flight RPCs until ctx is done, then closes the remaining connections
+func stopGRPC(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
 	}
 }